@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/snowflake"
+)
+
+// runDecodeId 解析雪花算法 id，输出生成时间、业务 hash 与自增序列。
+// hash 由业务 id 与业务 key 计算而来，用于定位分库分表。
+func runDecodeId(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: kuryr-admin decode-id <id>...")
+	}
+
+	ids := make([]uint64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid id %q: %w", arg, err)
+		}
+		ids = append(ids, id)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTIMESTAMP\tHASH\tSEQUENCE")
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(
+			w, "%d\t%s\t%d\t%d\n",
+			id,
+			snowflake.ExtractTimestamp(id).Format(time.RFC3339Nano),
+			snowflake.ExtractHash(id),
+			snowflake.ExtractSequence(id),
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+)
+
+// runGenJwtKeys 生成 jwt 签名使用的 Ed25519 密钥对，输出 PKCS#8 私钥与 PKIX 公钥的 PEM。
+// 未指定 --dir 时输出到标准输出，可以直接粘贴到配置文件的 jwt.private 与 jwt.public。
+func runGenJwtKeys(args []string) error {
+	fs := pflag.NewFlagSet("gen-jwt-keys", pflag.ContinueOnError)
+	dir := fs.String("dir", "", "密钥输出目录，生成 private.pem 与 public.pem")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: kuryr-admin gen-jwt-keys [--dir <dir>]")
+	}
+
+	private, public, err := genJwtKeys()
+	if err != nil {
+		return err
+	}
+
+	if *dir == "" {
+		fmt.Print(string(private))
+		fmt.Print(string(public))
+		return nil
+	}
+
+	if err = os.MkdirAll(*dir, 0o700); err != nil {
+		return err
+	}
+
+	privatePath := filepath.Join(*dir, "private.pem")
+	publicPath := filepath.Join(*dir, "public.pem")
+	// 不覆盖已存在的密钥，避免误操作导致已签发的 token 全部失效
+	for _, path := range []string{privatePath, publicPath} {
+		if _, err = os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+
+	if err = os.WriteFile(privatePath, private, 0o600); err != nil {
+		return err
+	}
+	if err = os.WriteFile(publicPath, public, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("private key: %s\npublic key: %s\n", privatePath, publicPath)
+	return nil
+}
+
+func genJwtKeys() (private, public []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	private = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	public = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return private, public, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	easyjwt "github.com/JrMarcco/easy-kit/jwt"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenJwtKeys(t *testing.T) {
+	t.Parallel()
+
+	private, public, err := genJwtKeys()
+	require.NoError(t, err)
+
+	manager, err := easyjwt.NewEd25519ManagerBuilder[pkggin.AuthUser](string(private), string(public)).
+		ClaimsConfig(easyjwt.NewClaimsConfig(time.Minute)).
+		Build()
+	require.NoError(t, err)
+
+	au := pkggin.AuthUser{Sid: "sid", Uid: 1}
+	token, err := manager.Encrypt(au)
+	require.NoError(t, err)
+
+	claims, err := manager.Decrypt(token)
+	require.NoError(t, err)
+	assert.Equal(t, au, claims.Data)
+}
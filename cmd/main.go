@@ -1,61 +1,169 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
 	"github.com/JrMarcco/kuryr-admin/internal/ioc"
 	"github.com/spf13/pflag"
-	"github.com/spf13/viper"
 	"go.uber.org/fx"
-	"go.uber.org/fx/fxevent"
-	"go.uber.org/zap"
 )
 
-func main() {
-	initViper()
+var (
+	configFile  = pflag.String("config", "etc/config.yaml", "配置文件路径")
+	printConfig = pflag.Bool("print-config", false, "打印脱敏后的最终配置并退出")
+	fakeBackend = pflag.Bool("dev-fake-backend", false, "使用进程内的 fake kuryr 后端，等同于 dev.fake_backend: true")
+)
 
-	fx.New(
-		fx.WithLogger(func(logger *zap.Logger) fxevent.Logger {
-			return &fxevent.ZapLogger{Logger: logger}
-		}),
+// command 子命令。
+// 全局参数需要放在子命令之前，子命令之后的参数由子命令自己解析。
+type command struct {
+	usage string
+	desc  string
+	run   func(args []string) error
+}
 
-		// 初始化 zap.Logger
-		ioc.LoggerFxOpt,
-		// 初始化 redis.Client
-		ioc.RedisFxOpt,
-		// 初始化 gorm.DB
-		ioc.DBFxOpt,
-		// 初始化 etcd
-		ioc.EtcdFxOpt,
-		// 初始化 grpc registry
-		ioc.RegistryFxOpt,
-		// 初始化 grpc client manager
-		ioc.GrpcClientFxOpt,
-		// 初始化 jwt manager
-		ioc.JwtManagerOpt,
-		// 初始化 middleware builder
-		ioc.MiddlewareBuilderOpt,
-		// 初始化 repo
-		ioc.RepoFxOpt,
-		// 初始化 service
-		ioc.ServiceFxOpt,
-		// 初始化 handler
-		ioc.HandlerFxOpt,
-
-		// 注册 gin 路由，需要在 app 启动前完成
-		// ioc.HandlerFxInvoke,
-
-		// 初始化 ioc.App
-		ioc.AppFxOpt,
-	).Run()
-}
-
-// initViper 初始化 viper
-func initViper() {
-	configFile := pflag.String("config", "etc/config.yaml", "配置文件路径")
+var commands = map[string]command{
+	"serve": {
+		usage: "serve",
+		desc:  "启动 http 服务（默认命令）",
+		run:   runServe,
+	},
+	"migrate": {
+		usage: "migrate up|down|status",
+		desc:  "执行数据库迁移",
+		run:   runMigrate,
+	},
+	"create-admin": {
+		usage: "create-admin --email <email> --name <real name>",
+		desc:  "创建管理员，密码从终端读取",
+		run:   runCreateAdmin,
+	},
+	"reset-password": {
+		usage: "reset-password --email <email>",
+		desc:  "重置用户密码并清理该用户的所有 session",
+		run:   runResetPassword,
+	},
+	"revoke-sessions": {
+		usage: "revoke-sessions --user <user id>",
+		desc:  "清理用户的所有 session",
+		run:   runRevokeSessions,
+	},
+	"gen-jwt-keys": {
+		usage: "gen-jwt-keys [--dir <dir>]",
+		desc:  "生成 jwt 使用的 Ed25519 密钥对",
+		run:   runGenJwtKeys,
+	},
+	"decode-id": {
+		usage: "decode-id <id>...",
+		desc:  "解析雪花算法 id",
+		run:   runDecodeId,
+	},
+}
+
+func main() {
+	pflag.CommandLine.SetInterspersed(false)
+	pflag.Usage = usage
 	pflag.Parse()
 
-	viper.SetConfigFile(*configFile)
-	viper.SetConfigType("yaml")
-	if err := viper.ReadInConfig(); err != nil {
-		panic(err)
+	if *printConfig {
+		if err := runPrintConfig(); err != nil {
+			exit(err)
+		}
+		return
+	}
+
+	// 未指定子命令时启动服务，兼容之前的启动方式
+	name, args := "serve", pflag.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		usage()
+		exit(fmt.Errorf("unknown command %q", name))
+	}
+	if err := cmd.run(args); err != nil {
+		exit(err)
+	}
+}
+
+func usage() {
+	var sb strings.Builder
+	sb.WriteString("usage: kuryr-admin [flags] [command]\n\ncommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(&sb, "  %-50s %s\n", commands[name].usage, commands[name].desc)
+	}
+	sb.WriteString("\nflags:\n")
+	sb.WriteString(pflag.CommandLine.FlagUsages())
+
+	_, _ = fmt.Fprint(os.Stderr, sb.String())
+}
+
+func exit(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// loadConfig 加载并校验配置。
+func loadConfig() (*config.Reloader, error) {
+	cfg, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return config.NewReloader(*configFile, cfg), nil
+}
+
+// readConfig 读取配置文件，命令行参数通过环境变量覆盖配置，保证配置热加载后仍然生效。
+func readConfig() (*config.Config, error) {
+	if *fakeBackend {
+		if err := os.Setenv(config.EnvPrefix+"_DEV_FAKE_BACKEND", "true"); err != nil {
+			return nil, err
+		}
+	}
+	return config.Load(*configFile)
+}
+
+// runPrintConfig 打印脱敏后的最终配置，配置不合法时打印配置后返回校验错误。
+func runPrintConfig() error {
+	cfg, err := readConfig()
+	if err != nil {
+		return err
+	}
+
+	out, err := cfg.RedactedYAML()
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+
+	return cfg.Validate()
+}
+
+// newCmdApp 创建子命令使用的 fx app。
+// 子命令与服务共用同一套模块，只按需引入子命令依赖的部分，且不会启动 app。
+func newCmdApp(reloader *config.Reloader, opts ...fx.Option) error {
+	app := fx.New(
+		fx.NopLogger,
+		fx.RecoverFromPanics(),
+		fx.Supply(reloader.Current(), reloader),
+		ioc.LoggerFxOpt,
+		ioc.MetricsFxOpt,
+		ioc.TracingFxOpt,
+		fx.Options(opts...),
+	)
+	return app.Err()
 }
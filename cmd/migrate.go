@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/ioc"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/migrate"
+	"go.uber.org/fx"
+)
+
+const migrateUsage = "usage: kuryr-admin [flags] migrate up|down|status"
+
+// runMigrate 执行数据库迁移命令，只初始化数据库相关的模块。
+//
+//	up     执行所有未执行的迁移
+//	down   回滚最近执行的一个迁移
+//	status 打印所有迁移的执行状态
+func runMigrate(args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	reloader, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var migrator *migrate.Migrator
+	if err = newCmdApp(reloader, ioc.DBFxOpt, fx.Populate(&migrator)); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if errors.Is(err, migrate.ErrNoChange) {
+			fmt.Println("database schema is up to date")
+			return nil
+		}
+		return err
+	case "down":
+		m, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d_%s\n", m.Version, m.Name)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			status, appliedAt := "pending", "-"
+			if s.Applied {
+				status, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Unknown {
+				status = "unknown"
+			}
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/ioc"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/zap"
+)
+
+// runServe 启动 http 服务，直到收到退出信号。
+func runServe(args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: kuryr-admin [flags] serve")
+	}
+
+	reloader, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg := reloader.Current()
+
+	fx.New(
+		// 停机超时时间需要覆盖排空时间和处理中请求的完成时间
+		fx.StopTimeout(time.Duration(cfg.App.StopTimeout)*time.Millisecond),
+		fx.Supply(cfg, reloader),
+		fx.WithLogger(func(logger *zap.Logger) fxevent.Logger {
+			return &fxevent.ZapLogger{Logger: logger}
+		}),
+
+		ioc.Modules(cfg),
+	).Run()
+	return nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/ioc"
+	"github.com/JrMarcco/kuryr-admin/internal/repository"
+	ijwt "github.com/JrMarcco/kuryr-admin/internal/web/jwt"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/pflag"
+	"go.uber.org/fx"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+)
+
+const minPasswordLen = 8
+
+// runCreateAdmin 创建管理员账号，替代原先 sql 脚本中写死的初始用户。
+func runCreateAdmin(args []string) error {
+	const usage = "usage: kuryr-admin [flags] create-admin --email <email> --name <real name>"
+
+	fs := pflag.NewFlagSet("create-admin", pflag.ContinueOnError)
+	email := fs.String("email", "", "管理员邮箱，作为登录账号")
+	name := fs.String("name", "", "管理员姓名")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || *name == "" || fs.NArg() != 0 {
+		return errors.New(usage)
+	}
+
+	reloader, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var repo repository.UserRepo
+	if err = newCmdApp(reloader, ioc.DBFxOpt, ioc.RepoFxOpt, fx.Populate(&repo)); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	_, err = repo.FindByEmail(ctx, *email)
+	if err == nil {
+		return fmt.Errorf("user %s already exists", *email)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	hashed, err := readPassword()
+	if err != nil {
+		return err
+	}
+
+	u, err := repo.Save(ctx, domain.SysUser{
+		Email:    *email,
+		Password: hashed,
+		RealName: *name,
+		UserType: domain.UserTypeAdmin,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created administrator %s (id %d)\n", u.Email, u.Id)
+	return nil
+}
+
+// runResetPassword 重置用户密码，同时清理该用户已登录的 session。
+func runResetPassword(args []string) error {
+	const usage = "usage: kuryr-admin [flags] reset-password --email <email>"
+
+	fs := pflag.NewFlagSet("reset-password", pflag.ContinueOnError)
+	email := fs.String("email", "", "用户邮箱")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || fs.NArg() != 0 {
+		return errors.New(usage)
+	}
+
+	reloader, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var (
+		repo repository.UserRepo
+		rc   redis.Cmdable
+	)
+	err = newCmdApp(reloader, ioc.DBFxOpt, ioc.RepoFxOpt, ioc.RedisFxOpt, fx.Populate(&repo, &rc))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	u, err := repo.FindByEmail(ctx, *email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user %s not found", *email)
+		}
+		return err
+	}
+
+	hashed, err := readPassword()
+	if err != nil {
+		return err
+	}
+
+	u.Password = hashed
+	if _, err = repo.Save(ctx, u); err != nil {
+		return err
+	}
+	fmt.Printf("password of %s has been reset\n", u.Email)
+
+	cleared, err := newSessionHandler(rc, reloader.Current().Session.Expiration).ClearUserSessions(ctx, u.Id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions of user %d: %w", u.Id, err)
+	}
+	fmt.Printf("revoked %d session(s)\n", cleared)
+	return nil
+}
+
+// runRevokeSessions 清理用户的所有 session，用户需要重新登录。
+// 已签发的 access token 在过期前仍然有效，但刷新 token 时会因为 session 不存在而失败。
+func runRevokeSessions(args []string) error {
+	const usage = "usage: kuryr-admin [flags] revoke-sessions --user <user id>"
+
+	fs := pflag.NewFlagSet("revoke-sessions", pflag.ContinueOnError)
+	uid := fs.Uint64("user", 0, "用户 id")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *uid == 0 || fs.NArg() != 0 {
+		return errors.New(usage)
+	}
+
+	reloader, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var rc redis.Cmdable
+	if err = newCmdApp(reloader, ioc.RedisFxOpt, fx.Populate(&rc)); err != nil {
+		return err
+	}
+
+	cleared, err := newSessionHandler(rc, reloader.Current().Session.Expiration).ClearUserSessions(context.Background(), *uid)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("revoked %d session(s) of user %d\n", cleared, *uid)
+	return nil
+}
+
+func newSessionHandler(rc redis.Cmdable, expiration int) *ijwt.RedisHandler {
+	return ijwt.NewRedisHandler(rc, time.Duration(expiration)*time.Second)
+}
+
+// readPassword 读取密码并返回 bcrypt 哈希。
+// 标准输入为终端时关闭回显并要求输入两次，否则读取一行，便于在脚本中通过管道传入。
+func readPassword() (string, error) {
+	var passwd string
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		_, _ = fmt.Fprint(os.Stderr, "password: ")
+		first, err := term.ReadPassword(fd)
+		_, _ = fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+
+		_, _ = fmt.Fprint(os.Stderr, "confirm password: ")
+		second, err := term.ReadPassword(fd)
+		_, _ = fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+
+		if string(first) != string(second) {
+			return "", errors.New("passwords do not match")
+		}
+		passwd = string(first)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+		passwd = strings.TrimRight(line, "\r\n")
+	}
+
+	if len(passwd) < minPasswordLen {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLen)
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(passwd), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
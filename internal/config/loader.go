@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	EnvPrefix = "KURYR_ADMIN"
+
+	// fileSuffix 敏感配置项从文件读取时使用的后缀
+	fileSuffix = "_file"
+)
+
+// Load 按以下顺序加载配置，后加载的覆盖先加载的：
+//
+//  1. 配置默认值（Default）
+//  2. 基础配置文件，例如 etc/config.yaml
+//  3. profile 配置文件，例如 etc/config.prod.yaml，profile 取自 profile.env（可以通过环境变量覆盖），文件不存在时忽略
+//  4. KURYR_ADMIN_ 前缀的环境变量，例如 KURYR_ADMIN_DB_DSN 覆盖 db.dsn
+//  5. 敏感配置项的 <key>_file，例如 db.dsn_file 指定的文件内容覆盖 db.dsn
+//
+// Load 不校验配置，需要调用方调用 Config.Validate。
+func Load(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to read config file %s: %w", path, err)
+	}
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	for _, key := range keys(reflect.TypeFor[Config](), "") {
+		if err := v.BindEnv(key); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range secretKeys(reflect.TypeFor[Config](), "") {
+		if err := v.BindEnv(key + fileSuffix); err != nil {
+			return nil, err
+		}
+	}
+
+	if env := v.GetString("profile.env"); env != "" {
+		ext := filepath.Ext(path)
+		profilePath := strings.TrimSuffix(path, ext) + "." + env + ext
+		if _, err := os.Stat(profilePath); err == nil {
+			v.SetConfigFile(profilePath)
+			if err = v.MergeInConfig(); err != nil {
+				return nil, fmt.Errorf("[kuryr-admin] failed to read profile config file %s: %w", profilePath, err)
+			}
+		}
+	}
+
+	for _, key := range secretKeys(reflect.TypeFor[Config](), "") {
+		file := v.GetString(key + fileSuffix)
+		if file == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("[kuryr-admin] failed to read %s%s: %w", key, fileSuffix, err)
+		}
+		v.Set(key, strings.TrimSpace(string(content)))
+	}
+
+	cfg := Default()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to unmarshal config: %w", err)
+	}
+	return cfg, nil
+}
+
+// keys 返回配置结构体中的所有配置项，用于绑定环境变量。
+// viper 只会为配置文件中出现过的配置项自动读取环境变量，这里显式绑定保证配置文件中缺省的配置项也可以通过环境变量设置。
+func keys(typ reflect.Type, prefix string) []string {
+	var res []string
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+		if field.Type.Kind() == reflect.Struct {
+			res = append(res, keys(field.Type, key+".")...)
+			continue
+		}
+		res = append(res, key)
+	}
+	return res
+}
+
+// secretKeys 返回配置结构体中标记为 secret 的配置项。
+func secretKeys(typ reflect.Type, prefix string) []string {
+	var res []string
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+		if field.Type.Kind() == reflect.Struct {
+			res = append(res, secretKeys(field.Type, key+".")...)
+			continue
+		}
+		if field.Tag.Get("secret") != "" {
+			res = append(res, key)
+		}
+	}
+	return res
+}
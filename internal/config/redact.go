@@ -0,0 +1,64 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+// Redacted 返回脱敏后的配置，key 与配置文件保持一致。
+func (c *Config) Redacted() map[string]any {
+	return redact(reflect.ValueOf(*c)).(map[string]any)
+}
+
+// RedactedYAML 返回脱敏后的 yaml 格式配置。
+func (c *Config) RedactedYAML() ([]byte, error) {
+	return yaml.Marshal(c.Redacted())
+}
+
+func redact(val reflect.Value) any {
+	if val.Kind() != reflect.Struct {
+		return val.Interface()
+	}
+
+	res := make(map[string]any, val.NumField())
+	typ := val.Type()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		key := field.Tag.Get("mapstructure")
+
+		switch field.Tag.Get("secret") {
+		case "true":
+			res[key] = redactString(val.Field(i).String())
+		case "url":
+			res[key] = redactURL(val.Field(i).String())
+		default:
+			res[key] = redact(val.Field(i))
+		}
+	}
+	return res
+}
+
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactURL 只脱敏 url 中的密码（替换为 xxxxx），保留地址等信息方便排查问题。
+func redactURL(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		// 无法解析为 url 的配置（例如 key=value 格式的 dsn）可能包含密码，整体脱敏
+		return redacted
+	}
+	return u.Redacted()
+}
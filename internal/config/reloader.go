@@ -0,0 +1,206 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadable 支持运行时热加载的配置项，apply 将新配置中的值复制到当前配置。
+type reloadable struct {
+	key   string
+	apply func(dst *Config, src *Config)
+}
+
+var reloadables = []reloadable{
+	{key: "log.level", apply: func(dst *Config, src *Config) { dst.Log.Level = src.Log.Level }},
+	{key: "db.log_level", apply: func(dst *Config, src *Config) { dst.DB.LogLevel = src.DB.LogLevel }},
+	{key: "db.slow_threshold", apply: func(dst *Config, src *Config) { dst.DB.SlowThreshold = src.DB.SlowThreshold }},
+	{key: "cors.hostnames", apply: func(dst *Config, src *Config) { dst.Cors.Hostnames = src.Cors.Hostnames }},
+	{key: "ignores", apply: func(dst *Config, src *Config) { dst.Ignores = src.Ignores }},
+	{key: "grpc.client.timeout", apply: func(dst *Config, src *Config) { dst.Grpc.Client.Timeout = src.Grpc.Client.Timeout }},
+	{key: "grpc.client.timeouts", apply: func(dst *Config, src *Config) { dst.Grpc.Client.Timeouts = src.Grpc.Client.Timeouts }},
+}
+
+// ReloadResult 配置重新加载的结果。
+type ReloadResult struct {
+	// Applied 已经生效的配置项
+	Applied []string `json:"applied"`
+	// RestartRequired 发生变化但不支持热加载的配置项，需要重启服务才能生效
+	RestartRequired []string `json:"restart_required"`
+}
+
+// Reloader 运行时重新加载配置。
+//
+// 重新加载时完整地加载并校验配置，校验失败时不做任何变更。
+// 校验通过后只有 reloadables 中的配置项会生效，依次通知所有监听者，其他配置项的变化只在结果中返回。
+type Reloader struct {
+	path string
+
+	mu        sync.Mutex
+	current   atomic.Pointer[Config]
+	listeners []func(cfg *Config)
+}
+
+// Current 返回当前生效的配置。
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// OnReload 注册监听者，热加载的配置项发生变化时调用。
+// 监听者需要自行保证配置切换的原子性，例如使用 atomic 保存配置值。
+func (r *Reloader) OnReload(fn func(cfg *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// Reload 重新加载配置。
+func (r *Reloader) Reload() (ReloadResult, error) {
+	loaded, err := Load(r.path)
+	if err != nil {
+		return ReloadResult{}, err
+	}
+	if err = loaded.Validate(); err != nil {
+		return ReloadResult{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.current.Load()
+	changed := diff(current, loaded)
+
+	res := ReloadResult{}
+	next := *current
+	for _, item := range reloadables {
+		if slices.Contains(changed, item.key) {
+			item.apply(&next, loaded)
+			res.Applied = append(res.Applied, item.key)
+		}
+	}
+	for _, key := range changed {
+		if !slices.Contains(res.Applied, key) {
+			res.RestartRequired = append(res.RestartRequired, key)
+		}
+	}
+
+	if len(res.Applied) == 0 {
+		return res, nil
+	}
+
+	r.current.Store(&next)
+	for _, listener := range r.listeners {
+		listener(&next)
+	}
+	return res, nil
+}
+
+// Watch 监听配置文件变化并重新加载配置，直到 ctx 被取消。
+// 监听的是配置文件所在目录，以兼容编辑器保存时重命名文件以及 k8s ConfigMap 通过符号链接替换文件的情况。
+func (r *Reloader) Watch(ctx context.Context, callback func(res ReloadResult, err error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(r.path)
+	if err = watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("[kuryr-admin] failed to watch config dir %s: %w", dir, err)
+	}
+
+	go func() {
+		defer func() { _ = watcher.Close() }()
+
+		// 一次保存可能触发多个事件，合并短时间内的事件只重新加载一次
+		const debounce = 200 * time.Millisecond
+		var timer *time.Timer
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !r.isConfigFile(event.Name) || event.Op == fsnotify.Chmod {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(debounce, func() {
+					callback(r.Reload())
+				})
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				callback(ReloadResult{}, err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *Reloader) isConfigFile(name string) bool {
+	base := filepath.Base(name)
+	// k8s ConfigMap 更新时替换 ..data 符号链接
+	if base == "..data" {
+		return true
+	}
+
+	ext := filepath.Ext(r.path)
+	prefix := strings.TrimSuffix(filepath.Base(r.path), ext)
+	return base == filepath.Base(r.path) || (strings.HasPrefix(base, prefix+".") && strings.HasSuffix(base, ext))
+}
+
+// diff 返回两个配置中值不同的配置项。
+func diff(prev *Config, next *Config) []string {
+	prevValues := make(map[string]any)
+	flatten(reflect.ValueOf(*prev), "", prevValues)
+	nextValues := make(map[string]any)
+	flatten(reflect.ValueOf(*next), "", nextValues)
+
+	var res []string
+	for key, val := range nextValues {
+		if !reflect.DeepEqual(prevValues[key], val) {
+			res = append(res, key)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+func flatten(val reflect.Value, prefix string, out map[string]any) {
+	typ := val.Type()
+	for i := range typ.NumField() {
+		key := prefix + typ.Field(i).Tag.Get("mapstructure")
+		if val.Field(i).Kind() == reflect.Struct {
+			flatten(val.Field(i), key+".", out)
+			continue
+		}
+		out[key] = val.Field(i).Interface()
+	}
+}
+
+func NewReloader(path string, cfg *Config) *Reloader {
+	r := &Reloader{path: path}
+	r.current.Store(cfg)
+	return r
+}
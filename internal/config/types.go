@@ -0,0 +1,295 @@
+package config
+
+// Config 根配置。
+// 启动时由 Load 从配置文件、profile 配置文件和环境变量合并得到，并由 Validate 统一校验。
+//
+// 字段使用 `secret` tag 标记敏感信息：
+//
+//	secret:"true" 打印配置时整体脱敏
+//	secret:"url"  打印配置时只脱敏 url 中的密码
+//
+// 标记为 secret 的配置项支持通过 <key>_file 从文件读取，例如 db.dsn_file / KURYR_ADMIN_DB_DSN_FILE。
+type Config struct {
+	Profile    ProfileConfig    `mapstructure:"profile"`
+	Log        LogConfig        `mapstructure:"log"`
+	App        AppConfig        `mapstructure:"app"`
+	Startup    StartupConfig    `mapstructure:"startup"`
+	DB         DBConfig         `mapstructure:"db"`
+	Redis      RedisConfig      `mapstructure:"redis"`
+	Cors       CorsConfig       `mapstructure:"cors"`
+	Jwt        JwtConfig        `mapstructure:"jwt"`
+	Ignores    []string         `mapstructure:"ignores"`
+	Session    SessionConfig    `mapstructure:"session"`
+	Etcd       EtcdConfig       `mapstructure:"etcd"`
+	Health     HealthConfig     `mapstructure:"health"`
+	Registry   RegistryConfig   `mapstructure:"registry"`
+	Tracing    TracingConfig    `mapstructure:"tracing"`
+	Grpc       GrpcConfig       `mapstructure:"grpc"`
+	QuotaAlert QuotaAlertConfig `mapstructure:"quota_alert"`
+	Dev        DevConfig        `mapstructure:"dev"`
+}
+
+type ProfileConfig struct {
+	Env string `mapstructure:"env"`
+}
+
+// DevConfig 本地开发配置，prod 环境不允许开启。
+type DevConfig struct {
+	// FakeBackend 使用进程内的 fake kuryr 后端代替 etcd 与 kuryr 服务
+	FakeBackend bool `mapstructure:"fake_backend"`
+}
+
+type LogConfig struct {
+	// Level 日志级别，为空时 prod 环境使用 info，其他环境使用 debug
+	Level string `mapstructure:"level"`
+}
+
+type AppConfig struct {
+	Addr        string `mapstructure:"addr"`
+	DrainPeriod int    `mapstructure:"drain_period"` // 单位：毫秒
+	StopTimeout int    `mapstructure:"stop_timeout"` // 单位：毫秒
+}
+
+type StartupConfig struct {
+	// Degraded 依赖在重试耗尽后仍不可用时，是否以降级模式启动（就绪检查持续失败）而不是直接退出
+	Degraded bool               `mapstructure:"degraded"`
+	Retry    StartupRetryConfig `mapstructure:"retry"`
+}
+
+type StartupRetryConfig struct {
+	InitialInterval int   `mapstructure:"initial_interval"` // 单位：毫秒
+	MaxInterval     int   `mapstructure:"max_interval"`     // 单位：毫秒
+	MaxTimes        int32 `mapstructure:"max_times"`
+}
+
+type DBConfig struct {
+	LogLevel                  string `mapstructure:"log_level"`
+	SlowThreshold             int    `mapstructure:"slow_threshold"` // 单位：毫秒
+	IgnoreRecordNotFoundError bool   `mapstructure:"ignore_record_not_found_error"`
+	DSN                       string `mapstructure:"dsn" secret:"url"`
+	// MigrationCheck 启动时检查数据库迁移是否已全部执行，存在未执行的迁移时拒绝启动
+	MigrationCheck bool `mapstructure:"migration_check"`
+}
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password" secret:"true"`
+}
+
+type CorsConfig struct {
+	MaxAge    int      `mapstructure:"max_age"` // 单位：秒
+	Hostnames []string `mapstructure:"hostnames"`
+}
+
+type JwtTokenConfig struct {
+	Issuer     string `mapstructure:"issuer"`
+	Expiration int    `mapstructure:"expiration"` // 单位：秒
+}
+
+type JwtConfig struct {
+	Private string         `mapstructure:"private" secret:"true"`
+	Public  string         `mapstructure:"public"`
+	Access  JwtTokenConfig `mapstructure:"access"`
+	Refresh JwtTokenConfig `mapstructure:"refresh"`
+}
+
+type SessionConfig struct {
+	Expiration int `mapstructure:"expiration"` // 单位：秒
+}
+
+// TLSConfig 客户端 tls 配置，同时配置 cert_file 与 key_file 时启用双向认证（mTLS）。
+type TLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	CAFile             string `mapstructure:"ca_file"`
+	ServerName         string `mapstructure:"server_name"` // 校验服务端证书使用的名称，为空时使用连接地址
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+type EtcdConfig struct {
+	Endpoints   []string  `mapstructure:"endpoints"`
+	Username    string    `mapstructure:"username"`
+	Password    string    `mapstructure:"password" secret:"true"`
+	DialTimeout int       `mapstructure:"dial_timeout"` // 单位：毫秒
+	TLS         TLSConfig `mapstructure:"tls"`
+}
+
+type HealthConfig struct {
+	Timeout int `mapstructure:"timeout"` // 单位：毫秒
+}
+
+type RegistryConfig struct {
+	KeyPrefix string `mapstructure:"key_prefix"`
+	LeaseTTL  int    `mapstructure:"lease_ttl"` // 单位：秒
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+	TracingExporterOtlp   = "otlp"
+)
+
+type OtlpConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+	Insecure bool   `mapstructure:"insecure"`
+}
+
+type TracingConfig struct {
+	Exporter    string     `mapstructure:"exporter"`
+	ServiceName string     `mapstructure:"service_name"`
+	SampleRatio float64    `mapstructure:"sample_ratio"`
+	File        string     `mapstructure:"file"`
+	Otlp        OtlpConfig `mapstructure:"otlp"`
+}
+
+const (
+	QuotaAlertNotifierLog   = "log"
+	QuotaAlertNotifierKuryr = "kuryr"
+)
+
+// QuotaAlertConfig 配额告警配置。
+type QuotaAlertConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Interval int    `mapstructure:"interval"` // 用量检查间隔，单位：毫秒
+	Notifier string `mapstructure:"notifier"` // 告警发送方式
+	// Kuryr notifier 为 kuryr 时通过 kuryr 发送告警邮件
+	Kuryr QuotaAlertKuryrConfig `mapstructure:"kuryr"`
+}
+
+type QuotaAlertKuryrConfig struct {
+	BizKey string `mapstructure:"biz_key"` // 发送告警邮件使用的业务方
+	TplId  string `mapstructure:"tpl_id"`  // 告警邮件模板
+}
+
+type GrpcConfig struct {
+	Server GrpcServerConfig `mapstructure:"server"`
+	Client GrpcClientConfig `mapstructure:"client"`
+}
+
+type GrpcServerConfig struct {
+	Name string `mapstructure:"name"`
+}
+
+type GrpcClientConfig struct {
+	Timeout        int                  `mapstructure:"timeout"` // 单次调用的默认超时时间，单位：毫秒
+	Timeouts       []GrpcTimeoutConfig  `mapstructure:"timeouts"`
+	Retry          GrpcRetryConfig      `mapstructure:"retry"`
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	LoadBalance    LoadBalanceConfig    `mapstructure:"load_balance"`
+	TLS            TLSConfig            `mapstructure:"tls"`
+	Auth           GrpcAuthConfig       `mapstructure:"auth"`
+}
+
+// GrpcAuthConfig 服务间认证配置，token 通过 authorization metadata 随每次调用发送。
+type GrpcAuthConfig struct {
+	Token string `mapstructure:"token" secret:"true"`
+}
+
+// GrpcTimeoutConfig 按服务或方法覆盖默认超时时间。
+// Method 为空时对整个服务生效，方法级配置优先于服务级配置。
+type GrpcTimeoutConfig struct {
+	Service string `mapstructure:"service"` // 完整服务名，例如 business.v1.BusinessService
+	Method  string `mapstructure:"method"`
+	Timeout int    `mapstructure:"timeout"` // 单位：毫秒
+}
+
+// GrpcRetryConfig 幂等读请求的重试策略，只有返回 Unavailable 的调用会重试。
+type GrpcRetryConfig struct {
+	MaxTimes        int32    `mapstructure:"max_times"`        // 最大重试次数，0 表示不重试
+	InitialInterval int      `mapstructure:"initial_interval"` // 单位：毫秒
+	MaxInterval     int      `mapstructure:"max_interval"`     // 单位：毫秒
+	Methods         []string `mapstructure:"methods"`          // 允许重试的方法名
+}
+
+// CircuitBreakerConfig 按服务熔断的配置。
+type CircuitBreakerConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	FailureThreshold int  `mapstructure:"failure_threshold"` // 连续失败多少次后熔断
+	OpenTimeout      int  `mapstructure:"open_timeout"`      // 熔断持续时间，单位：毫秒
+}
+
+type KeepAliveConfig struct {
+	Time                int  `mapstructure:"time"`    // 单位：毫秒
+	Timeout             int  `mapstructure:"timeout"` // 单位：毫秒
+	PermitWithoutStream bool `mapstructure:"permit_without_stream"`
+}
+
+const (
+	LoadBalancePolicyRwWeight       = "rw_weight"
+	LoadBalancePolicyRoundRobin     = "round_robin"
+	LoadBalancePolicyPickFirst      = "pick_first"
+	LoadBalancePolicyConsistentHash = "consistent_hash"
+)
+
+type LoadBalanceConfig struct {
+	Policy    string                     `mapstructure:"policy"` // 默认负载均衡策略
+	Services  []LoadBalanceServiceConfig `mapstructure:"services"`
+	Timeout   int                        `mapstructure:"timeout"` // 单位：毫秒
+	KeepAlive KeepAliveConfig            `mapstructure:"keep_alive"`
+}
+
+// LoadBalanceServiceConfig 按服务覆盖默认负载均衡策略。
+type LoadBalanceServiceConfig struct {
+	Service string `mapstructure:"service"` // 完整服务名，例如 config.v1.BizConfigService
+	Policy  string `mapstructure:"policy"`
+}
+
+// PolicyFor 返回指定服务使用的负载均衡策略。
+func (c LoadBalanceConfig) PolicyFor(service string) string {
+	for _, s := range c.Services {
+		if s.Service == service {
+			return s.Policy
+		}
+	}
+	return c.Policy
+}
+
+// Default 返回带默认值的配置，配置文件和环境变量中未设置的配置项保持默认值。
+func Default() *Config {
+	return &Config{
+		Profile: ProfileConfig{Env: "dev"},
+		App: AppConfig{
+			Addr:        "localhost:8080",
+			StopTimeout: 15000,
+		},
+		Startup: StartupConfig{
+			Retry: StartupRetryConfig{
+				InitialInterval: 500,
+				MaxInterval:     5000,
+				MaxTimes:        5,
+			},
+		},
+		DB: DBConfig{LogLevel: "info", MigrationCheck: true},
+		Grpc: GrpcConfig{
+			Client: GrpcClientConfig{
+				Timeout: 5000,
+				LoadBalance: LoadBalanceConfig{
+					Policy: LoadBalancePolicyRwWeight,
+				},
+				Retry: GrpcRetryConfig{
+					MaxTimes:        2,
+					InitialInterval: 100,
+					MaxInterval:     1000,
+					Methods:         []string{"FindById", "FindByBizId", "Search", "List", "FindByChannel"},
+				},
+				CircuitBreaker: CircuitBreakerConfig{
+					Enabled:          true,
+					FailureThreshold: 5,
+					OpenTimeout:      10000,
+				},
+			},
+		},
+		Health: HealthConfig{Timeout: 2000},
+		QuotaAlert: QuotaAlertConfig{
+			Interval: 300000,
+			Notifier: QuotaAlertNotifierLog,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			ServiceName: "kuryr-admin",
+			SampleRatio: 1,
+		},
+	}
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
+)
+
+var logLevels = []string{"", "debug", "info", "warn", "error"}
+
+var dbLogLevels = []string{"silent", "error", "warn", "info"}
+
+var loadBalancePolicies = []string{
+	LoadBalancePolicyRwWeight, LoadBalancePolicyRoundRobin, LoadBalancePolicyPickFirst, LoadBalancePolicyConsistentHash,
+}
+
+var tracingExporters = []string{
+	TracingExporterNone, TracingExporterStdout, TracingExporterFile, TracingExporterOtlp,
+}
+
+var quotaAlertNotifiers = []string{QuotaAlertNotifierLog, QuotaAlertNotifierKuryr}
+
+// validator 收集所有校验错误，保证一次启动就可以看到全部配置问题。
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, key string, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (v *validator) required(value string, key string) {
+	v.check(value != "", key, "is required")
+}
+
+func (v *validator) positive(value int, key string) {
+	v.check(value > 0, key, "must be positive, got %d", value)
+}
+
+func (v *validator) nonNegative(value int, key string) {
+	v.check(value >= 0, key, "must not be negative, got %d", value)
+}
+
+func (v *validator) tls(cfg TLSConfig, key string) {
+	if !cfg.Enabled {
+		return
+	}
+	v.check((cfg.CertFile == "") == (cfg.KeyFile == ""), key, "cert_file and key_file must be set together")
+	v.fileExists(cfg.CertFile, key+".cert_file")
+	v.fileExists(cfg.KeyFile, key+".key_file")
+	v.fileExists(cfg.CAFile, key+".ca_file")
+}
+
+func (v *validator) fileExists(path string, key string) {
+	if path == "" {
+		return
+	}
+	_, err := os.Stat(path)
+	v.check(err == nil, key, "file %s is not accessible", path)
+}
+
+// Validate 校验配置，返回所有校验错误。
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.required(c.Profile.Env, "profile.env")
+
+	v.check(slices.Contains(logLevels, c.Log.Level), "log.level", "must be one of %v, got %q", logLevels, c.Log.Level)
+
+	v.required(c.App.Addr, "app.addr")
+	v.nonNegative(c.App.DrainPeriod, "app.drain_period")
+	v.nonNegative(c.App.StopTimeout, "app.stop_timeout")
+	if c.App.StopTimeout > 0 {
+		v.check(c.App.StopTimeout > c.App.DrainPeriod, "app.stop_timeout", "must be greater than app.drain_period")
+	}
+
+	v.positive(c.Startup.Retry.InitialInterval, "startup.retry.initial_interval")
+	v.check(c.Startup.Retry.MaxInterval >= c.Startup.Retry.InitialInterval,
+		"startup.retry.max_interval", "must not be less than startup.retry.initial_interval")
+	v.check(c.Startup.Retry.MaxTimes >= 0, "startup.retry.max_times", "must not be negative, got %d", c.Startup.Retry.MaxTimes)
+
+	v.required(c.DB.DSN, "db.dsn")
+	v.check(slices.Contains(dbLogLevels, c.DB.LogLevel), "db.log_level", "must be one of %v, got %q", dbLogLevels, c.DB.LogLevel)
+	v.nonNegative(c.DB.SlowThreshold, "db.slow_threshold")
+
+	v.required(c.Redis.Addr, "redis.addr")
+
+	v.nonNegative(c.Cors.MaxAge, "cors.max_age")
+
+	v.required(c.Jwt.Private, "jwt.private")
+	v.required(c.Jwt.Public, "jwt.public")
+	v.required(c.Jwt.Access.Issuer, "jwt.access.issuer")
+	v.positive(c.Jwt.Access.Expiration, "jwt.access.expiration")
+	v.required(c.Jwt.Refresh.Issuer, "jwt.refresh.issuer")
+	v.positive(c.Jwt.Refresh.Expiration, "jwt.refresh.expiration")
+
+	if _, err := route.ParseRules(c.Ignores); err != nil {
+		v.errs = append(v.errs, fmt.Errorf("ignores: %w", err))
+	}
+
+	v.positive(c.Session.Expiration, "session.expiration")
+
+	// 使用 fake 后端时不连接 etcd
+	if c.Dev.FakeBackend {
+		v.check(c.Profile.Env != "prod", "dev.fake_backend", "must not be enabled in prod")
+	} else {
+		v.check(len(c.Etcd.Endpoints) > 0, "etcd.endpoints", "is required")
+		v.positive(c.Etcd.DialTimeout, "etcd.dial_timeout")
+		v.tls(c.Etcd.TLS, "etcd.tls")
+		v.positive(c.Registry.LeaseTTL, "registry.lease_ttl")
+	}
+
+	v.positive(c.Health.Timeout, "health.timeout")
+
+	v.check(slices.Contains(tracingExporters, c.Tracing.Exporter),
+		"tracing.exporter", "must be one of %v, got %q", tracingExporters, c.Tracing.Exporter)
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"tracing.sample_ratio", "must be in [0, 1], got %v", c.Tracing.SampleRatio)
+	if c.Tracing.Exporter == TracingExporterFile {
+		v.required(c.Tracing.File, "tracing.file")
+	}
+	if c.Tracing.Exporter == TracingExporterOtlp {
+		v.required(c.Tracing.Otlp.Endpoint, "tracing.otlp.endpoint")
+	}
+
+	v.required(c.Grpc.Server.Name, "grpc.server.name")
+	v.positive(c.Grpc.Client.Timeout, "grpc.client.timeout")
+	for i, t := range c.Grpc.Client.Timeouts {
+		key := fmt.Sprintf("grpc.client.timeouts[%d]", i)
+		v.required(t.Service, key+".service")
+		v.positive(t.Timeout, key+".timeout")
+	}
+	v.check(c.Grpc.Client.Retry.MaxTimes >= 0, "grpc.client.retry.max_times", "must not be negative, got %d", c.Grpc.Client.Retry.MaxTimes)
+	if c.Grpc.Client.Retry.MaxTimes > 0 {
+		v.positive(c.Grpc.Client.Retry.InitialInterval, "grpc.client.retry.initial_interval")
+		v.check(c.Grpc.Client.Retry.MaxInterval >= c.Grpc.Client.Retry.InitialInterval,
+			"grpc.client.retry.max_interval", "must not be less than grpc.client.retry.initial_interval")
+	}
+	if c.Grpc.Client.CircuitBreaker.Enabled {
+		v.positive(c.Grpc.Client.CircuitBreaker.FailureThreshold, "grpc.client.circuit_breaker.failure_threshold")
+		v.positive(c.Grpc.Client.CircuitBreaker.OpenTimeout, "grpc.client.circuit_breaker.open_timeout")
+	}
+	v.check(slices.Contains(loadBalancePolicies, c.Grpc.Client.LoadBalance.Policy), "grpc.client.load_balance.policy",
+		"must be one of %v, got %q", loadBalancePolicies, c.Grpc.Client.LoadBalance.Policy)
+	for i, s := range c.Grpc.Client.LoadBalance.Services {
+		key := fmt.Sprintf("grpc.client.load_balance.services[%d]", i)
+		v.required(s.Service, key+".service")
+		v.check(slices.Contains(loadBalancePolicies, s.Policy), key+".policy",
+			"must be one of %v, got %q", loadBalancePolicies, s.Policy)
+	}
+	v.positive(c.Grpc.Client.LoadBalance.Timeout, "grpc.client.load_balance.timeout")
+	v.nonNegative(c.Grpc.Client.LoadBalance.KeepAlive.Time, "grpc.client.load_balance.keep_alive.time")
+	v.nonNegative(c.Grpc.Client.LoadBalance.KeepAlive.Timeout, "grpc.client.load_balance.keep_alive.timeout")
+	v.tls(c.Grpc.Client.TLS, "grpc.client.tls")
+	// token 随每次调用发送，未开启 tls 时会以明文传给 kuryr，只允许在 fake 后端与测试环境使用
+	if c.Grpc.Client.Auth.Token != "" && !c.Dev.FakeBackend && c.Profile.Env != "test" {
+		v.check(c.Grpc.Client.TLS.Enabled, "grpc.client.auth.token", "requires grpc.client.tls.enabled, token must not be sent in plaintext")
+	}
+
+	if c.QuotaAlert.Enabled {
+		v.positive(c.QuotaAlert.Interval, "quota_alert.interval")
+		v.check(slices.Contains(quotaAlertNotifiers, c.QuotaAlert.Notifier),
+			"quota_alert.notifier", "must be one of %v, got %q", quotaAlertNotifiers, c.QuotaAlert.Notifier)
+		if c.QuotaAlert.Notifier == QuotaAlertNotifierKuryr {
+			v.required(c.QuotaAlert.Kuryr.BizKey, "quota_alert.kuryr.biz_key")
+			v.required(c.QuotaAlert.Kuryr.TplId, "quota_alert.kuryr.tpl_id")
+		}
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("[kuryr-admin] invalid config:\n%w", errors.Join(v.errs...))
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "encoding/json"
+
+// AuditAction 审计操作类型
+type AuditAction string
+
+const (
+	AuditActionCreate   AuditAction = "create"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionReload   AuditAction = "reload"
+	AuditActionRollback AuditAction = "rollback"
+)
+
+// AuditTarget 审计操作对象类型
+type AuditTarget string
+
+const (
+	AuditTargetBizInfo         AuditTarget = "biz_info"
+	AuditTargetBizConfig       AuditTarget = "biz_config"
+	AuditTargetBizConfigPreset AuditTarget = "biz_config_preset"
+	AuditTargetQuotaAlertRule  AuditTarget = "quota_alert_rule"
+	AuditTargetProvider        AuditTarget = "provider"
+	AuditTargetTemplate        AuditTarget = "template"
+	AuditTargetConfig          AuditTarget = "config"
+)
+
+// AuditLog 审计日志，只允许追加写入。
+type AuditLog struct {
+	Id uint64 `json:"id"`
+
+	ActorUid uint64 `json:"actor_uid"` // 操作人 user id
+	ActorBid uint64 `json:"actor_bid"` // 操作人 biz id
+	ActorSid string `json:"actor_sid"` // 操作人 session id
+
+	Action     AuditAction `json:"action"`
+	TargetType AuditTarget `json:"target_type"`
+	TargetId   uint64      `json:"target_id"`
+
+	// Diff 变更字段，key 为字段路径，value 为变更前后的值，敏感字段已脱敏
+	Diff json.RawMessage `json:"diff"`
+
+	ClientIp  string `json:"client_ip"`
+	RequestId string `json:"request_id"`
+	CreatedAt int64  `json:"created_at"`
+}
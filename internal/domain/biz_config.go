@@ -1,7 +1,5 @@
 package domain
 
-import "time"
-
 // BizConfig 业务方配置领域对象。
 type BizConfig struct {
 	Id             uint64          `json:"id"`             // 对应 ( biz_info.id )
@@ -13,10 +11,11 @@ type BizConfig struct {
 	RateLimit      int32           `json:"rate_limit"`
 }
 
+// RetryConfig 重试策略，kuryr 内重试间隔精确到毫秒。
 type RetryConfig struct {
-	InitialInterval time.Duration `json:"initial_interval"`
-	MaxInterval     time.Duration `json:"max_interval"`
-	MaxRetryTimes   int32         `json:"max_retry_times"`
+	InitialInterval Duration `json:"initial_interval"`
+	MaxInterval     Duration `json:"max_interval"`
+	MaxRetryTimes   int32    `json:"max_retry_times"`
 }
 
 type ChannelItem struct {
@@ -0,0 +1,42 @@
+package domain
+
+import "github.com/JrMarcco/kuryr-admin/internal/pkg/audit"
+
+// BizConfigPreset 业务方配置预设，由管理员维护，用于快速初始化新业务方的配置。
+// 预设只包含通用的渠道、配额与限流配置，回调配置属于业务方自身，不在预设内。
+type BizConfigPreset struct {
+	Id            uint64         `json:"id"`
+	Name          string         `json:"name"` // 唯一，例如 sms-only-standard
+	Description   string         `json:"description"`
+	ChannelConfig *ChannelConfig `json:"channel_config"`
+	QuotaConfig   *QuotaConfig   `json:"quota_config"`
+	RateLimit     int32          `json:"rate_limit"`
+	IsDefault     bool           `json:"is_default"` // 新建业务方未指定预设时使用默认预设，最多只有一个默认预设
+	CreatedAt     int64          `json:"created_at"`
+	UpdatedAt     int64          `json:"updated_at"`
+}
+
+// BizConfig 将预设转换为指定业务方的配置
+func (p BizConfigPreset) BizConfig(bizId uint64) BizConfig {
+	return BizConfig{
+		BizId:         bizId,
+		ChannelConfig: p.ChannelConfig,
+		QuotaConfig:   p.QuotaConfig,
+		RateLimit:     p.RateLimit,
+	}
+}
+
+// BizConfigPresetBinding 业务方配置所基于的预设，每个业务方最多基于一个预设。
+type BizConfigPresetBinding struct {
+	BizId     uint64 `json:"biz_id"`
+	PresetId  uint64 `json:"preset_id"`
+	AppliedBy uint64 `json:"applied_by"` // 应用预设的用户 id
+	AppliedAt int64  `json:"applied_at"`
+}
+
+// BizConfigPresetOverrides 业务方当前配置相对于预设的差异。
+type BizConfigPresetOverrides struct {
+	Binding   BizConfigPresetBinding  `json:"binding"`
+	Preset    BizConfigPreset         `json:"preset"`
+	Overrides map[string]audit.Change `json:"overrides"` // before 为预设值，after 为业务方当前值
+}
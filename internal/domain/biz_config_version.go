@@ -0,0 +1,18 @@
+package domain
+
+// MaxBizConfigVersionCommentLen 变更说明的最大字符数，与 biz_config_version.comment 列长度一致
+const MaxBizConfigVersionCommentLen = 256
+
+// BizConfigVersion 业务方配置版本，每次保存配置后记录完整的配置快照。
+type BizConfigVersion struct {
+	Id      uint64    `json:"id"`
+	BizId   uint64    `json:"biz_id"`
+	Version uint64    `json:"version"` // 同一业务方内从 1 开始递增
+	Config  BizConfig `json:"config"`  // 配置快照
+
+	AuthorId     uint64 `json:"author_id"`     // 保存配置的用户 id，0 表示由系统生成
+	Comment      string `json:"comment"`       // 变更说明
+	RollbackFrom uint64 `json:"rollback_from"` // 回滚时为回滚到的版本，否则为 0
+
+	CreatedAt int64 `json:"created_at"`
+}
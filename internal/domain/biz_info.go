@@ -29,7 +29,7 @@ type BizInfo struct {
 	Id           uint64  `json:"id"`
 	BizType      BizType `json:"biz_type"`
 	BizKey       string  `json:"biz_key"`
-	BizSecret    string  `json:"biz_secret"`
+	BizSecret    string  `json:"biz_secret" secret:"true"`
 	BizName      string  `json:"biz_name"`
 	Contact      string  `json:"contact"`
 	ContactEmail string  `json:"contact_email"`
@@ -0,0 +1,25 @@
+package domain
+
+const (
+	CallbackProbeStatusServing       = "serving"       // 健康检查返回 SERVING
+	CallbackProbeStatusNotServing    = "not_serving"   // 健康检查返回 NOT_SERVING / UNKNOWN / SERVICE_UNKNOWN
+	CallbackProbeStatusUnimplemented = "unimplemented" // 实例可以连接，但没有注册 grpc 健康检查服务
+	CallbackProbeStatusUnreachable   = "unreachable"   // 实例无法连接或健康检查超时
+)
+
+// CallbackInstanceProbe 单个回调服务实例的探测结果。
+type CallbackInstanceProbe struct {
+	Addr    string   `json:"addr"`
+	Group   string   `json:"group,omitempty"`
+	Status  string   `json:"status"`
+	Latency Duration `json:"latency"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// CallbackProbe 回调服务连通性探测结果。
+type CallbackProbe struct {
+	ServiceName string                  `json:"service_name"`
+	Instances   []CallbackInstanceProbe `json:"instances"`
+	// Serving 处于 serving 状态的实例数，为 0 时 kuryr 无法投递回调
+	Serving int `json:"serving"`
+}
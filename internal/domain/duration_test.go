@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration_JSON(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		input   string
+		want    Duration
+		wantErr bool
+	}{
+		{name: "string", input: `"500ms"`, want: Duration(500 * time.Millisecond)},
+		{name: "compound string", input: `"1m30s"`, want: Duration(90 * time.Second)},
+		{name: "milliseconds", input: `2000`, want: Duration(2 * time.Second)},
+		{name: "null", input: `null`, want: 0},
+		{name: "invalid string", input: `"2 seconds"`, wantErr: true},
+		{name: "float", input: `1.5`, wantErr: true},
+		{name: "overflow", input: `9223372036854775807`, wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var d Duration
+			err := json.Unmarshal([]byte(tc.input), &d)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, d)
+		})
+	}
+}
+
+func TestRetryConfig_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cfg := RetryConfig{
+		InitialInterval: Duration(500 * time.Millisecond),
+		MaxInterval:     Duration(2 * time.Minute),
+		MaxRetryTimes:   5,
+	}
+
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"initial_interval":"500ms","max_interval":"2m0s","max_retry_times":5}`, string(data))
+
+	var decoded RetryConfig
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, cfg, decoded)
+}
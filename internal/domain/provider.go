@@ -1,6 +1,7 @@
 package domain
 
 // Provider 供应商领域对象。
+// secret:"true" 标记的字段在审计日志内脱敏。
 type Provider struct {
 	Id           uint64 `json:"id"`
 	ProviderName string `json:"provider_name"` // 供应商名称
@@ -9,9 +10,9 @@ type Provider struct {
 	Endpoint string `json:"endpoint"`  // 接口地址
 	RegionId string `json:"region_id"` // 区域 ID
 
-	AppId     string `json:"app_id"`     // 应用 id
-	ApiKey    string `json:"api_key"`    // 接口 key
-	ApiSecret string `json:"api_secret"` // 接口 secret
+	AppId     string `json:"app_id"`                   // 应用 id
+	ApiKey    string `json:"api_key" secret:"true"`    // 接口 key
+	ApiSecret string `json:"api_secret" secret:"true"` // 接口 secret
 
 	Weight     int32 `json:"weight"`      // 权重
 	QpsLimit   int32 `json:"qps_limit"`   // 每秒请求限制
@@ -0,0 +1,26 @@
+package domain
+
+// QuotaAlertRule 业务方单个渠道的配额告警阈值，日配额与月配额使用相同的阈值。
+type QuotaAlertRule struct {
+	BizId      uint64  `json:"biz_id"`
+	Channel    string  `json:"channel"`    // sms / email
+	Thresholds []int32 `json:"thresholds"` // 用量占配额的百分比，升序，例如 [80, 100]
+	Enabled    bool    `json:"enabled"`
+	CreatedAt  int64   `json:"created_at"`
+	UpdatedAt  int64   `json:"updated_at"`
+}
+
+// QuotaAlert 一次配额告警，同一业务方、渠道与周期内每个阈值只告警一次。
+type QuotaAlert struct {
+	BizId     uint64 `json:"biz_id"`
+	BizName   string `json:"biz_name"`
+	Channel   string `json:"channel"`
+	Period    string `json:"period"`     // daily / monthly
+	PeriodKey string `json:"period_key"` // 周期标识，例如 2026-10-19 / 2026-10
+	Threshold int32  `json:"threshold"`  // 本次达到的最高阈值
+	Limit     int32  `json:"limit"`
+	Used      int32  `json:"used"`
+	CreatedAt int64  `json:"created_at"`
+
+	Receivers []string `json:"receivers"` // 业务方联系人与管理员邮箱
+}
@@ -0,0 +1,39 @@
+package domain
+
+const (
+	QuotaPeriodDaily   = "daily"
+	QuotaPeriodMonthly = "monthly"
+
+	QuotaChannelSms   = "sms"
+	QuotaChannelEmail = "email"
+)
+
+// QuotaConsumption 业务方在当前自然日与自然月内已消耗的配额。
+type QuotaConsumption struct {
+	Daily   Quota `json:"daily"`
+	Monthly Quota `json:"monthly"`
+}
+
+// QuotaUsageItem 单个周期内单个渠道的配额用量。
+// 用量不可用时只返回配置的配额，Used 与 Remaining 为空。
+type QuotaUsageItem struct {
+	Period    string `json:"period"`
+	Channel   string `json:"channel"`
+	Limit     int32  `json:"limit"`
+	Used      *int32 `json:"used,omitempty"`
+	Remaining *int32 `json:"remaining,omitempty"`
+	Exhausted bool   `json:"exhausted"`
+	// ExhaustAt 按周期内的平均消耗速度预计配额耗尽的时间（unix 毫秒），
+	// 已耗尽或预计周期结束前不会耗尽时为 0
+	ExhaustAt int64 `json:"exhaust_at,omitempty"`
+	PeriodEnd int64 `json:"period_end"` // 周期结束时间（unix 毫秒），配额在此时重置
+}
+
+// QuotaUsage 业务方配额用量。
+type QuotaUsage struct {
+	BizId uint64 `json:"biz_id"`
+	// Available 是否获取到了用量数据，为 false 时只返回配置的配额
+	Available bool             `json:"available"`
+	At        int64            `json:"at"` // 统计时间（unix 毫秒）
+	Items     []QuotaUsageItem `json:"items"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+// RetryAttempt 一次重试。
+type RetryAttempt struct {
+	Attempt  int32    `json:"attempt"`  // 第几次重试，从 1 开始
+	Interval Duration `json:"interval"` // 距上一次发送的间隔
+	Elapsed  Duration `json:"elapsed"`  // 距首次发送的时间
+}
+
+// RetrySchedule 按重试策略计算的重试时间表。
+type RetrySchedule struct {
+	Attempts []RetryAttempt `json:"attempts"`
+	// TotalDelay 最后一次重试距首次发送的时间，即最坏情况下的投递延迟
+	TotalDelay Duration `json:"total_delay"`
+	// Unlimited max_retry_times 为 0 时不限制重试次数，时间表只包含前若干次重试
+	Unlimited bool `json:"unlimited"`
+}
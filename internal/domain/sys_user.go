@@ -11,7 +11,7 @@ const (
 type SysUser struct {
 	Id        uint64
 	Email     string
-	Password  string
+	Password  string `secret:"true"`
 	RealName  string
 	UserType  UserType
 	BizId     uint64
@@ -0,0 +1,193 @@
+package e2e
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/audit"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchAudit 查询审计日志
+func (h *harness) searchAudit(t *testing.T, token string, query string) []domain.AuditLog {
+	t.Helper()
+
+	code, res := call[pkggorm.PaginationResult[domain.AuditLog]](t, h, http.MethodGet, "/api/v1/audit/search?"+query, token, nil)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	return res.Data.Records
+}
+
+func diffOf(t *testing.T, l domain.AuditLog) map[string]audit.Change {
+	t.Helper()
+
+	var diff map[string]audit.Change
+	require.NoError(t, json.Unmarshal(l.Diff, &diff))
+	return diff
+}
+
+func TestAudit_Provider(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/provider/save", token, map[string]any{
+		"provider_name": "sms provider",
+		"channel":       1,
+		"api_key":       "key",
+		"api_secret":    "top-secret",
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	logs := h.searchAudit(t, token, "target_type=provider")
+	require.Len(t, logs, 1)
+
+	l := logs[0]
+	assert.Equal(t, domain.AuditActionCreate, l.Action)
+	assert.NotZero(t, l.ActorUid)
+	assert.NotEmpty(t, l.ActorSid)
+	assert.NotZero(t, l.TargetId)
+	assert.Equal(t, "127.0.0.1", l.ClientIp)
+	assert.NotEmpty(t, l.RequestId)
+	assert.NotZero(t, l.CreatedAt)
+
+	// 敏感字段只记录是否变更
+	assert.NotContains(t, string(l.Diff), "top-secret")
+	diff := diffOf(t, l)
+	assert.Equal(t, audit.Change{After: audit.Redacted}, diff["api_secret"])
+	assert.Equal(t, audit.Change{After: audit.Redacted}, diff["api_key"])
+	assert.Equal(t, audit.Change{After: "sms provider"}, diff["provider_name"])
+
+	// 按对象类型过滤
+	assert.Empty(t, h.searchAudit(t, token, "target_type=biz_info"))
+}
+
+func TestAudit_BizInfo(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	order := h.saveBiz(t, token, "order")
+	code, updated := call[domain.BizInfo](t, h, http.MethodPut, "/api/v1/biz_info/update", token, map[string]any{
+		"id":       order.Id,
+		"biz_name": "order center",
+		"contact":  order.Contact,
+	})
+	require.Equal(t, http.StatusOK, code, updated.Msg)
+	code, _ = call[any](t, h, http.MethodDelete, fmt.Sprintf("/api/v1/biz_info/delete?biz_id=%d", order.Id), token, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// 按时间倒序返回
+	logs := h.searchAudit(t, token, fmt.Sprintf("target_type=biz_info&target_id=%d", order.Id))
+	require.Len(t, logs, 3)
+	assert.Equal(t, domain.AuditActionDelete, logs[0].Action)
+	assert.Equal(t, domain.AuditActionUpdate, logs[1].Action)
+	assert.Equal(t, domain.AuditActionCreate, logs[2].Action)
+
+	diff := diffOf(t, logs[1])
+	assert.Equal(t, audit.Change{Before: "order biz", After: "order center"}, diff["biz_name"])
+	assert.NotContains(t, diff, "contact")
+	assert.NotContains(t, diff, "creator.Email")
+
+	diff = diffOf(t, logs[0])
+	assert.Equal(t, audit.Change{Before: "order center"}, diff["biz_name"])
+
+	// 分页与过滤
+	code, page := call[pkggorm.PaginationResult[domain.AuditLog]](t, h, http.MethodGet, "/api/v1/audit/search?action=update&offset=0&limit=1", token, nil)
+	require.Equal(t, http.StatusOK, code, page.Msg)
+	assert.Equal(t, int64(1), page.Data.Total)
+	require.Len(t, page.Data.Records, 1)
+	assert.Equal(t, logs[1].Id, page.Data.Records[0].Id)
+
+	code, page = call[pkggorm.PaginationResult[domain.AuditLog]](t, h, http.MethodGet, "/api/v1/audit/search?offset=1&limit=1", token, nil)
+	require.Equal(t, http.StatusOK, code, page.Msg)
+	assert.Equal(t, int64(3), page.Data.Total)
+	require.Len(t, page.Data.Records, 1)
+	assert.Equal(t, logs[1].Id, page.Data.Records[0].Id)
+
+	assert.Empty(t, h.searchAudit(t, token, fmt.Sprintf("start_time=%d", logs[0].CreatedAt+1)))
+}
+
+func TestAudit_Operator(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	operatorToken := h.loginOperator(t, h.saveBiz(t, token, "order"))
+
+	code, _ := call[any](t, h, http.MethodGet, "/api/v1/audit/search", operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	resp := h.get(t, "/api/v1/audit/export", operatorToken)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestAudit_Export(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	h.saveBiz(t, token, "order")
+	h.saveBiz(t, token, "user")
+	// 构造 csv 注入的 request id
+	require.NoError(t, h.db.Create(&dao.AuditLog{
+		ActorUid:   1,
+		Action:     string(domain.AuditActionReload),
+		TargetType: string(domain.AuditTargetConfig),
+		Diff:       "{}",
+		RequestId:  "=cmd()",
+	}).Error)
+
+	resp := h.get(t, "/api/v1/audit/export?target_type=biz_info", token)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "id", rows[0][0])
+	assert.Equal(t, "diff", rows[0][len(rows[0])-1])
+
+	// 按时间顺序导出
+	first, err := strconv.ParseUint(rows[1][0], 10, 64)
+	require.NoError(t, err)
+	second, err := strconv.ParseUint(rows[2][0], 10, 64)
+	require.NoError(t, err)
+	assert.Less(t, first, second)
+	assert.Equal(t, "create", rows[1][5])
+	assert.Equal(t, "biz_info", rows[1][6])
+
+	resp = h.get(t, "/api/v1/audit/export?target_type=config", token)
+	defer func() { _ = resp.Body.Close() }()
+	rows, err = csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "'=cmd()", rows[1][9])
+}
+
+// get 发送 GET 请求，返回原始响应
+func (h *harness) get(t *testing.T, path string, token string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, h.baseURL+path, nil)
+	require.NoError(t, err)
+	req.Header.Set(pkggin.HeaderNameAccessToken, token)
+
+	resp, err := h.client.Do(req)
+	require.NoError(t, err)
+	return resp
+}
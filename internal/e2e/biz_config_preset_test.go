@@ -0,0 +1,200 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// savePreset 保存配置预设
+func (h *harness) savePreset(t *testing.T, token string, body map[string]any) domain.BizConfigPreset {
+	t.Helper()
+
+	code, res := call[domain.BizConfigPreset](t, h, http.MethodPost, "/api/v1/biz_config_preset/save", token, body)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	require.NotZero(t, res.Data.Id)
+	return res.Data
+}
+
+func (h *harness) findBizConfig(t *testing.T, token string, bizId uint64) *domain.BizConfig {
+	t.Helper()
+
+	code, res := call[*domain.BizConfig](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", bizId), token, nil)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	return res.Data
+}
+
+func smsOnlyPreset(isDefault bool) map[string]any {
+	return map[string]any{
+		"name":        "sms-only-standard",
+		"description": "sms only",
+		"rate_limit":  100,
+		"is_default":  isDefault,
+		"channel_config": map[string]any{
+			"channels":            []map[string]any{{"channel": 1, "priority": 1, "enabled": true}},
+			"retry_policy_config": map[string]any{"initial_interval": "1s", "max_interval": "1m", "max_retry_times": 3},
+		},
+		"quota_config": map[string]any{
+			"daily":   map[string]any{"sms": 1000},
+			"monthly": map[string]any{"sms": 20000},
+		},
+	}
+}
+
+func TestBizConfigPreset_CreateBiz(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	// 没有默认预设时不初始化配置
+	plain := h.saveBiz(t, token, "plain")
+	assert.Nil(t, h.findBizConfig(t, token, plain.Id))
+
+	preset := h.savePreset(t, token, smsOnlyPreset(true))
+	assert.True(t, preset.IsDefault)
+
+	// 新建业务方时使用默认预设
+	order := h.saveBiz(t, token, "order")
+	cfg := h.findBizConfig(t, token, order.Id)
+	require.NotNil(t, cfg)
+	assert.Equal(t, int32(100), cfg.RateLimit)
+	require.NotNil(t, cfg.ChannelConfig)
+	assert.Equal(t, []domain.ChannelItem{{Channel: 1, Priority: 1, Enabled: true}}, cfg.ChannelConfig.Channels)
+	assert.Equal(t, domain.Duration(time.Second), cfg.ChannelConfig.RetryPolicyConfig.InitialInterval)
+	require.NotNil(t, cfg.QuotaConfig)
+	assert.Equal(t, &domain.Quota{Sms: 1000}, cfg.QuotaConfig.Daily)
+
+	// 指定预设
+	enterprise := h.savePreset(t, token, map[string]any{
+		"name":         "enterprise-email",
+		"rate_limit":   500,
+		"quota_config": map[string]any{"daily": map[string]any{"email": 5000}},
+	})
+	code, res := call[domain.BizInfo](t, h, http.MethodPost, "/api/v1/biz_info/save", token, map[string]any{
+		"biz_type":      string(domain.BizTypeOrganization),
+		"biz_key":       "user",
+		"biz_name":      "user biz",
+		"contact":       "user contact",
+		"contact_email": "user@kuryr.test",
+		"preset_id":     enterprise.Id,
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	cfg = h.findBizConfig(t, token, res.Data.Id)
+	require.NotNil(t, cfg)
+	assert.Equal(t, int32(500), cfg.RateLimit)
+	assert.Nil(t, cfg.ChannelConfig)
+
+	// 指定的预设不存在时不创建业务方
+	code, res = call[domain.BizInfo](t, h, http.MethodPost, "/api/v1/biz_info/save", token, map[string]any{
+		"biz_type":  string(domain.BizTypeIndividual),
+		"biz_key":   "missing",
+		"preset_id": 9999,
+	})
+	assert.Equal(t, http.StatusBadRequest, code, res.Msg)
+
+	// 配置版本记录应用的预设
+	code, versions := call[pkggorm.PaginationResult[domain.BizConfigVersion]](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/versions?biz_id=%d", order.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, versions.Msg)
+	require.Len(t, versions.Data.Records, 1)
+	assert.Equal(t, "apply preset sms-only-standard", versions.Data.Records[0].Comment)
+}
+
+func TestBizConfigPreset_ManageAndOverrides(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	order := h.saveBiz(t, token, "order")
+
+	preset := h.savePreset(t, token, smsOnlyPreset(true))
+
+	// 名称重复
+	code, _ := call[any](t, h, http.MethodPost, "/api/v1/biz_config_preset/save", token, smsOnlyPreset(false))
+	assert.Equal(t, http.StatusConflict, code)
+
+	// 配置不合法
+	invalid := smsOnlyPreset(false)
+	invalid["name"] = "invalid"
+	invalid["rate_limit"] = -1
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config_preset/save", token, invalid)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// 新的默认预设取消原默认预设
+	other := h.savePreset(t, token, map[string]any{"name": "other", "rate_limit": 1, "is_default": true})
+	code, found := call[domain.BizConfigPreset](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config_preset/get?id=%d", preset.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	assert.False(t, found.Data.IsDefault)
+
+	code, list := call[pkggorm.PaginationResult[domain.BizConfigPreset]](t, h, http.MethodGet, "/api/v1/biz_config_preset/list?offset=0&limit=10", token, nil)
+	require.Equal(t, http.StatusOK, code, list.Msg)
+	require.Len(t, list.Data.Records, 2)
+
+	// 业务方未基于预设
+	code, _ = call[any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config_preset/overrides?biz_id=%d", order.Id), token, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// 应用预设时保留回调配置
+	code, saved := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":          order.Id,
+		"rate_limit":      7,
+		"callback_config": map[string]any{"service_name": callbackService},
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	code, applied := call[domain.BizConfig](t, h, http.MethodPost, "/api/v1/biz_config_preset/apply", token, map[string]any{
+		"biz_id":    order.Id,
+		"preset_id": preset.Id,
+	})
+	require.Equal(t, http.StatusOK, code, applied.Msg)
+	assert.Equal(t, int32(100), applied.Data.RateLimit)
+	require.NotNil(t, applied.Data.CallbackConfig)
+	assert.Equal(t, callbackService, applied.Data.CallbackConfig.ServiceName)
+
+	code, overrides := call[domain.BizConfigPresetOverrides](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config_preset/overrides?biz_id=%d", order.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, overrides.Msg)
+	assert.Equal(t, preset.Id, overrides.Data.Binding.PresetId)
+	assert.NotZero(t, overrides.Data.Binding.AppliedBy)
+	assert.Empty(t, overrides.Data.Overrides)
+
+	// 业务方在预设基础上调整配置
+	code, saved = call[any](t, h, http.MethodPatch, "/api/v1/biz_config/update", token, map[string]any{
+		"biz_id":       order.Id,
+		"rate_limit":   200,
+		"quota_config": map[string]any{"daily": map[string]any{"sms": 2000}},
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	code, overrides = call[domain.BizConfigPresetOverrides](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config_preset/overrides?biz_id=%d", order.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, overrides.Msg)
+	assert.Len(t, overrides.Data.Overrides, 2)
+	assert.Equal(t, float64(100), overrides.Data.Overrides["rate_limit"].Before)
+	assert.Equal(t, float64(200), overrides.Data.Overrides["rate_limit"].After)
+	assert.Equal(t, float64(2000), overrides.Data.Overrides["quota_config.daily.sms"].After)
+
+	// 操作员可以查看自己的差异，不能维护预设
+	operatorToken := h.loginOperator(t, order)
+	code, _ = call[any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config_preset/overrides?biz_id=%d", order.Id), operatorToken, nil)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config_preset/apply", operatorToken, map[string]any{"biz_id": order.Id, "preset_id": other.Id})
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config_preset/save", operatorToken, map[string]any{"name": "x"})
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// 删除预设后业务方配置保持不变
+	code, _ = call[any](t, h, http.MethodDelete, fmt.Sprintf("/api/v1/biz_config_preset/delete?id=%d", preset.Id), token, nil)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = call[any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config_preset/overrides?biz_id=%d", order.Id), token, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, int32(200), h.findBizConfig(t, token, order.Id).RateLimit)
+
+	logs := h.searchAudit(t, token, "target_type=biz_config_preset")
+	require.Len(t, logs, 3)
+	assert.Equal(t, domain.AuditActionDelete, logs[0].Action)
+}
@@ -0,0 +1,473 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestBizConfig_SaveAndFind(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	findPath := fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id)
+
+	// 未保存配置时返回空数据
+	code, found := call[*domain.BizConfig](t, h, http.MethodGet, findPath, token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	assert.Nil(t, found.Data)
+
+	retry := map[string]any{"initial_interval": 2000, "max_interval": 120000, "max_retry_times": 8}
+	code, saved := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":     biz.Id,
+		"rate_limit": 100,
+		"channel_config": map[string]any{
+			"channels":            []map[string]any{{"channel": 2, "priority": 1, "enabled": true}},
+			"retry_policy_config": retry,
+		},
+		"quota_config": map[string]any{
+			"daily":   map[string]any{"sms": 100, "email": 300},
+			"monthly": map[string]any{"sms": 1500, "email": 5000},
+		},
+		"callback_config": map[string]any{
+			"service_name":        callbackService,
+			"retry_policy_config": retry,
+		},
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	code, found = call[*domain.BizConfig](t, h, http.MethodGet, findPath, token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	cfg := found.Data
+	assert.Equal(t, biz.Id, cfg.BizId)
+	assert.Equal(t, int32(100), cfg.RateLimit)
+	require.NotNil(t, cfg.ChannelConfig)
+	assert.Equal(t, []domain.ChannelItem{{Channel: 2, Priority: 1, Enabled: true}}, cfg.ChannelConfig.Channels)
+	require.NotNil(t, cfg.ChannelConfig.RetryPolicyConfig)
+	// 整数重试间隔单位为毫秒
+	assert.Equal(t, &domain.RetryConfig{
+		InitialInterval: domain.Duration(2 * time.Second),
+		MaxInterval:     domain.Duration(2 * time.Minute),
+		MaxRetryTimes:   8,
+	}, cfg.ChannelConfig.RetryPolicyConfig)
+	require.NotNil(t, cfg.QuotaConfig)
+	assert.Equal(t, &domain.Quota{Sms: 100, Email: 300}, cfg.QuotaConfig.Daily)
+	assert.Equal(t, &domain.Quota{Sms: 1500, Email: 5000}, cfg.QuotaConfig.Monthly)
+	require.NotNil(t, cfg.CallbackConfig)
+	assert.Equal(t, callbackService, cfg.CallbackConfig.ServiceName)
+	assert.Equal(t, cfg.ChannelConfig.RetryPolicyConfig, cfg.CallbackConfig.RetryPolicyConfig)
+
+	// 重复新建配置失败
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{"biz_id": biz.Id})
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	// 指定 id 时更新配置
+	code, saved = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"id":         cfg.Id,
+		"biz_id":     biz.Id,
+		"rate_limit": 200,
+		"quota_config": map[string]any{
+			"daily":   map[string]any{"sms": 10, "email": 30},
+			"monthly": map[string]any{"sms": 150, "email": 500},
+		},
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	code, found = call[*domain.BizConfig](t, h, http.MethodGet, findPath, token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	assert.Equal(t, int32(200), found.Data.RateLimit)
+	assert.Equal(t, &domain.Quota{Sms: 10, Email: 30}, found.Data.QuotaConfig.Daily)
+	// 更新时未提交的配置块被清空
+	assert.Nil(t, found.Data.ChannelConfig)
+	assert.Nil(t, found.Data.CallbackConfig)
+
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{"biz_id": 0})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// 操作员不能保存其他业务方配置
+	other := h.saveBiz(t, token, "user")
+	code, saved = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", h.loginOperator(t, other), map[string]any{
+		"biz_id":     biz.Id,
+		"rate_limit": 1,
+	})
+	assert.Equal(t, http.StatusForbidden, code, saved.Msg)
+
+	// 操作员不能查询其他业务方配置
+	code, found = call[*domain.BizConfig](t, h, http.MethodGet, findPath, h.loginOperator(t, other), nil)
+	assert.Equal(t, http.StatusForbidden, code, found.Msg)
+	assert.Nil(t, found.Data)
+
+	// 操作员不能通过其他业务方配置的 id 覆盖其他业务方配置
+	foreign := map[string]any{"id": cfg.Id, "biz_id": other.Id, "rate_limit": 1}
+	code, saved = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", h.loginOperator(t, other), foreign)
+	assert.Equal(t, http.StatusBadRequest, code, saved.Msg)
+
+	code, saved = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{"biz_id": other.Id})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+	code, saved = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", h.loginOperator(t, other), foreign)
+	assert.Equal(t, http.StatusBadRequest, code, saved.Msg)
+	assert.Contains(t, saved.Msg, fmt.Sprintf("id: config %d does not belong to biz %d", cfg.Id, other.Id))
+
+	code, found = call[*domain.BizConfig](t, h, http.MethodGet, findPath, token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	assert.Equal(t, int32(200), found.Data.RateLimit)
+	assert.NotNil(t, found.Data.QuotaConfig)
+}
+
+func TestBizConfig_Patch(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	patch := func(body map[string]any) (int, domain.BizConfig) {
+		t.Helper()
+		body["biz_id"] = biz.Id
+		code, res := call[domain.BizConfig](t, h, http.MethodPatch, "/api/v1/biz_config/update", token, body)
+		return code, res.Data
+	}
+
+	// 配置不存在
+	code, _ := patch(map[string]any{"rate_limit": 10})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	retry := map[string]any{"initial_interval": 2000, "max_interval": 120000, "max_retry_times": 8}
+	code, saved := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":     biz.Id,
+		"rate_limit": 100,
+		"channel_config": map[string]any{
+			"channels":            []map[string]any{{"channel": 2, "priority": 1, "enabled": true}},
+			"retry_policy_config": retry,
+		},
+		"quota_config": map[string]any{
+			"daily":   map[string]any{"sms": 100, "email": 300},
+			"monthly": map[string]any{"sms": 1500, "email": 5000},
+		},
+		"callback_config": map[string]any{"service_name": callbackService, "retry_policy_config": retry},
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	// 只更新限流，其他配置块保持不变
+	code, cfg := patch(map[string]any{"rate_limit": 200})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int32(200), cfg.RateLimit)
+	require.NotNil(t, cfg.ChannelConfig)
+	assert.Equal(t, []domain.ChannelItem{{Channel: 2, Priority: 1, Enabled: true}}, cfg.ChannelConfig.Channels)
+	require.NotNil(t, cfg.QuotaConfig)
+	assert.Equal(t, &domain.Quota{Sms: 100, Email: 300}, cfg.QuotaConfig.Daily)
+	require.NotNil(t, cfg.CallbackConfig)
+	assert.Equal(t, callbackService, cfg.CallbackConfig.ServiceName)
+
+	// 只更新每日配额
+	code, cfg = patch(map[string]any{"quota_config": map[string]any{"daily": map[string]any{"sms": 10, "email": 30}}})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int32(200), cfg.RateLimit)
+	require.NotNil(t, cfg.QuotaConfig)
+	assert.Equal(t, &domain.Quota{Sms: 10, Email: 30}, cfg.QuotaConfig.Daily)
+	assert.Equal(t, &domain.Quota{Sms: 1500, Email: 5000}, cfg.QuotaConfig.Monthly)
+
+	// null 清空字段
+	code, cfg = patch(map[string]any{"quota_config": map[string]any{"monthly": nil}, "callback_config": nil})
+	require.Equal(t, http.StatusOK, code)
+	require.NotNil(t, cfg.QuotaConfig)
+	assert.Equal(t, &domain.Quota{Sms: 10, Email: 30}, cfg.QuotaConfig.Daily)
+	assert.Nil(t, cfg.QuotaConfig.Monthly)
+	assert.Nil(t, cfg.CallbackConfig)
+	require.NotNil(t, cfg.ChannelConfig)
+
+	code, found := call[*domain.BizConfig](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	assert.Equal(t, cfg, *found.Data)
+
+	// 没有需要更新的字段
+	code, _ = patch(map[string]any{"comment": "nothing"})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = patch(map[string]any{"quota_config": map[string]any{}})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = patch(map[string]any{"rate_limit": -1})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// 操作员不能更新其他业务方配置
+	other := h.saveBiz(t, token, "user")
+	code, res := call[any](t, h, http.MethodPatch, "/api/v1/biz_config/update", h.loginOperator(t, other), map[string]any{
+		"biz_id":     biz.Id,
+		"rate_limit": 1,
+	})
+	assert.Equal(t, http.StatusForbidden, code, res.Msg)
+}
+
+func TestBizConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	// 未传重试策略时使用 kuryr 默认策略
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":          biz.Id,
+		"channel_config":  map[string]any{"channels": []map[string]any{{"channel": 1, "priority": 1, "enabled": true}}},
+		"callback_config": map[string]any{"service_name": callbackService},
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	code, res = call[any](t, h, http.MethodPatch, "/api/v1/biz_config/update", token, map[string]any{
+		"biz_id": biz.Id,
+		"channel_config": map[string]any{
+			"channels": []map[string]any{
+				{"channel": 1, "priority": -1},
+				{"channel": 1, "priority": 1},
+			},
+			"retry_policy_config": map[string]any{"initial_interval": 2000, "max_interval": 1000},
+		},
+		"quota_config":    map[string]any{"daily": map[string]any{"sms": -1}},
+		"callback_config": map[string]any{"service_name": "unknown"},
+	})
+	require.Equal(t, http.StatusBadRequest, code)
+	for _, want := range []string{
+		"channel_config.channels[0].priority",
+		"channel_config.channels[1].channel: duplicate channel 1",
+		"channel_config.retry_policy_config.max_interval",
+		"quota_config.daily.sms",
+		`callback_config.service_name: service "unknown" not found in registry`,
+	} {
+		assert.Contains(t, res.Msg, want)
+	}
+
+	// 校验失败时配置保持不变
+	code, found := call[*domain.BizConfig](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	assert.Nil(t, found.Data.QuotaConfig)
+	assert.Equal(t, callbackService, found.Data.CallbackConfig.ServiceName)
+}
+
+func TestBizConfig_PatchWithCallbackOffline(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":          biz.Id,
+		"rate_limit":      100,
+		"callback_config": map[string]any{"service_name": callbackService},
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	// 回调服务下线后，不修改回调配置的更新不受影响
+	require.NoError(t, h.registry.Unregister(context.Background(), registry.ServiceInstance{
+		Name: callbackService,
+		Addr: "127.0.0.1:0",
+	}))
+
+	code, cfg := call[domain.BizConfig](t, h, http.MethodPatch, "/api/v1/biz_config/update", token, map[string]any{
+		"biz_id":     biz.Id,
+		"rate_limit": 200,
+	})
+	require.Equal(t, http.StatusOK, code, cfg.Msg)
+	assert.Equal(t, int32(200), cfg.Data.RateLimit)
+	require.NotNil(t, cfg.Data.CallbackConfig)
+	assert.Equal(t, callbackService, cfg.Data.CallbackConfig.ServiceName)
+
+	// 修改回调服务时仍然检查注册中心
+	code, res = call[any](t, h, http.MethodPatch, "/api/v1/biz_config/update", token, map[string]any{
+		"biz_id":          biz.Id,
+		"callback_config": map[string]any{"service_name": "unknown"},
+	})
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, res.Msg, `callback_config.service_name: service "unknown" not found in registry`)
+}
+
+func TestBizConfig_RetryInterval(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id": biz.Id,
+		"channel_config": map[string]any{
+			"retry_policy_config": map[string]any{"initial_interval": "500ms", "max_interval": "1m30s", "max_retry_times": 3},
+		},
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	// 响应内的重试间隔为字符串
+	code, raw := call[map[string]any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, raw.Msg)
+	channel := raw.Data["channel_config"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"initial_interval": "500ms",
+		"max_interval":     "1m30s",
+		"max_retry_times":  float64(3),
+	}, channel["retry_policy_config"])
+
+	code, res = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id": biz.Id,
+		"channel_config": map[string]any{
+			"retry_policy_config": map[string]any{"initial_interval": "2 seconds"},
+		},
+	})
+	assert.Equal(t, http.StatusBadRequest, code, res.Msg)
+}
+
+func TestBizConfig_SimulateRetry(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	code, res := call[domain.RetrySchedule](t, h, http.MethodPost, "/api/v1/biz_config/simulate_retry", token, map[string]any{
+		"initial_interval": "500ms",
+		"max_interval":     "2s",
+		"max_retry_times":  4,
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	assert.Equal(t, domain.RetrySchedule{
+		Attempts: []domain.RetryAttempt{
+			{Attempt: 1, Interval: domain.Duration(500 * time.Millisecond), Elapsed: domain.Duration(500 * time.Millisecond)},
+			{Attempt: 2, Interval: domain.Duration(time.Second), Elapsed: domain.Duration(1500 * time.Millisecond)},
+			{Attempt: 3, Interval: domain.Duration(2 * time.Second), Elapsed: domain.Duration(3500 * time.Millisecond)},
+			{Attempt: 4, Interval: domain.Duration(2 * time.Second), Elapsed: domain.Duration(5500 * time.Millisecond)},
+		},
+		TotalDelay: domain.Duration(5500 * time.Millisecond),
+	}, res.Data)
+
+	code, res = call[domain.RetrySchedule](t, h, http.MethodPost, "/api/v1/biz_config/simulate_retry", token, map[string]any{
+		"initial_interval": 0,
+		"max_interval":     1000,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, res.Msg, "retry_policy_config.initial_interval")
+}
+
+// serveGrpc 在随机端口启动 grpc 服务，测试结束时停止
+func serveGrpc(t *testing.T, withHealth bool) (string, *health.Server) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	svr := grpc.NewServer()
+	hs := health.NewServer()
+	if withHealth {
+		healthv1.RegisterHealthServer(svr, hs)
+	}
+	go func() { _ = svr.Serve(lis) }()
+	t.Cleanup(svr.Stop)
+	return lis.Addr().String(), hs
+}
+
+func TestBizConfig_ProbeCallback(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	const service = "order-callback"
+	servingAddr, hs := serveGrpc(t, true)
+	noHealthAddr, _ := serveGrpc(t, false)
+	for _, addr := range []string{servingAddr, noHealthAddr, "127.0.0.1:0"} {
+		require.NoError(t, h.registry.Register(context.Background(), registry.ServiceInstance{Name: service, Addr: addr}))
+	}
+
+	probe := func(path string) map[string]domain.CallbackInstanceProbe {
+		t.Helper()
+
+		code, res := call[domain.CallbackProbe](t, h, http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, code, res.Msg)
+		assert.Equal(t, service, res.Data.ServiceName)
+
+		instances := make(map[string]domain.CallbackInstanceProbe, len(res.Data.Instances))
+		for _, instance := range res.Data.Instances {
+			instances[instance.Addr] = instance
+		}
+		return instances
+	}
+
+	probePath := fmt.Sprintf("/api/v1/biz_config/callback_probe?biz_id=%d&service_name=%s", biz.Id, service)
+	instances := probe(probePath)
+	require.Len(t, instances, 3)
+	assert.Equal(t, domain.CallbackProbeStatusServing, instances[servingAddr].Status)
+	assert.Empty(t, instances[servingAddr].Error)
+	assert.Equal(t, domain.CallbackProbeStatusUnimplemented, instances[noHealthAddr].Status)
+	assert.Equal(t, domain.CallbackProbeStatusUnreachable, instances["127.0.0.1:0"].Status)
+	assert.NotEmpty(t, instances["127.0.0.1:0"].Error)
+
+	hs.SetServingStatus("", healthv1.HealthCheckResponse_NOT_SERVING)
+	instances = probe(probePath)
+	assert.Equal(t, domain.CallbackProbeStatusNotServing, instances[servingAddr].Status)
+	hs.SetServingStatus("", healthv1.HealthCheckResponse_SERVING)
+
+	// 未指定 service_name 且业务方没有回调配置
+	bizPath := fmt.Sprintf("/api/v1/biz_config/callback_probe?biz_id=%d", biz.Id)
+	code, res := call[any](t, h, http.MethodGet, bizPath, token, nil)
+	assert.Equal(t, http.StatusBadRequest, code, res.Msg)
+
+	// 未指定 service_name 时探测当前配置的回调服务
+	code, res = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id": biz.Id,
+		"callback_config": map[string]any{
+			"service_name":        service,
+			"retry_policy_config": map[string]any{"initial_interval": "1s", "max_interval": "10s", "max_retry_times": 3},
+		},
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	instances = probe(bizPath)
+	assert.Equal(t, domain.CallbackProbeStatusServing, instances[servingAddr].Status)
+
+	// 注册中心内没有实例
+	code, empty := call[domain.CallbackProbe](t, h, http.MethodGet,
+		fmt.Sprintf("/api/v1/biz_config/callback_probe?biz_id=%d&service_name=unknown", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, empty.Msg)
+	assert.Empty(t, empty.Data.Instances)
+	assert.Zero(t, empty.Data.Serving)
+
+	// 操作员不能探测其他业务方的回调服务
+	other := h.saveBiz(t, token, "payment")
+	operatorToken := h.loginOperator(t, other)
+	code, res = call[any](t, h, http.MethodGet, probePath, operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code, res.Msg)
+
+	// 操作员只能探测业务方已配置的回调服务
+	ownToken := h.loginOperator(t, biz)
+	code, own := call[domain.CallbackProbe](t, h, http.MethodGet, probePath, ownToken, nil)
+	require.Equal(t, http.StatusOK, code, own.Msg)
+	assert.Len(t, own.Data.Instances, 3)
+	code, own = call[domain.CallbackProbe](t, h, http.MethodGet, bizPath, ownToken, nil)
+	require.Equal(t, http.StatusOK, code, own.Msg)
+	assert.Equal(t, service, own.Data.ServiceName)
+
+	for _, name := range []string{callbackService, "unknown"} {
+		code, res = call[any](t, h, http.MethodGet,
+			fmt.Sprintf("/api/v1/biz_config/callback_probe?biz_id=%d&service_name=%s", biz.Id, name), ownToken, nil)
+		assert.Equal(t, http.StatusForbidden, code, res.Msg)
+	}
+	code, res = call[any](t, h, http.MethodGet,
+		fmt.Sprintf("/api/v1/biz_config/callback_probe?biz_id=%d&service_name=%s", other.Id, service), operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code, res.Msg)
+}
@@ -0,0 +1,169 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bizConfigDiff struct {
+	From    uint64                    `json:"from"`
+	To      uint64                    `json:"to"`
+	Changes map[string]map[string]any `json:"changes"`
+}
+
+func TestBizConfigVersion_DiffAndRollback(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	code, saved := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":       biz.Id,
+		"rate_limit":   100,
+		"quota_config": map[string]any{"daily": map[string]any{"sms": 100, "email": 300}},
+		"comment":      "init",
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	code, found := call[*domain.BizConfig](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+
+	code, saved = call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"id":           found.Data.Id,
+		"biz_id":       biz.Id,
+		"rate_limit":   200,
+		"quota_config": map[string]any{"daily": map[string]any{"sms": 10, "email": 300}},
+		"comment":      "limit sms",
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	// 按版本号倒序返回
+	versionsPath := fmt.Sprintf("/api/v1/biz_config/versions?biz_id=%d&offset=0&limit=10", biz.Id)
+	code, versions := call[pkggorm.PaginationResult[domain.BizConfigVersion]](t, h, http.MethodGet, versionsPath, token, nil)
+	require.Equal(t, http.StatusOK, code, versions.Msg)
+	require.Len(t, versions.Data.Records, 2)
+	assert.Equal(t, uint64(2), versions.Data.Records[0].Version)
+	assert.Equal(t, "limit sms", versions.Data.Records[0].Comment)
+	assert.NotZero(t, versions.Data.Records[0].AuthorId)
+	assert.Equal(t, uint64(1), versions.Data.Records[1].Version)
+	assert.Equal(t, "init", versions.Data.Records[1].Comment)
+
+	code, version := call[domain.BizConfigVersion](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/version?biz_id=%d&version=1", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, version.Msg)
+	assert.Equal(t, int32(100), version.Data.Config.RateLimit)
+
+	code, _ = call[any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/version?biz_id=%d&version=9", biz.Id), token, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, diff := call[bizConfigDiff](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/diff?biz_id=%d&from=1&to=2", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, diff.Msg)
+	assert.Equal(t, map[string]map[string]any{
+		"rate_limit":             {"before": float64(100), "after": float64(200)},
+		"quota_config.daily.sms": {"before": float64(100), "after": float64(10)},
+	}, diff.Data.Changes)
+
+	// 回滚到版本 1，生成新版本
+	code, rolled := call[domain.BizConfig](t, h, http.MethodPost, "/api/v1/biz_config/rollback", token, map[string]any{
+		"biz_id":  biz.Id,
+		"version": 1,
+		"comment": "revert",
+	})
+	require.Equal(t, http.StatusOK, code, rolled.Msg)
+	assert.Equal(t, int32(100), rolled.Data.RateLimit)
+
+	code, found = call[*domain.BizConfig](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	assert.Equal(t, int32(100), found.Data.RateLimit)
+	require.NotNil(t, found.Data.QuotaConfig)
+	assert.Equal(t, &domain.Quota{Sms: 100, Email: 300}, found.Data.QuotaConfig.Daily)
+
+	code, versions = call[pkggorm.PaginationResult[domain.BizConfigVersion]](t, h, http.MethodGet, versionsPath, token, nil)
+	require.Equal(t, http.StatusOK, code, versions.Msg)
+	require.Len(t, versions.Data.Records, 3)
+	assert.Equal(t, uint64(3), versions.Data.Records[0].Version)
+	assert.Equal(t, uint64(1), versions.Data.Records[0].RollbackFrom)
+
+	code, diff = call[bizConfigDiff](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/diff?biz_id=%d&from=1&to=3", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, diff.Msg)
+	assert.Empty(t, diff.Data.Changes)
+
+	// 回滚记录审计日志
+	logs := h.searchAudit(t, token, "action=rollback")
+	require.Len(t, logs, 1)
+	assert.Equal(t, biz.Id, logs[0].TargetId)
+
+	// 回滚不存在的版本
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config/rollback", token, map[string]any{"biz_id": biz.Id, "version": 9})
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestBizConfigVersion_CommentTooLong(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	// 按字符计算长度，最大长度的中文说明可以保存
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":     biz.Id,
+		"rate_limit": 100,
+		"comment":    strings.Repeat("初", domain.MaxBizConfigVersionCommentLen),
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	// 说明过长时在保存配置之前拒绝，配置与版本保持不变
+	long := strings.Repeat("a", domain.MaxBizConfigVersionCommentLen+1)
+	for _, req := range []struct {
+		method, path string
+		body         map[string]any
+	}{
+		{http.MethodPost, "/api/v1/biz_config/save", map[string]any{"biz_id": biz.Id, "rate_limit": 200}},
+		{http.MethodPatch, "/api/v1/biz_config/update", map[string]any{"biz_id": biz.Id, "rate_limit": 200}},
+		{http.MethodPost, "/api/v1/biz_config/rollback", map[string]any{"biz_id": biz.Id, "version": 1}},
+	} {
+		req.body["comment"] = long
+		code, res = call[any](t, h, req.method, req.path, token, req.body)
+		assert.Equal(t, http.StatusBadRequest, code, req.path)
+		assert.Contains(t, res.Msg, "invalid comment", req.path)
+	}
+
+	code, found := call[*domain.BizConfig](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/find?biz_id=%d", biz.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.NotNil(t, found.Data)
+	assert.Equal(t, int32(100), found.Data.RateLimit)
+
+	versionsPath := fmt.Sprintf("/api/v1/biz_config/versions?biz_id=%d&offset=0&limit=10", biz.Id)
+	code, versions := call[pkggorm.PaginationResult[domain.BizConfigVersion]](t, h, http.MethodGet, versionsPath, token, nil)
+	require.Equal(t, http.StatusOK, code, versions.Msg)
+	assert.Len(t, versions.Data.Records, 1)
+}
+
+func TestBizConfigVersion_Operator(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	order := h.saveBiz(t, token, "order")
+	user := h.saveBiz(t, token, "user")
+	operatorToken := h.loginOperator(t, order)
+
+	code, res := call[any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/versions?biz_id=%d", order.Id), operatorToken, nil)
+	assert.Equal(t, http.StatusOK, code, res.Msg)
+
+	code, _ = call[any](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_config/versions?biz_id=%d", user.Id), operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/biz_config/rollback", operatorToken, map[string]any{"biz_id": user.Id, "version": 1})
+	assert.Equal(t, http.StatusForbidden, code)
+}
@@ -0,0 +1,116 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// saveBiz 新建业务方，返回新建的业务方信息
+func (h *harness) saveBiz(t *testing.T, token string, key string) domain.BizInfo {
+	t.Helper()
+
+	code, res := call[domain.BizInfo](t, h, http.MethodPost, "/api/v1/biz_info/save", token, map[string]string{
+		"biz_type":      string(domain.BizTypeIndividual),
+		"biz_key":       key,
+		"biz_name":      key + " biz",
+		"contact":       key + " contact",
+		"contact_email": key + "@kuryr.test",
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	require.NotZero(t, res.Data.Id)
+	return res.Data
+}
+
+// loginOperator 使用业务方的操作员账号登录，返回 access token
+func (h *harness) loginOperator(t *testing.T, bi domain.BizInfo) string {
+	t.Helper()
+
+	// 新建业务方时会同时创建操作员，密码随机生成，这里直接重置为已知密码
+	const passwd = "operator-passwd"
+	hashed, err := bcrypt.GenerateFromPassword([]byte(passwd), bcrypt.MinCost)
+	require.NoError(t, err)
+	res := h.db.Model(&dao.SysUser{}).Where("biz_id = ?", bi.Id).Update("password", string(hashed))
+	require.NoError(t, res.Error)
+	require.Equal(t, int64(1), res.RowsAffected)
+
+	return h.login(t, bi.ContactEmail, passwd).AccessToken
+}
+
+func TestBizInfo_CRUD(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	order := h.saveBiz(t, token, "order")
+	h.saveBiz(t, token, "user")
+
+	code, updated := call[domain.BizInfo](t, h, http.MethodPut, "/api/v1/biz_info/update", token, map[string]any{
+		"id":       order.Id,
+		"biz_name": "order center",
+		"contact":  "order contact",
+	})
+	require.Equal(t, http.StatusOK, code, updated.Msg)
+	assert.Equal(t, "order center", updated.Data.BizName)
+
+	code, found := call[domain.BizInfo](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_info/get?biz_id=%d", order.Id), token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	assert.Equal(t, "order", found.Data.BizKey)
+	assert.Equal(t, "order center", found.Data.BizName)
+	assert.Equal(t, adminEmail, found.Data.Creator.Email)
+	assert.Empty(t, found.Data.Creator.Password)
+
+	code, searched := call[pkggorm.PaginationResult[domain.BizInfo]](t, h, http.MethodGet, "/api/v1/biz_info/search?biz_name=center&offset=0&limit=10", token, nil)
+	require.Equal(t, http.StatusOK, code, searched.Msg)
+	assert.Equal(t, int64(1), searched.Data.Total)
+	require.Len(t, searched.Data.Records, 1)
+	assert.Equal(t, order.Id, searched.Data.Records[0].Id)
+
+	code, searched = call[pkggorm.PaginationResult[domain.BizInfo]](t, h, http.MethodGet, "/api/v1/biz_info/search?offset=1&limit=10", token, nil)
+	require.Equal(t, http.StatusOK, code, searched.Msg)
+	assert.Equal(t, int64(2), searched.Data.Total)
+	assert.Len(t, searched.Data.Records, 1)
+
+	code, _ = call[any](t, h, http.MethodDelete, fmt.Sprintf("/api/v1/biz_info/delete?biz_id=%d", order.Id), token, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = call[domain.BizInfo](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_info/get?biz_id=%d", order.Id), token, nil)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestBizInfo_Operator(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	order := h.saveBiz(t, token, "order")
+	other := h.saveBiz(t, token, "other")
+
+	operatorToken := h.loginOperator(t, order)
+
+	code, found := call[domain.BizInfo](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_info/get?biz_id=%d", order.Id), operatorToken, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	assert.Equal(t, order.BizKey, found.Data.BizKey)
+
+	// 操作员只能查看自己的业务方，不能新建、修改、删除业务方
+	code, _ = call[domain.BizInfo](t, h, http.MethodGet, fmt.Sprintf("/api/v1/biz_info/get?biz_id=%d", other.Id), operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = call[domain.BizInfo](t, h, http.MethodPost, "/api/v1/biz_info/save", operatorToken, map[string]string{"biz_key": "forbidden"})
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = call[domain.BizInfo](t, h, http.MethodPut, "/api/v1/biz_info/update", operatorToken, map[string]any{"id": order.Id, "biz_name": "forbidden"})
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = call[any](t, h, http.MethodDelete, fmt.Sprintf("/api/v1/biz_info/delete?biz_id=%d", order.Id), operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+}
@@ -0,0 +1,6 @@
+// Package e2e 端到端测试。
+//
+// 测试使用与 main 相同的模块列表启动完整的 fx 依赖图，依赖替换为进程内实现：
+// Redis 使用 miniredis，Postgres 使用临时目录下的 sqlite，kuryr 服务使用 fake 后端，
+// 然后通过真实的 HTTP 请求调用 admin API。
+package e2e
@@ -0,0 +1,228 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/ioc"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	adminEmail  = "admin@kuryr.test"
+	adminPasswd = "admin-passwd"
+
+	// callbackService 注册在 fake 注册中心内的回调服务
+	callbackService = "kuryr-admin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	os.Exit(m.Run())
+}
+
+// harness 一个完整启动的 kuryr-admin 实例
+type harness struct {
+	baseURL string
+	client  *http.Client
+
+	db        *gorm.DB
+	templates *pkggrpc.Manager[templatev1.TemplateServiceClient]
+	registry  registry.Registry
+	cfg       *config.Config
+}
+
+// newHarness 启动 kuryr-admin，测试结束时停止，opts 用于替换默认的依赖
+func newHarness(t *testing.T, opts ...fx.Option) *harness {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, nil, 0o600))
+
+	cfg := testConfig(t, mr.Addr())
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{
+		client: &http.Client{Timeout: 10 * time.Second},
+		cfg:    cfg,
+	}
+
+	var engine *gin.Engine
+	app := fx.New(
+		fx.NopLogger,
+		fx.Supply(cfg, config.NewReloader(cfgPath, cfg)),
+		ioc.Modules(cfg),
+		// 使用 sqlite 代替 postgres
+		fx.Decorate(func(gorm.Dialector) gorm.Dialector {
+			return sqlite.Open(filepath.Join(dir, "kuryr_admin.db") + "?_pragma=busy_timeout(5000)")
+		}),
+		fx.Populate(&engine, &h.db, &h.templates, &h.registry),
+		fx.Options(opts...),
+	)
+	require.NoError(t, app.Err())
+	require.NoError(t, h.registry.Register(context.Background(), registry.ServiceInstance{
+		Name: callbackService,
+		Addr: "127.0.0.1:0",
+	}))
+
+	h.migrate(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(ctx))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	svr := httptest.NewServer(engine)
+	t.Cleanup(svr.Close)
+	h.baseURL = svr.URL
+	return h
+}
+
+func testConfig(t *testing.T, redisAddr string) *config.Config {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	cfg := config.Default()
+	cfg.Profile.Env = "test"
+	cfg.Log.Level = "error"
+	// 由 httptest.Server 对外提供服务，app 自身监听随机端口
+	cfg.App.Addr = "127.0.0.1:0"
+	cfg.DB.DSN = "sqlite"
+	cfg.DB.LogLevel = "silent"
+	// 迁移脚本使用 postgres 语法，sqlite 下由 migrate 按 dao 模型创建表结构
+	cfg.DB.MigrationCheck = false
+	cfg.Redis.Addr = redisAddr
+	cfg.Jwt = config.JwtConfig{
+		Private: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})),
+		Public:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})),
+		Access:  config.JwtTokenConfig{Issuer: "kuryr-admin-access", Expiration: 1800},
+		Refresh: config.JwtTokenConfig{Issuer: "kuryr-admin-refresh", Expiration: 3600},
+	}
+	cfg.Session.Expiration = 3600
+	cfg.Grpc.Server.Name = "kuryr"
+	cfg.Grpc.Client.LoadBalance.Timeout = 3000
+	cfg.Dev.FakeBackend = true
+	return cfg
+}
+
+// migrate 创建表结构并写入管理员账号
+func (h *harness) migrate(t *testing.T) {
+	t.Helper()
+
+	require.NoError(t, h.db.AutoMigrate(&dao.SysUser{}, &dao.AuditLog{}, &dao.BizConfigVersion{},
+		&dao.BizConfigPreset{}, &dao.BizConfigPresetBinding{}, &dao.QuotaAlertRule{}, &dao.QuotaAlertRecord{},
+	))
+
+	passwd, err := bcrypt.GenerateFromPassword([]byte(adminPasswd), bcrypt.MinCost)
+	require.NoError(t, err)
+	now := time.Now().UnixMilli()
+	require.NoError(t, h.db.Create(&dao.SysUser{
+		Email:     adminEmail,
+		Password:  string(passwd),
+		RealName:  "admin",
+		UserType:  string(domain.UserTypeAdmin),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}).Error)
+}
+
+// result 接口统一返回，Data 解析为 T
+type result[T any] struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	Data T      `json:"data"`
+}
+
+// call 发送请求，返回 http 状态码与解析后的响应体，响应体为空时返回零值
+func call[T any](t *testing.T, h *harness, method string, path string, token string, body any) (int, result[T]) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, h.baseURL+path, reader)
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set(pkggin.HeaderNameAccessToken, token)
+	}
+
+	resp, err := h.client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var res result[T]
+	if len(raw) > 0 {
+		require.NoError(t, json.Unmarshal(raw, &res), string(raw))
+	}
+	return resp.StatusCode, res
+}
+
+type tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// login 使用邮箱密码登录
+func (h *harness) login(t *testing.T, email string, passwd string) tokens {
+	t.Helper()
+
+	code, res := call[tokens](t, h, http.MethodPost, "/api/v1/user/login", "", map[string]string{
+		"account":      email,
+		"credential":   passwd,
+		"account_type": "email",
+		"verify_type":  "passwd",
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	require.NotEmpty(t, res.Data.AccessToken)
+	require.NotEmpty(t, res.Data.RefreshToken)
+	return res.Data
+}
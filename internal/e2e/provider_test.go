@@ -0,0 +1,37 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_SaveAndList(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+
+	for _, provider := range []map[string]any{
+		{"provider_name": "sms provider", "channel": 1, "endpoint": "http://localhost:8090/v1/sms/send", "weight": 1, "qps_limit": 100},
+		{"provider_name": "email provider", "channel": 2, "endpoint": "http://localhost:8090/v1/email/send", "weight": 1, "qps_limit": 100},
+	} {
+		code, res := call[any](t, h, http.MethodPost, "/api/v1/provider/save", token, provider)
+		require.Equal(t, http.StatusOK, code, res.Msg)
+	}
+
+	code, listed := call[struct {
+		Records []domain.Provider `json:"records"`
+	}](t, h, http.MethodGet, "/api/v1/provider/list", token, nil)
+	require.Equal(t, http.StatusOK, code, listed.Msg)
+	assert.Len(t, listed.Data.Records, 2)
+
+	code, found := call[[]domain.Provider](t, h, http.MethodGet, "/api/v1/provider/find_by_channel?channel=1", token, nil)
+	require.Equal(t, http.StatusOK, code, found.Msg)
+	require.Len(t, found.Data, 1)
+	assert.Equal(t, "sms provider", found.Data[0].ProviderName)
+	assert.Equal(t, "http://localhost:8090/v1/sms/send", found.Data[0].Endpoint)
+}
@@ -0,0 +1,202 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+// stubQuotaAlertNotifier 记录发送的告警，fail 为 true 时发送失败
+type stubQuotaAlertNotifier struct {
+	mu     sync.Mutex
+	fail   bool
+	alerts []domain.QuotaAlert
+}
+
+func (n *stubQuotaAlertNotifier) Notify(_ context.Context, alert domain.QuotaAlert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.fail {
+		return errors.New("smtp unavailable")
+	}
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func (n *stubQuotaAlertNotifier) setFail(fail bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.fail = fail
+}
+
+func TestQuotaAlert_Rule(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	// 阈值按升序去重保存
+	code, saved := call[domain.QuotaAlertRule](t, h, http.MethodPost, "/api/v1/quota/alert_rule/save", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelSms, "thresholds": []int32{100, 80, 80}, "enabled": true,
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+	assert.Equal(t, []int32{80, 100}, saved.Data.Thresholds)
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/quota/alert_rule/save", token, map[string]any{
+		"biz_id": biz.Id, "channel": "push", "thresholds": []int32{0, 120},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, res.Msg, "channel")
+	assert.Contains(t, res.Msg, "thresholds[0]")
+	assert.Contains(t, res.Msg, "thresholds[1]")
+
+	// 覆盖已有阈值
+	code, saved = call[domain.QuotaAlertRule](t, h, http.MethodPost, "/api/v1/quota/alert_rule/save", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelSms, "thresholds": []int32{90}, "enabled": false,
+	})
+	require.Equal(t, http.StatusOK, code, saved.Msg)
+
+	listPath := fmt.Sprintf("/api/v1/quota/alert_rule/list?biz_id=%d", biz.Id)
+	code, rules := call[[]domain.QuotaAlertRule](t, h, http.MethodGet, listPath, token, nil)
+	require.Equal(t, http.StatusOK, code, rules.Msg)
+	require.Len(t, rules.Data, 1)
+	assert.Equal(t, []int32{90}, rules.Data[0].Thresholds)
+	assert.False(t, rules.Data[0].Enabled)
+
+	updates := h.searchAudit(t, token, "action=update&target_type=quota_alert_rule")
+	require.Len(t, updates, 1)
+	assert.Equal(t, biz.Id, updates[0].TargetId)
+
+	// 操作员只能管理自己的业务方
+	other := h.saveBiz(t, token, "payment")
+	operatorToken := h.loginOperator(t, other)
+	code, _ = call[any](t, h, http.MethodGet, listPath, operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/quota/alert_rule/delete", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelSms,
+	})
+	require.Equal(t, http.StatusOK, code)
+	code, _ = call[any](t, h, http.MethodPost, "/api/v1/quota/alert_rule/delete", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelSms,
+	})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, rules = call[[]domain.QuotaAlertRule](t, h, http.MethodGet, listPath, token, nil)
+	require.Equal(t, http.StatusOK, code, rules.Msg)
+	assert.Empty(t, rules.Data)
+}
+
+func TestQuotaAlert_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	source := &stubQuotaUsageSource{usage: map[uint64]domain.QuotaConsumption{}}
+	notifier := &stubQuotaAlertNotifier{}
+	var svc service.QuotaAlertService
+	h := newHarness(t,
+		withQuotaUsage(source),
+		fx.Decorate(func(service.QuotaAlertNotifier) service.QuotaAlertNotifier { return notifier }),
+		fx.Populate(&svc),
+	)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+	h.saveQuota(t, token, biz.Id, domain.Quota{Sms: 100, Email: 1000}, domain.Quota{Sms: 3000, Email: 20000})
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/quota/alert_rule/save", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelSms, "thresholds": []int32{80, 100}, "enabled": true,
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	evaluate := func() []domain.QuotaAlert {
+		t.Helper()
+
+		alerts, err := svc.Evaluate(context.Background())
+		require.NoError(t, err)
+		return alerts
+	}
+
+	// 未达到阈值
+	source.set(biz.Id, domain.QuotaConsumption{Daily: domain.Quota{Sms: 50}, Monthly: domain.Quota{Sms: 50}})
+	assert.Empty(t, evaluate())
+
+	// 达到日配额 80%，邮件用量很高但没有配置邮件阈值
+	source.set(biz.Id, domain.QuotaConsumption{Daily: domain.Quota{Sms: 85, Email: 1000}, Monthly: domain.Quota{Sms: 85, Email: 1000}})
+	alerts := evaluate()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, domain.QuotaPeriodDaily, alerts[0].Period)
+	assert.Equal(t, domain.QuotaChannelSms, alerts[0].Channel)
+	assert.Equal(t, int32(80), alerts[0].Threshold)
+	assert.Equal(t, int32(85), alerts[0].Used)
+	assert.Equal(t, "order biz", alerts[0].BizName)
+	assert.Equal(t, []string{"order@kuryr.test", adminEmail}, alerts[0].Receivers)
+
+	// 同一周期内同一阈值只告警一次
+	assert.Empty(t, evaluate())
+
+	// 发送失败时不记录告警，下次检查时重新发送
+	source.set(biz.Id, domain.QuotaConsumption{Daily: domain.Quota{Sms: 100}, Monthly: domain.Quota{Sms: 100}})
+	notifier.setFail(true)
+	_, err := svc.Evaluate(context.Background())
+	require.Error(t, err)
+
+	notifier.setFail(false)
+	alerts = evaluate()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, int32(100), alerts[0].Threshold)
+	assert.Empty(t, evaluate())
+
+	// 同时达到多个阈值时只发送最高阈值的告警
+	source.set(biz.Id, domain.QuotaConsumption{Daily: domain.Quota{Sms: 100}, Monthly: domain.Quota{Sms: 3000}})
+	alerts = evaluate()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, domain.QuotaPeriodMonthly, alerts[0].Period)
+	assert.Equal(t, int32(100), alerts[0].Threshold)
+
+	notifier.mu.Lock()
+	assert.Len(t, notifier.alerts, 3)
+	notifier.mu.Unlock()
+
+	// 停用后不再检查
+	code, res = call[any](t, h, http.MethodPost, "/api/v1/quota/alert_rule/save", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelEmail, "thresholds": []int32{50}, "enabled": false,
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	assert.Empty(t, evaluate())
+}
+
+func TestQuotaAlert_EvaluateWithoutUsageSource(t *testing.T) {
+	t.Parallel()
+
+	notifier := &stubQuotaAlertNotifier{}
+	var svc service.QuotaAlertService
+	var usageSvc service.QuotaUsageService
+	h := newHarness(t,
+		fx.Decorate(func(service.QuotaAlertNotifier) service.QuotaAlertNotifier { return notifier }),
+		fx.Populate(&svc, &usageSvc),
+	)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+	h.saveQuota(t, token, biz.Id, domain.Quota{Sms: 100, Email: 1000}, domain.Quota{Sms: 3000, Email: 20000})
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/quota/alert_rule/save", token, map[string]any{
+		"biz_id": biz.Id, "channel": domain.QuotaChannelSms, "thresholds": []int32{80}, "enabled": true,
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	// 默认没有接入用量来源，不检查任何业务方
+	assert.False(t, usageSvc.Available())
+	alerts, err := svc.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alerts)
+	assert.Empty(t, notifier.alerts)
+}
@@ -0,0 +1,127 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+// stubQuotaUsageSource 按业务方返回固定用量
+type stubQuotaUsageSource struct {
+	mu    sync.Mutex
+	usage map[uint64]domain.QuotaConsumption
+}
+
+func (s *stubQuotaUsageSource) Consumption(_ context.Context, bizId uint64, _ time.Time) (domain.QuotaConsumption, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[bizId], nil
+}
+
+func (s *stubQuotaUsageSource) set(bizId uint64, usage domain.QuotaConsumption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage[bizId] = usage
+}
+
+// withQuotaUsage 使用固定用量代替默认的用量来源
+func withQuotaUsage(source *stubQuotaUsageSource) fx.Option {
+	return fx.Decorate(func(service.QuotaUsageSource) service.QuotaUsageSource { return source })
+}
+
+// saveQuota 保存业务方配额配置
+func (h *harness) saveQuota(t *testing.T, token string, bizId uint64, daily domain.Quota, monthly domain.Quota) {
+	t.Helper()
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/biz_config/save", token, map[string]any{
+		"biz_id":       bizId,
+		"quota_config": map[string]any{"daily": daily, "monthly": monthly},
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+}
+
+func TestQuota_UsageUnavailable(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+	usagePath := fmt.Sprintf("/api/v1/quota/usage?biz_id=%d", biz.Id)
+
+	// 没有配额配置
+	code, res := call[domain.QuotaUsage](t, h, http.MethodGet, usagePath, token, nil)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	assert.Empty(t, res.Data.Items)
+
+	// kuryr 没有提供用量数据时只返回配置的配额
+	h.saveQuota(t, token, biz.Id, domain.Quota{Sms: 100, Email: 1000}, domain.Quota{Sms: 3000, Email: 20000})
+	code, res = call[domain.QuotaUsage](t, h, http.MethodGet, usagePath, token, nil)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	assert.False(t, res.Data.Available)
+	require.Len(t, res.Data.Items, 4)
+	for _, item := range res.Data.Items {
+		assert.Nil(t, item.Used)
+		assert.Nil(t, item.Remaining)
+		assert.False(t, item.Exhausted)
+	}
+	assert.Equal(t, int32(100), res.Data.Items[0].Limit)
+}
+
+func TestQuota_Usage(t *testing.T) {
+	t.Parallel()
+
+	source := &stubQuotaUsageSource{usage: map[uint64]domain.QuotaConsumption{}}
+	h := newHarness(t, withQuotaUsage(source))
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+	source.set(biz.Id, domain.QuotaConsumption{
+		Daily:   domain.Quota{Sms: 100, Email: 10},
+		Monthly: domain.Quota{Sms: 1000, Email: 10},
+	})
+
+	h.saveQuota(t, token, biz.Id, domain.Quota{Sms: 100, Email: 1000}, domain.Quota{Sms: 3000, Email: 20000})
+
+	usagePath := fmt.Sprintf("/api/v1/quota/usage?biz_id=%d", biz.Id)
+	code, res := call[domain.QuotaUsage](t, h, http.MethodGet, usagePath, token, nil)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	assert.True(t, res.Data.Available)
+	assert.Equal(t, biz.Id, res.Data.BizId)
+
+	items := make(map[string]domain.QuotaUsageItem, len(res.Data.Items))
+	for _, item := range res.Data.Items {
+		items[item.Period+"."+item.Channel] = item
+	}
+	require.Len(t, items, 4)
+
+	dailySms := items["daily.sms"]
+	require.NotNil(t, dailySms.Remaining)
+	assert.Equal(t, int32(100), *dailySms.Used)
+	assert.Equal(t, int32(0), *dailySms.Remaining)
+	assert.True(t, dailySms.Exhausted)
+	assert.Greater(t, dailySms.PeriodEnd, res.Data.At)
+
+	monthlySms := items["monthly.sms"]
+	require.NotNil(t, monthlySms.Remaining)
+	assert.Equal(t, int32(2000), *monthlySms.Remaining)
+	assert.False(t, monthlySms.Exhausted)
+
+	// 操作员只能查询自己的业务方
+	other := h.saveBiz(t, token, "payment")
+	operatorToken := h.loginOperator(t, other)
+	code, _ = call[any](t, h, http.MethodGet, usagePath, operatorToken, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, res = call[domain.QuotaUsage](t, h, http.MethodGet,
+		fmt.Sprintf("/api/v1/quota/usage?biz_id=%d", other.Id), operatorToken, nil)
+	require.Equal(t, http.StatusOK, code, res.Msg)
+	assert.Empty(t, res.Data.Items)
+}
@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_Save(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	token := h.login(t, adminEmail, adminPasswd).AccessToken
+	biz := h.saveBiz(t, token, "order")
+
+	code, res := call[any](t, h, http.MethodPost, "/api/v1/template/save", token, map[string]any{
+		"biz_id":            biz.Id,
+		"biz_type":          biz.BizType,
+		"tpl_name":          "verify code",
+		"tpl_desc":          "login verify code",
+		"channel":           1,
+		"notification_type": 1,
+	})
+	require.Equal(t, http.StatusOK, code, res.Msg)
+
+	// admin API 暂未提供模板查询接口，直接通过 grpc 客户端检查 fake 后端内的数据
+	client, err := h.templates.Get(h.cfg.Grpc.Server.Name)
+	require.NoError(t, err)
+	listed, err := client.ListTemplateByBizId(context.Background(), &templatev1.ListTemplateByBizIdRequest{
+		BizId: biz.Id,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, listed.GetTemplates(), 1)
+	assert.Equal(t, "verify code", listed.GetTemplates()[0].GetTplName())
+	assert.Equal(t, "login verify code", listed.GetTemplates()[0].GetTplDesc())
+}
@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_LoginRefreshLogout(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+
+	// 错误的密码
+	code, _ := call[tokens](t, h, http.MethodPost, "/api/v1/user/login", "", map[string]string{
+		"account":      adminEmail,
+		"credential":   "wrong-passwd",
+		"account_type": "email",
+		"verify_type":  "passwd",
+	})
+	assert.NotEqual(t, http.StatusOK, code)
+
+	tk := h.login(t, adminEmail, adminPasswd)
+
+	// 未携带 token 访问受保护的接口
+	code, _ = call[any](t, h, http.MethodGet, "/api/v1/provider/list", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, res := call[tokens](t, h, http.MethodPost, "/api/v1/user/refresh_token", "", map[string]string{
+		"refresh_token": tk.RefreshToken,
+	})
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, res.Data.AccessToken)
+
+	code, _ = call[any](t, h, http.MethodGet, "/api/v1/provider/list", res.Data.AccessToken, nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	// 登出后 session 失效，不能再刷新 token
+	code, _ = call[any](t, h, http.MethodGet, "/api/v1/user/logout", res.Data.AccessToken, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = call[tokens](t, h, http.MethodPost, "/api/v1/user/refresh_token", "", map[string]string{
+		"refresh_token": res.Data.RefreshToken,
+	})
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+
+	code, _ := call[any](t, h, http.MethodGet, "/healthz", "", nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = call[any](t, h, http.MethodGet, "/readyz", "", nil)
+	assert.Equal(t, http.StatusOK, code)
+}
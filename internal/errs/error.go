@@ -15,4 +15,10 @@ var (
 
 	ErrDuplicateKey   = errors.New("[kuryr-admin] duplicate key violation")
 	ErrBizKeyConflict = errors.New("[kuryr-admin] biz key already exists")
+
+	ErrInvalidBizConfig   = errors.New("[kuryr-admin] invalid biz config")
+	ErrPresetNameConflict = errors.New("[kuryr-admin] biz config preset name already exists")
+
+	ErrQuotaUsageUnavailable = errors.New("[kuryr-admin] quota usage unavailable")
+	ErrInvalidQuotaAlertRule = errors.New("[kuryr-admin] invalid quota alert rule")
 )
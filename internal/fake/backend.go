@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	pkgregistry "github.com/JrMarcco/kuryr-admin/internal/pkg/registry"
+	businessv1 "github.com/JrMarcco/kuryr-api/api/go/business/v1"
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	notificationv1 "github.com/JrMarcco/kuryr-api/api/go/notification/v1"
+	providerv1 "github.com/JrMarcco/kuryr-api/api/go/provider/v1"
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// Addr fake 服务在注册中心内的实例地址，实际连接由 Dialer 建立，与地址无关
+	Addr = "bufconn"
+
+	bufSize = 1 << 20
+)
+
+// Backend 进程内的 fake kuryr 后端。
+// 通过 bufconn 提供 Business / BizConfig / Provider / Template 服务的内存实现，
+// 并提供只包含该后端实例的静态注册中心，用于不依赖外部服务的本地开发与集成测试。
+// Notification 服务只注册未实现的桩，调用时返回 Unimplemented。
+type Backend struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+	registry *pkgregistry.MemoryRegistry
+}
+
+// Registry 返回只包含该后端实例的注册中心
+func (b *Backend) Registry() registry.Registry {
+	return b.registry
+}
+
+// Dialer 用于 grpc.WithContextDialer，所有连接都建立到 bufconn 上
+func (b *Backend) Dialer(ctx context.Context, _ string) (net.Conn, error) {
+	return b.listener.DialContext(ctx)
+}
+
+// Start 在后台启动 grpc 服务
+func (b *Backend) Start() {
+	go func() {
+		if err := b.server.Serve(b.listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			panic(err)
+		}
+	}()
+}
+
+// Stop 停止 grpc 服务，ctx 结束时强制关闭仍在处理中的请求
+func (b *Backend) Stop(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		b.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		b.server.Stop()
+	}
+	_ = b.registry.Close()
+}
+
+// NewBackend 创建 fake 后端，serviceName 为 kuryr 在注册中心内的服务名。
+func NewBackend(serviceName string) *Backend {
+	server := grpc.NewServer()
+	businessv1.RegisterBusinessServiceServer(server, NewBusinessServer())
+	configv1.RegisterBizConfigServiceServer(server, NewBizConfigServer())
+	providerv1.RegisterProviderServiceServer(server, NewProviderServer())
+	templatev1.RegisterTemplateServiceServer(server, NewTemplateServer())
+	notificationv1.RegisterNotificationServiceServer(server, notificationv1.UnimplementedNotificationServiceServer{})
+
+	return &Backend{
+		listener: bufconn.Listen(bufSize),
+		server:   server,
+		registry: pkgregistry.NewMemoryRegistry(registry.ServiceInstance{
+			Name:        serviceName,
+			Addr:        Addr,
+			ReadWeight:  1,
+			WriteWeight: 1,
+		}),
+	}
+}
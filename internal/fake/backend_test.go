@@ -0,0 +1,184 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	businessv1 "github.com/JrMarcco/kuryr-api/api/go/business/v1"
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	backend := NewBackend("kuryr")
+	backend.Start()
+	t.Cleanup(func() { backend.Stop(context.Background()) })
+
+	cc, err := grpc.NewClient(
+		"passthrough:///"+Addr,
+		grpc.WithContextDialer(backend.Dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
+
+func TestBackend_Registry(t *testing.T) {
+	t.Parallel()
+
+	backend := NewBackend("kuryr")
+	instances, err := backend.Registry().ListServices(context.Background(), "kuryr")
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, Addr, instances[0].Addr)
+}
+
+func TestBusinessServer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := businessv1.NewBusinessServiceClient(newTestConn(t))
+
+	saved, err := client.Save(ctx, &businessv1.SaveRequest{BusinessInfo: &businessv1.BusinessInfo{
+		BizKey:  "order",
+		BizName: "订单服务",
+		Contact: "jrmarcco",
+	}})
+	require.NoError(t, err)
+	id := saved.GetBusinessInfo().GetId()
+	assert.NotZero(t, id)
+	assert.NotZero(t, saved.GetBusinessInfo().GetCreatedAt())
+
+	_, err = client.Save(ctx, &businessv1.SaveRequest{BusinessInfo: &businessv1.BusinessInfo{BizKey: "user", BizName: "用户服务"}})
+	require.NoError(t, err)
+
+	// 只更新 mask 指定的字段
+	_, err = client.Update(ctx, &businessv1.UpdateRequest{
+		BusinessInfo: &businessv1.BusinessInfo{Id: id, BizName: "订单中心", Contact: "ignored"},
+		FieldMask:    &fieldmaskpb.FieldMask{Paths: []string{businessv1.FieldBizName}},
+	})
+	require.NoError(t, err)
+
+	found, err := client.FindById(ctx, &businessv1.FindByIdRequest{BizId: id})
+	require.NoError(t, err)
+	assert.Equal(t, "订单中心", found.GetBusinessInfo().GetBizName())
+	assert.Equal(t, "jrmarcco", found.GetBusinessInfo().GetContact())
+
+	// 只返回 mask 指定的字段
+	found, err = client.FindById(ctx, &businessv1.FindByIdRequest{
+		BizId:     id,
+		FieldMask: &fieldmaskpb.FieldMask{Paths: []string{businessv1.FieldId, businessv1.FieldBizKey}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order", found.GetBusinessInfo().GetBizKey())
+	assert.Empty(t, found.GetBusinessInfo().GetBizName())
+
+	searched, err := client.Search(ctx, &businessv1.SearchRequest{BizName: "服务", Offset: 0, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), searched.GetTotal())
+	require.Len(t, searched.GetRecords(), 1)
+	assert.Equal(t, "user", searched.GetRecords()[0].GetBizKey())
+
+	searched, err = client.Search(ctx, &businessv1.SearchRequest{Offset: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), searched.GetTotal())
+	assert.Len(t, searched.GetRecords(), 1)
+
+	_, err = client.Delete(ctx, &businessv1.DeleteRequest{BizId: id})
+	require.NoError(t, err)
+	_, err = client.FindById(ctx, &businessv1.FindByIdRequest{BizId: id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestBizConfigServer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := configv1.NewBizConfigServiceClient(newTestConn(t))
+
+	_, err := client.Save(ctx, &configv1.SaveRequest{BizConfig: &configv1.BizConfig{BizId: 1, RateLimit: 100}})
+	require.NoError(t, err)
+
+	_, err = client.Save(ctx, &configv1.SaveRequest{BizConfig: &configv1.BizConfig{BizId: 1}})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// 未指定 id 时按 biz id 更新
+	_, err = client.Update(ctx, &configv1.UpdateRequest{BizConfig: &configv1.BizConfig{BizId: 1, RateLimit: 200}})
+	require.NoError(t, err)
+
+	found, err := client.FindByBizId(ctx, &configv1.FindByBizIdRequest{BizId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(200), found.GetBizConfig().GetRateLimit())
+
+	_, err = client.FindByBizId(ctx, &configv1.FindByBizIdRequest{BizId: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestTemplateServer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := templatev1.NewTemplateServiceClient(newTestConn(t))
+
+	saved, err := client.SaveTemplate(ctx, &templatev1.SaveTemplateRequest{Template: &templatev1.ChannelTemplate{BizId: 1, TplName: "验证码"}})
+	require.NoError(t, err)
+	tplId := saved.GetTemplate().GetId()
+
+	version, err := client.SaveTemplateVersion(ctx, &templatev1.SaveTemplateVersionRequest{Version: &templatev1.TemplateVersion{TplId: tplId}})
+	require.NoError(t, err)
+	versionId := version.GetVersion().GetId()
+	assert.Equal(t, AuditStatusPending, version.GetVersion().GetAuditStatus())
+
+	_, err = client.ActivateTemplateVersion(ctx, &templatev1.ActivateTemplateVersionRequest{TplId: tplId, VersionId: versionId})
+	require.NoError(t, err)
+	_, err = client.ActivateTemplateVersion(ctx, &templatev1.ActivateTemplateVersionRequest{TplId: tplId + 1, VersionId: versionId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.SaveTemplateProviders(ctx, &templatev1.SaveTemplateProvidersRequest{
+		TplId:        tplId,
+		TplVersionId: versionId,
+		RelatedProviders: []*templatev1.RelatedProvider{
+			{ProviderId: 1, ProviderName: "aliyun"},
+			{ProviderId: 2, ProviderName: "tencent"},
+		},
+	})
+	require.NoError(t, err)
+
+	listed, err := client.ListTemplateByBizId(ctx, &templatev1.ListTemplateByBizIdRequest{BizId: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), listed.GetTotal())
+	require.Len(t, listed.GetTemplates(), 1)
+	assert.Equal(t, versionId, listed.GetTemplates()[0].GetActivatedVersionId())
+
+	providers, err := client.ListTemplateProvider(ctx, &templatev1.ListTemplateProviderRequest{VersionId: versionId})
+	require.NoError(t, err)
+	assert.Len(t, providers.GetProviders(), 2)
+
+	// 删除模板时级联删除版本与供应商
+	_, err = client.DeleteTemplate(ctx, &templatev1.DeleteTemplateRequest{Id: tplId})
+	require.NoError(t, err)
+
+	versions, err := client.ListTemplateVersion(ctx, &templatev1.ListTemplateVersionRequest{TplId: tplId})
+	require.NoError(t, err)
+	assert.Empty(t, versions.GetVersions())
+	providers, err = client.ListTemplateProvider(ctx, &templatev1.ListTemplateProviderRequest{VersionId: versionId})
+	require.NoError(t, err)
+	assert.Empty(t, providers.GetProviders())
+}
@@ -0,0 +1,89 @@
+package fake
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ configv1.BizConfigServiceServer = (*BizConfigServer)(nil)
+
+// BizConfigServer 内存实现的 config.v1.BizConfigService，每个业务最多一份配置。
+type BizConfigServer struct {
+	configv1.UnimplementedBizConfigServiceServer
+
+	// saveMu 保证检查配置是否存在与保存配置的原子性
+	saveMu  sync.Mutex
+	configs *table[*configv1.BizConfig]
+}
+
+func (s *BizConfigServer) Save(_ context.Context, req *configv1.SaveRequest) (*configv1.SaveResponse, error) {
+	bizId := req.GetBizConfig().GetBizId()
+	if bizId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "biz id is required")
+	}
+
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	if _, ok := s.findByBizId(bizId); ok {
+		return nil, status.Errorf(codes.AlreadyExists, "biz config of business %d already exists", bizId)
+	}
+
+	now := time.Now().UnixMilli()
+	cfg := s.configs.insert(req.GetBizConfig(), func(row *configv1.BizConfig, id uint64) {
+		row.Id = id
+		row.CreatedAt = now
+		row.UpdatedAt = now
+	})
+	return &configv1.SaveResponse{BizConfig: cfg}, nil
+}
+
+func (s *BizConfigServer) Update(_ context.Context, req *configv1.UpdateRequest) (*configv1.UpdateResponse, error) {
+	// 优先按 id 更新，未指定 id 时按 biz id 更新
+	id := req.GetBizConfig().GetId()
+	if id == 0 {
+		existing, ok := s.findByBizId(req.GetBizConfig().GetBizId())
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "biz config of business %d not found", req.GetBizConfig().GetBizId())
+		}
+		id = existing.GetId()
+	}
+
+	cfg, ok := s.configs.update(id, func(row *configv1.BizConfig) {
+		bizId := row.GetBizId()
+		merge(row, req.GetBizConfig(), req.GetFieldMask())
+		row.Id = id
+		row.BizId = bizId
+		row.UpdatedAt = time.Now().UnixMilli()
+	})
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "biz config %d not found", id)
+	}
+	return &configv1.UpdateResponse{BizConfig: cfg}, nil
+}
+
+func (s *BizConfigServer) FindByBizId(_ context.Context, req *configv1.FindByBizIdRequest) (*configv1.FindByBizIdResponse, error) {
+	cfg, ok := s.findByBizId(req.GetBizId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "biz config of business %d not found", req.GetBizId())
+	}
+	return &configv1.FindByBizIdResponse{BizConfig: project(cfg, req.GetFieldMask())}, nil
+}
+
+func (s *BizConfigServer) findByBizId(bizId uint64) (*configv1.BizConfig, bool) {
+	configs := s.configs.find(func(row *configv1.BizConfig) bool {
+		return row.GetBizId() == bizId
+	})
+	if len(configs) == 0 {
+		return nil, false
+	}
+	return configs[0], true
+}
+
+func NewBizConfigServer() *BizConfigServer {
+	return &BizConfigServer{configs: newTable[*configv1.BizConfig]()}
+}
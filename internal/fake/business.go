@@ -0,0 +1,78 @@
+package fake
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	businessv1 "github.com/JrMarcco/kuryr-api/api/go/business/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ businessv1.BusinessServiceServer = (*BusinessServer)(nil)
+
+// BusinessServer 内存实现的 business.v1.BusinessService。
+type BusinessServer struct {
+	businessv1.UnimplementedBusinessServiceServer
+
+	infos *table[*businessv1.BusinessInfo]
+}
+
+func (s *BusinessServer) Save(_ context.Context, req *businessv1.SaveRequest) (*businessv1.SaveResponse, error) {
+	if req.GetBusinessInfo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "business info is required")
+	}
+
+	now := time.Now().UnixMilli()
+	info := s.infos.insert(req.GetBusinessInfo(), func(row *businessv1.BusinessInfo, id uint64) {
+		row.Id = id
+		row.CreatedAt = now
+		row.UpdatedAt = now
+	})
+	return &businessv1.SaveResponse{BusinessInfo: info}, nil
+}
+
+func (s *BusinessServer) Delete(_ context.Context, req *businessv1.DeleteRequest) (*businessv1.DeleteResponse, error) {
+	if !s.infos.delete(req.GetBizId()) {
+		return nil, status.Errorf(codes.NotFound, "business %d not found", req.GetBizId())
+	}
+	return &businessv1.DeleteResponse{}, nil
+}
+
+func (s *BusinessServer) Update(_ context.Context, req *businessv1.UpdateRequest) (*businessv1.UpdateResponse, error) {
+	id := req.GetBusinessInfo().GetId()
+	info, ok := s.infos.update(id, func(row *businessv1.BusinessInfo) {
+		merge(row, req.GetBusinessInfo(), req.GetFieldMask())
+		row.Id = id
+		row.UpdatedAt = time.Now().UnixMilli()
+	})
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "business %d not found", id)
+	}
+	return &businessv1.UpdateResponse{BusinessInfo: info}, nil
+}
+
+func (s *BusinessServer) FindById(_ context.Context, req *businessv1.FindByIdRequest) (*businessv1.FindByIdResponse, error) {
+	info, ok := s.infos.get(req.GetBizId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "business %d not found", req.GetBizId())
+	}
+	return &businessv1.FindByIdResponse{BusinessInfo: project(info, req.GetFieldMask())}, nil
+}
+
+func (s *BusinessServer) Search(_ context.Context, req *businessv1.SearchRequest) (*businessv1.SearchResponse, error) {
+	infos := s.infos.find(func(row *businessv1.BusinessInfo) bool {
+		return strings.Contains(row.GetBizName(), req.GetBizName())
+	})
+
+	records := paginate(infos, req.GetOffset(), req.GetLimit())
+	for i, record := range records {
+		records[i] = project(record, req.GetFieldMask())
+	}
+	return &businessv1.SearchResponse{Records: records, Total: int64(len(infos))}, nil
+}
+
+func NewBusinessServer() *BusinessServer {
+	return &BusinessServer{infos: newTable[*businessv1.BusinessInfo]()}
+}
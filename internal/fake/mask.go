@@ -0,0 +1,70 @@
+package fake
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// merge 将 src 中 mask 指定的顶层字段复制到 dst，mask 为空时复制所有字段。
+// 与 kuryr 一致，mask 指定但 src 未设置的字段会被清空。
+func merge(dst proto.Message, src proto.Message, mask *fieldmaskpb.FieldMask) {
+	d := dst.ProtoReflect()
+	s := proto.Clone(src).ProtoReflect()
+
+	fields := d.Descriptor().Fields()
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		for i := range fields.Len() {
+			paths = append(paths, string(fields.Get(i).Name()))
+		}
+	}
+
+	for _, path := range paths {
+		fd := fields.ByName(protoreflect.Name(path))
+		if fd == nil {
+			continue
+		}
+		if s.Has(fd) {
+			d.Set(fd, s.Get(fd))
+			continue
+		}
+		d.Clear(fd)
+	}
+}
+
+// project 返回只包含 mask 指定字段的副本，mask 为空时返回所有字段。
+func project[T proto.Message](msg T, mask *fieldmaskpb.FieldMask) T {
+	res := proto.Clone(msg).(T)
+	if len(mask.GetPaths()) == 0 {
+		return res
+	}
+
+	keep := make(map[protoreflect.Name]struct{}, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		keep[protoreflect.Name(path)] = struct{}{}
+	}
+
+	m := res.ProtoReflect()
+	var clear []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if _, ok := keep[fd.Name()]; !ok {
+			clear = append(clear, fd)
+		}
+		return true
+	})
+	for _, fd := range clear {
+		m.Clear(fd)
+	}
+	return res
+}
+
+// paginate 返回分页后的切片，limit 不大于 0 时返回 offset 之后的所有记录
+func paginate[T any](records []T, offset int32, limit int32) []T {
+	start := min(max(int(offset), 0), len(records))
+	end := len(records)
+	if limit > 0 {
+		end = min(start+int(limit), len(records))
+	}
+	return records[start:end]
+}
@@ -0,0 +1,78 @@
+package fake
+
+import (
+	"context"
+
+	providerv1 "github.com/JrMarcco/kuryr-api/api/go/provider/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ providerv1.ProviderServiceServer = (*ProviderServer)(nil)
+
+// ProviderServer 内存实现的 provider.v1.ProviderService。
+type ProviderServer struct {
+	providerv1.UnimplementedProviderServiceServer
+
+	providers *table[*providerv1.Provider]
+}
+
+func (s *ProviderServer) Save(_ context.Context, req *providerv1.SaveRequest) (*providerv1.SaveResponse, error) {
+	if req.GetProvider() == nil {
+		return nil, status.Error(codes.InvalidArgument, "provider is required")
+	}
+
+	provider := s.providers.insert(req.GetProvider(), func(row *providerv1.Provider, id uint64) {
+		row.Id = id
+	})
+	return &providerv1.SaveResponse{Provider: provider}, nil
+}
+
+func (s *ProviderServer) Delete(_ context.Context, req *providerv1.DeleteRequest) (*providerv1.DeleteResponse, error) {
+	if !s.providers.delete(req.GetId()) {
+		return nil, status.Errorf(codes.NotFound, "provider %d not found", req.GetId())
+	}
+	return &providerv1.DeleteResponse{}, nil
+}
+
+func (s *ProviderServer) Update(_ context.Context, req *providerv1.UpdateRequest) (*providerv1.UpdateResponse, error) {
+	id := req.GetProvider().GetId()
+	provider, ok := s.providers.update(id, func(row *providerv1.Provider) {
+		merge(row, req.GetProvider(), req.GetFieldMask())
+		row.Id = id
+	})
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "provider %d not found", id)
+	}
+	return &providerv1.UpdateResponse{Provider: provider}, nil
+}
+
+func (s *ProviderServer) List(_ context.Context, req *providerv1.ListRequest) (*providerv1.ListResponse, error) {
+	providers := s.providers.find(func(*providerv1.Provider) bool { return true })
+	for i, provider := range providers {
+		providers[i] = project(provider, req.GetFieldMask())
+	}
+	return &providerv1.ListResponse{Providers: providers}, nil
+}
+
+func (s *ProviderServer) FindById(_ context.Context, req *providerv1.FindByIdRequest) (*providerv1.FindByIdResponse, error) {
+	provider, ok := s.providers.get(req.GetId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "provider %d not found", req.GetId())
+	}
+	return &providerv1.FindByIdResponse{Provider: project(provider, req.GetFieldMask())}, nil
+}
+
+func (s *ProviderServer) FindByChannel(_ context.Context, req *providerv1.FindByChannelRequest) (*providerv1.FindByChannelResponse, error) {
+	providers := s.providers.find(func(row *providerv1.Provider) bool {
+		return row.GetChannel() == req.GetChannel()
+	})
+	for i, provider := range providers {
+		providers[i] = project(provider, req.GetFieldMask())
+	}
+	return &providerv1.FindByChannelResponse{Providers: providers}, nil
+}
+
+func NewProviderServer() *ProviderServer {
+	return &ProviderServer{providers: newTable[*providerv1.Provider]()}
+}
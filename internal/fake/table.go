@@ -0,0 +1,80 @@
+package fake
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// table 内存数据表，按 id 保存记录，读写时复制记录避免调用方修改内部数据。
+type table[T proto.Message] struct {
+	mu   sync.RWMutex
+	seq  uint64
+	rows map[uint64]T
+}
+
+// insert 为记录分配 id 并保存，setId 用于回写 id
+func (t *table[T]) insert(row T, setId func(row T, id uint64)) T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	row = proto.Clone(row).(T)
+	setId(row, t.seq)
+	t.rows[t.seq] = row
+	return proto.Clone(row).(T)
+}
+
+// update 修改指定记录，记录不存在时返回 false
+func (t *table[T]) update(id uint64, fn func(row T)) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	row, ok := t.rows[id]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	fn(row)
+	return proto.Clone(row).(T), true
+}
+
+func (t *table[T]) delete(id uint64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.rows[id]
+	delete(t.rows, id)
+	return ok
+}
+
+func (t *table[T]) get(id uint64) (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	row, ok := t.rows[id]
+	if !ok {
+		return row, false
+	}
+	return proto.Clone(row).(T), true
+}
+
+// find 按 id 升序返回所有满足条件的记录
+func (t *table[T]) find(filter func(row T) bool) []T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make([]T, 0, len(t.rows))
+	for _, id := range slices.Sorted(maps.Keys(t.rows)) {
+		if row := t.rows[id]; filter(row) {
+			res = append(res, proto.Clone(row).(T))
+		}
+	}
+	return res
+}
+
+func newTable[T proto.Message]() *table[T] {
+	return &table[T]{rows: make(map[uint64]T)}
+}
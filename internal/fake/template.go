@@ -0,0 +1,188 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuditStatusPending 新建的模板版本与模板供应商的审核状态，fake 服务不做审核。
+const AuditStatusPending = "PENDING"
+
+var _ templatev1.TemplateServiceServer = (*TemplateServer)(nil)
+
+// TemplateServer 内存实现的 template.v1.TemplateService。
+type TemplateServer struct {
+	templatev1.UnimplementedTemplateServiceServer
+
+	templates *table[*templatev1.ChannelTemplate]
+	versions  *table[*templatev1.TemplateVersion]
+	providers *table[*templatev1.TemplateProvider]
+}
+
+func (s *TemplateServer) SaveTemplate(_ context.Context, req *templatev1.SaveTemplateRequest) (*templatev1.SaveTemplateResponse, error) {
+	tpl := req.GetTemplate()
+	if tpl == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+
+	now := time.Now().UnixMilli()
+	if tpl.GetId() == 0 {
+		tpl = s.templates.insert(tpl, func(row *templatev1.ChannelTemplate, id uint64) {
+			row.Id = id
+			row.ActivatedVersionId = 0
+			row.CreatedAt = now
+			row.UpdatedAt = now
+		})
+		return &templatev1.SaveTemplateResponse{Template: tpl}, nil
+	}
+
+	// 指定 id 时更新模板基本信息，激活版本只能通过 ActivateTemplateVersion 修改
+	updated, ok := s.templates.update(tpl.GetId(), func(row *templatev1.ChannelTemplate) {
+		row.BizType = tpl.GetBizType()
+		row.TplName = tpl.GetTplName()
+		row.TplDesc = tpl.GetTplDesc()
+		row.Channel = tpl.GetChannel()
+		row.NotificationType = tpl.GetNotificationType()
+		row.UpdatedAt = now
+	})
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "template %d not found", tpl.GetId())
+	}
+	return &templatev1.SaveTemplateResponse{Template: updated}, nil
+}
+
+func (s *TemplateServer) DeleteTemplate(_ context.Context, req *templatev1.DeleteTemplateRequest) (*templatev1.DeleteTemplateResponse, error) {
+	if !s.templates.delete(req.GetId()) {
+		return nil, status.Errorf(codes.NotFound, "template %d not found", req.GetId())
+	}
+
+	// 级联删除模板版本与模板供应商
+	for _, version := range s.versions.find(func(row *templatev1.TemplateVersion) bool { return row.GetTplId() == req.GetId() }) {
+		s.versions.delete(version.GetId())
+	}
+	for _, provider := range s.providers.find(func(row *templatev1.TemplateProvider) bool { return row.GetTplId() == req.GetId() }) {
+		s.providers.delete(provider.GetId())
+	}
+	return &templatev1.DeleteTemplateResponse{}, nil
+}
+
+func (s *TemplateServer) ListTemplateByBizId(_ context.Context, req *templatev1.ListTemplateByBizIdRequest) (*templatev1.ListTemplateByBizIdResponse, error) {
+	templates := s.templates.find(func(row *templatev1.ChannelTemplate) bool {
+		return row.GetBizId() == req.GetBizId()
+	})
+
+	records := paginate(templates, req.GetOffset(), req.GetLimit())
+	for i, record := range records {
+		records[i] = project(record, req.GetFieldMask())
+	}
+	return &templatev1.ListTemplateByBizIdResponse{Templates: records, Total: int64(len(templates))}, nil
+}
+
+func (s *TemplateServer) SaveTemplateVersion(_ context.Context, req *templatev1.SaveTemplateVersionRequest) (*templatev1.SaveTemplateVersionResponse, error) {
+	version := req.GetVersion()
+	if _, ok := s.templates.get(version.GetTplId()); !ok {
+		return nil, status.Errorf(codes.NotFound, "template %d not found", version.GetTplId())
+	}
+
+	now := time.Now().UnixMilli()
+	version = s.versions.insert(version, func(row *templatev1.TemplateVersion, id uint64) {
+		row.Id = id
+		row.AuditStatus = AuditStatusPending
+		row.CreatedAt = now
+		row.UpdatedAt = now
+	})
+	return &templatev1.SaveTemplateVersionResponse{Version: version}, nil
+}
+
+func (s *TemplateServer) DeleteTemplateVersion(_ context.Context, req *templatev1.DeleteTemplateVersionRequest) (*templatev1.DeleteTemplateVersionResponse, error) {
+	if !s.versions.delete(req.GetId()) {
+		return nil, status.Errorf(codes.NotFound, "template version %d not found", req.GetId())
+	}
+	for _, provider := range s.providers.find(func(row *templatev1.TemplateProvider) bool { return row.GetTplVersionId() == req.GetId() }) {
+		s.providers.delete(provider.GetId())
+	}
+	return &templatev1.DeleteTemplateVersionResponse{}, nil
+}
+
+func (s *TemplateServer) ActivateTemplateVersion(_ context.Context, req *templatev1.ActivateTemplateVersionRequest) (*templatev1.ActivateTemplateVersionResponse, error) {
+	version, ok := s.versions.get(req.GetVersionId())
+	if !ok || version.GetTplId() != req.GetTplId() {
+		return nil, status.Errorf(codes.NotFound, "template version %d of template %d not found", req.GetVersionId(), req.GetTplId())
+	}
+
+	_, ok = s.templates.update(req.GetTplId(), func(row *templatev1.ChannelTemplate) {
+		row.ActivatedVersionId = req.GetVersionId()
+		row.UpdatedAt = time.Now().UnixMilli()
+	})
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "template %d not found", req.GetTplId())
+	}
+	return &templatev1.ActivateTemplateVersionResponse{}, nil
+}
+
+func (s *TemplateServer) ListTemplateVersion(_ context.Context, req *templatev1.ListTemplateVersionRequest) (*templatev1.ListTemplateVersionResponse, error) {
+	versions := s.versions.find(func(row *templatev1.TemplateVersion) bool {
+		return row.GetTplId() == req.GetTplId()
+	})
+	for i, version := range versions {
+		versions[i] = project(version, req.GetFieldMask())
+	}
+	return &templatev1.ListTemplateVersionResponse{Versions: versions}, nil
+}
+
+func (s *TemplateServer) SaveTemplateProviders(_ context.Context, req *templatev1.SaveTemplateProvidersRequest) (*templatev1.SaveTemplateProvidersResponse, error) {
+	version, ok := s.versions.get(req.GetTplVersionId())
+	if !ok || version.GetTplId() != req.GetTplId() {
+		return nil, status.Errorf(codes.NotFound, "template version %d of template %d not found", req.GetTplVersionId(), req.GetTplId())
+	}
+	tpl, ok := s.templates.get(req.GetTplId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "template %d not found", req.GetTplId())
+	}
+
+	now := time.Now().UnixMilli()
+	for _, related := range req.GetRelatedProviders() {
+		s.providers.insert(&templatev1.TemplateProvider{
+			TplId:           req.GetTplId(),
+			TplVersionId:    req.GetTplVersionId(),
+			ProviderId:      related.GetProviderId(),
+			ProviderName:    related.GetProviderName(),
+			ProviderChannel: tpl.GetChannel(),
+			AuditStatus:     AuditStatusPending,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}, func(row *templatev1.TemplateProvider, id uint64) {
+			row.Id = id
+		})
+	}
+	return &templatev1.SaveTemplateProvidersResponse{}, nil
+}
+
+func (s *TemplateServer) DeleteTemplateProvider(_ context.Context, req *templatev1.DeleteTemplateProviderRequest) (*templatev1.DeleteTemplateProviderResponse, error) {
+	if !s.providers.delete(req.GetId()) {
+		return nil, status.Errorf(codes.NotFound, "template provider %d not found", req.GetId())
+	}
+	return &templatev1.DeleteTemplateProviderResponse{}, nil
+}
+
+func (s *TemplateServer) ListTemplateProvider(_ context.Context, req *templatev1.ListTemplateProviderRequest) (*templatev1.ListTemplateProviderResponse, error) {
+	providers := s.providers.find(func(row *templatev1.TemplateProvider) bool {
+		return row.GetTplVersionId() == req.GetVersionId()
+	})
+	for i, provider := range providers {
+		providers[i] = project(provider, req.GetFieldMask())
+	}
+	return &templatev1.ListTemplateProviderResponse{Providers: providers}, nil
+}
+
+func NewTemplateServer() *TemplateServer {
+	return &TemplateServer{
+		templates: newTable[*templatev1.ChannelTemplate](),
+		versions:  newTable[*templatev1.TemplateVersion](),
+		providers: newTable[*templatev1.TemplateProvider](),
+	}
+}
@@ -3,12 +3,17 @@ package ioc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/JrMarcco/kuryr-admin/internal/config"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
 	"github.com/JrMarcco/kuryr-admin/internal/pkg/gin/middleware"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/health"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
 	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -22,26 +27,54 @@ var AppFxOpt = fx.Module(
 	fx.Invoke(
 		fx.Annotate(
 			InitApp,
-			fx.ParamTags(``, ``, ``, ``, `group:"handler"`),
+			fx.ParamTags(``, ``, ``, ``, ``, ``, ``, ``, `group:"handler"`),
 		),
 	),
 )
 
 type App struct {
-	svr    *http.Server
-	logger *zap.Logger
+	svr          *http.Server
+	logger       *zap.Logger
+	shutdowner   fx.Shutdowner
+	drainChecker *health.DrainChecker
+	drainPeriod  time.Duration
 }
 
+// Start 同步监听端口，端口占用等错误直接通过 fx 生命周期返回。
+// 监听成功后在后台处理请求，运行期间出现的错误通过 fx.Shutdowner 触发停机。
 func (app *App) Start() error {
+	ln, err := net.Listen("tcp", app.svr.Addr)
+	if err != nil {
+		return fmt.Errorf("[kuryr-admin] failed to listen on %s: %w", app.svr.Addr, err)
+	}
+	app.logger.Info("[kuryr-admin] server started", zap.String("addr", ln.Addr().String()))
+
 	go func() {
-		if err := app.svr.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			app.logger.Fatal("[kuryr-admin] listen and serve", zap.Error(err))
+		if err := app.svr.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			app.logger.Error("[kuryr-admin] failed to serve", zap.Error(err))
+			if err = app.shutdowner.Shutdown(fx.ExitCode(1)); err != nil {
+				app.logger.Error("[kuryr-admin] failed to shutdown", zap.Error(err))
+			}
 		}
 	}()
 	return nil
 }
 
+// Stop 优雅停机。
+// 先将就绪检查置为失败并等待排空时间，让负载均衡摘除流量，再关闭服务等待处理中的请求完成。
 func (app *App) Stop(ctx context.Context) error {
+	app.drainChecker.StartDraining()
+
+	if app.drainPeriod > 0 {
+		app.logger.Info("[kuryr-admin] draining server ...", zap.Duration("drain_period", app.drainPeriod))
+		timer := time.NewTimer(app.drainPeriod)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
 	app.logger.Info("[kuryr-admin] shutdown server ...")
 	if err := app.svr.Shutdown(ctx); err != nil {
 		app.logger.Error("[kuryr-admin] server shutdown", zap.Error(err))
@@ -51,14 +84,22 @@ func (app *App) Stop(ctx context.Context) error {
 	return nil
 }
 
-func InitApp(lc fx.Lifecycle, engine *gin.Engine, logger *zap.Logger, mbs []middleware.Builder, registries []pkggin.RouteRegistry) *App {
-	type config struct {
-		Addr string `mapstructure:"addr"`
-	}
+func InitApp(
+	lc fx.Lifecycle,
+	shutdowner fx.Shutdowner,
+	engine *gin.Engine,
+	logger *zap.Logger,
+	drainChecker *health.DrainChecker,
+	publicRoutes *route.Set,
+	appCfg *config.Config,
+	mbs []middleware.Builder,
+	registries []pkggin.RouteRegistry,
+) *App {
+	cfg := appCfg.App
 
-	cfg := config{}
-	if err := viper.UnmarshalKey("app", &cfg); err != nil {
-	}
+	// gin.Context 的 Value / Done / Deadline 回退到 http.Request 的 context，
+	// 使写入 request context 的 request id 等信息可以传递到 service 层
+	engine.ContextWithFallback = true
 
 	middlewares := make([]gin.HandlerFunc, 0, len(mbs))
 	for _, mb := range mbs {
@@ -72,12 +113,19 @@ func InitApp(lc fx.Lifecycle, engine *gin.Engine, logger *zap.Logger, mbs []midd
 	}
 
 	app := &App{
-		svr:    svr,
-		logger: logger,
+		svr:          svr,
+		logger:       logger,
+		shutdowner:   shutdowner,
+		drainChecker: drainChecker,
+		drainPeriod:  time.Duration(cfg.DrainPeriod) * time.Millisecond,
 	}
 
 	for _, registry := range registries {
 		registry.RegisterRoutes(engine)
+		// 记录路由注册时声明的公开路由，jwt 中间件据此跳过 token 校验
+		if pr, ok := registry.(pkggin.PublicRouteRegistry); ok {
+			publicRoutes.Add(pr.PublicRoutes()...)
+		}
 	}
 
 	lc.Append(fx.Hook{
@@ -94,11 +142,17 @@ func InitApp(lc fx.Lifecycle, engine *gin.Engine, logger *zap.Logger, mbs []midd
 
 // InitMiddlewares 提供一个用于创建有序中间件切片的函数
 func InitMiddlewares(
+	requestIdBuilder *middleware.RequestIdBuilder,
+	tracingBuilder *middleware.TracingBuilder,
+	metricsBuilder *middleware.MetricsBuilder,
 	corsBuilder *middleware.CorsBuilder,
 	jwtBuilder *middleware.JwtBuilder,
 ) []middleware.Builder {
 	// 按顺序排列中间件
 	return []middleware.Builder{
+		requestIdBuilder,
+		tracingBuilder,
+		metricsBuilder,
 		corsBuilder,
 		jwtBuilder,
 	}
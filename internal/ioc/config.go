@@ -0,0 +1,53 @@
+package ioc
+
+import (
+	"context"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/web"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var ConfigFxOpt = fx.Module(
+	"config",
+	fx.Provide(
+		// config handler
+		fx.Annotate(
+			web.NewConfigHandler,
+			fx.As(new(pkggin.RouteRegistry)),
+			fx.ResultTags(`group:"handler"`),
+		),
+	),
+	fx.Invoke(InitConfigWatcher),
+)
+
+// InitConfigWatcher 监听配置文件变化，热加载支持运行时修改的配置项
+func InitConfigWatcher(lc fx.Lifecycle, reloader *config.Reloader, logger *zap.Logger) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			return reloader.Watch(ctx, func(res config.ReloadResult, err error) {
+				if err != nil {
+					logger.Error("[kuryr-admin] failed to reload config", zap.Error(err))
+					return
+				}
+				if len(res.Applied) == 0 && len(res.RestartRequired) == 0 {
+					return
+				}
+				if len(res.RestartRequired) > 0 {
+					logger.Warn("[kuryr-admin] config changes require restart to take effect",
+						zap.Strings("restart_required", res.RestartRequired),
+					)
+				}
+				logger.Info("[kuryr-admin] config reloaded", zap.Strings("applied", res.Applied))
+			})
+		},
+		OnStop: func(_ context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
@@ -1,12 +1,19 @@
 package ioc
 
 import (
+	"context"
+	"errors"
 	"time"
 
+	"github.com/JrMarcco/kuryr-admin/internal/config"
 	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/health"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/migrate"
 	"github.com/JrMarcco/kuryr-admin/internal/pkg/snowflake"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/migration"
 	_ "github.com/lib/pq"
-	"github.com/spf13/viper"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
@@ -17,49 +24,113 @@ import (
 var DBFxOpt = fx.Module(
 	"db",
 	fx.Provide(
+		InitDialector,
 		InitDB,
+		InitMigrator,
 		snowflake.NewGenerator,
 	),
+	fx.Invoke(CheckMigration),
 )
 
-func InitDB(zLogger *zap.Logger) *gorm.DB {
-	type config struct {
-		LogLevel                  string `mapstructure:"log_level"`
-		SlowThreshold             int    `mapstructure:"slow_threshold"`
-		IgnoreRecordNotFoundError bool   `mapstructure:"ignore_record_not_found_error"`
-		DSN                       string `mapstructure:"dsn"`
-	}
-	cfg := config{}
-	if err := viper.UnmarshalKey("db", &cfg); err != nil {
-		panic(err)
-	}
+// InitDialector 初始化数据库驱动，测试时可以通过 fx.Decorate 替换为其他数据库
+func InitDialector(cfg *config.Config) gorm.Dialector {
+	return postgres.Open(cfg.DB.DSN)
+}
 
-	var level logger.LogLevel
-	switch cfg.LogLevel {
-	case "silent":
-		level = logger.Silent
-	case "error":
-		level = logger.Error
-	case "warn":
-		level = logger.Warn
-	case "info":
-		level = logger.Info
-	default:
-		panic("invalid log level")
-	}
+func InitDB(
+	dialector gorm.Dialector,
+	zLogger *zap.Logger,
+	reg prometheus.Registerer,
+	tp trace.TracerProvider,
+	appCfg *config.Config,
+	reloader *config.Reloader,
+) *gorm.DB {
+	cfg := appCfg.DB
 
 	gormLogger := pkggorm.NewZapLogger(
 		zLogger,
-		pkggorm.WithLogLevel(level),
+		pkggorm.WithLogLevel(gormLogLevel(cfg.LogLevel)),
 		pkggorm.WithSlowThreshold(time.Duration(cfg.SlowThreshold)*time.Millisecond),
 		pkggorm.WithIgnoreRecordNotFoundError(cfg.IgnoreRecordNotFoundError),
 	)
+	reloader.OnReload(func(cfg *config.Config) {
+		gormLogger.SetLogLevel(gormLogLevel(cfg.DB.LogLevel))
+		gormLogger.SetSlowThreshold(time.Duration(cfg.DB.SlowThreshold) * time.Millisecond)
+	})
 
-	db, err := gorm.Open(postgres.Open(cfg.DSN), &gorm.Config{
-		Logger: gormLogger,
+	// 关闭 gorm 打开连接时的自动 ping，由下面的重试逻辑检查数据库是否可用
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:               gormLogger,
+		DisableAutomaticPing: true,
 	})
 	if err != nil {
 		panic(err)
 	}
+
+	checker := health.NewDBChecker(db)
+	if err = connectWithRetry(appCfg.Startup, zLogger, "postgres", time.Second, checker.Check); err != nil {
+		panic(err)
+	}
+
+	// 注册 sql 指标插件
+	if err = db.Use(pkggorm.NewMetricsPlugin(reg)); err != nil {
+		panic(err)
+	}
+	// 注册 sql 链路追踪插件
+	if err = db.Use(pkggorm.NewTracingPlugin(tp)); err != nil {
+		panic(err)
+	}
 	return db
 }
+
+// InitMigrator 初始化数据库迁移，迁移脚本内嵌在程序内
+func InitMigrator(db *gorm.DB) *migrate.Migrator {
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic(err)
+	}
+
+	migrator, err := migrate.NewMigrator(sqlDB, migration.FS)
+	if err != nil {
+		panic(err)
+	}
+	return migrator
+}
+
+// CheckMigration 启动时检查数据库迁移是否已全部执行。
+// 存在未执行的迁移时拒绝启动；数据库不可用时，降级模式下只记录日志并继续启动。
+func CheckMigration(lc fx.Lifecycle, migrator *migrate.Migrator, logger *zap.Logger, cfg *config.Config) {
+	if !cfg.DB.MigrationCheck {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			err := migrator.Check(ctx)
+			if err == nil || errors.Is(err, migrate.ErrBehind) {
+				return err
+			}
+
+			if cfg.Startup.Degraded {
+				logger.Error("[kuryr-admin] failed to check database migration, starting in degraded mode", zap.Error(err))
+				return nil
+			}
+			return err
+		},
+	})
+}
+
+func gormLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "silent":
+		return logger.Silent
+	case "error":
+		return logger.Error
+	case "warn":
+		return logger.Warn
+	case "info":
+		return logger.Info
+	default:
+		panic("invalid log level")
+	}
+}
@@ -0,0 +1,47 @@
+package ioc
+
+import (
+	"context"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/fake"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// FakeBackendFxOpt 使用进程内的 fake kuryr 后端代替 EtcdFxOpt 与 RegistryFxOpt，
+// 所有 grpc 客户端都通过 bufconn 连接到 fake 后端。
+var FakeBackendFxOpt = fx.Module(
+	"fake-backend",
+	fx.Provide(
+		InitFakeBackend,
+		func(backend *fake.Backend) registry.Registry {
+			return backend.Registry()
+		},
+		fx.Annotate(
+			func(backend *fake.Backend) grpc.DialOption {
+				return grpc.WithContextDialer(backend.Dialer)
+			},
+			fx.ResultTags(`group:"grpc-dial-option"`),
+		),
+	),
+)
+
+func InitFakeBackend(lc fx.Lifecycle, logger *zap.Logger, cfg *config.Config) *fake.Backend {
+	backend := fake.NewBackend(cfg.Grpc.Server.Name)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Warn("[kuryr-admin] using in-process fake kuryr backend, data will be lost on exit")
+			backend.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			backend.Stop(ctx)
+			return nil
+		},
+	})
+	return backend
+}
@@ -1,150 +1,210 @@
 package ioc
 
 import (
+	"context"
+	"fmt"
 	"time"
 
-	"github.com/JrMarcco/easy-grpc/client"
-	"github.com/JrMarcco/easy-grpc/client/br"
 	"github.com/JrMarcco/easy-grpc/client/rr"
 	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/circuitbreaker"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/grpc/interceptor"
 	businessv1 "github.com/JrMarcco/kuryr-api/api/go/business/v1"
 	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
 	notificationv1 "github.com/JrMarcco/kuryr-api/api/go/notification/v1"
 	providerv1 "github.com/JrMarcco/kuryr-api/api/go/provider/v1"
-	"github.com/spf13/viper"
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/balancer"
-	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/balancer/pickfirst"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
 
 var GrpcClientFxOpt = fx.Module(
 	"grpc",
 	fx.Provide(
-		InitBizInfoGrpcClients,
-		InitBizConfigGrpcClients,
-		InitProviderGrpcClients,
-		InitNotificationGrpcClients,
+		interceptor.NewTracingBuilder,
+		interceptor.NewRequestIdBuilder,
+		interceptor.NewBizIdBuilder,
+		interceptor.NewMetricsBuilder,
+		InitGrpcTimeoutBuilder,
+		InitGrpcRetryBuilder,
+		InitGrpcBreakerBuilder,
+		InitGrpcAuthBuilder,
+		InitGrpcInterceptors,
+		fx.Annotate(
+			InitGrpcManagerFactory,
+			fx.ParamTags(``, ``, `group:"grpc-dial-option"`, ``, ``),
+		),
+
+		// kuryr-api 客户端管理器
+		grpcClients(businessv1.BusinessService_ServiceDesc.ServiceName, businessv1.NewBusinessServiceClient),
+		grpcClients(configv1.BizConfigService_ServiceDesc.ServiceName, configv1.NewBizConfigServiceClient),
+		grpcClients(providerv1.ProviderService_ServiceDesc.ServiceName, providerv1.NewProviderServiceClient),
+		grpcClients(templatev1.TemplateService_ServiceDesc.ServiceName, templatev1.NewTemplateServiceClient),
+		grpcClients(notificationv1.NotificationService_ServiceDesc.ServiceName, notificationv1.NewNotificationServiceClient),
 	),
 )
 
-type keepaliveConfig struct {
-	Time                int  `mapstructure:"time"`
-	Timeout             int  `mapstructure:"timeout"`
-	PermitWithoutStream bool `mapstructure:"permit_without_stream"`
+// InitGrpcTimeoutBuilder 初始化 grpc 调用超时拦截器，超时时间支持运行时修改
+func InitGrpcTimeoutBuilder(cfg *config.Config, reloader *config.Reloader) *interceptor.TimeoutBuilder {
+	builder := interceptor.NewTimeoutBuilder(grpcTimeouts(cfg.Grpc.Client))
+	reloader.OnReload(func(cfg *config.Config) {
+		builder.SetTimeouts(grpcTimeouts(cfg.Grpc.Client))
+	})
+	return builder
 }
 
-type lbConfig struct {
-	Name      string           `mapstructure:"name"`
-	Timeout   int              `mapstructure:"timeout"`
-	KeepAlive *keepaliveConfig `mapstructure:"keep_alive"`
+// grpcTimeouts 将默认超时时间与按服务 / 方法配置的超时时间转换为拦截器配置
+func grpcTimeouts(cfg config.GrpcClientConfig) interceptor.Timeouts {
+	overrides := make(map[string]time.Duration, len(cfg.Timeouts))
+	for _, t := range cfg.Timeouts {
+		key := t.Service
+		if t.Method != "" {
+			key += "/" + t.Method
+		}
+		overrides[key] = time.Duration(t.Timeout) * time.Millisecond
+	}
+	return interceptor.Timeouts{
+		Default:   time.Duration(cfg.Timeout) * time.Millisecond,
+		Overrides: overrides,
+	}
 }
 
-func loadLoadBalanceConfig() *lbConfig {
-	cfg := &lbConfig{}
-	if err := viper.UnmarshalKey("grpc.client.load_balance", &cfg); err != nil {
+// InitGrpcRetryBuilder 初始化幂等读请求重试拦截器
+func InitGrpcRetryBuilder(appCfg *config.Config) *interceptor.RetryBuilder {
+	cfg := appCfg.Grpc.Client.Retry
+	builder, err := interceptor.NewRetryBuilder(
+		time.Duration(cfg.InitialInterval)*time.Millisecond,
+		time.Duration(cfg.MaxInterval)*time.Millisecond,
+		cfg.MaxTimes,
+		cfg.Methods,
+	)
+	if err != nil {
 		panic(err)
 	}
-	return cfg
+	return builder
 }
 
-// InitBizInfoGrpcClients 初始化 biz info grpc client 管理器
-func InitBizInfoGrpcClients(r registry.Registry) *client.Manager[businessv1.BusinessServiceClient] {
-	cfg := loadLoadBalanceConfig()
-	bb := base.NewBalancerBuilder(
-		cfg.Name,
-		br.NewRwWeightBalancerBuilder(),
-		base.Config{HealthCheck: true},
-	)
+// InitGrpcBreakerBuilder 初始化按服务熔断拦截器，未开启熔断时同样提供，供健康检查使用
+func InitGrpcBreakerBuilder(appCfg *config.Config, reg prometheus.Registerer) *interceptor.BreakerBuilder {
+	cfg := appCfg.Grpc.Client.CircuitBreaker
+	return interceptor.NewBreakerBuilder(circuitbreaker.Config{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      time.Duration(cfg.OpenTimeout) * time.Millisecond,
+	}, reg)
+}
 
-	// 注册负载均衡
-	balancer.Register(bb)
-
-	return client.NewManagerBuilder(
-		rr.NewResolverBuilder(r, time.Duration(cfg.Timeout)*time.Millisecond),
-		bb,
-		func(conn *grpc.ClientConn) businessv1.BusinessServiceClient {
-			return businessv1.NewBusinessServiceClient(conn)
-		},
-	).KeepAlive(keepalive.ClientParameters{
-		Time:                time.Duration(cfg.KeepAlive.Time) * time.Millisecond,
-		Timeout:             time.Duration(cfg.KeepAlive.Timeout) * time.Millisecond,
-		PermitWithoutStream: cfg.KeepAlive.PermitWithoutStream,
-	}).Insecure().Build()
+// InitGrpcAuthBuilder 初始化服务间认证拦截器
+func InitGrpcAuthBuilder(cfg *config.Config) *interceptor.AuthBuilder {
+	return interceptor.NewAuthBuilder(cfg.Grpc.Client.Auth.Token)
 }
 
-// InitBizConfigGrpcClients 初始化 biz config grpc client 管理器
-func InitBizConfigGrpcClients(r registry.Registry) *client.Manager[configv1.BizConfigServiceClient] {
-	cfg := loadLoadBalanceConfig()
-	bb := base.NewBalancerBuilder(
-		cfg.Name,
-		br.NewRwWeightBalancerBuilder(),
-		base.Config{HealthCheck: true},
+// InitGrpcInterceptors 提供一个用于创建有序 grpc 客户端拦截器切片的函数
+func InitGrpcInterceptors(
+	timeoutBuilder *interceptor.TimeoutBuilder,
+	breakerBuilder *interceptor.BreakerBuilder,
+	retryBuilder *interceptor.RetryBuilder,
+	tracingBuilder *interceptor.TracingBuilder,
+	requestIdBuilder *interceptor.RequestIdBuilder,
+	bizIdBuilder *interceptor.BizIdBuilder,
+	authBuilder *interceptor.AuthBuilder,
+	metricsBuilder *interceptor.MetricsBuilder,
+	cfg *config.Config,
+) []grpc.UnaryClientInterceptor {
+	// 按顺序排列拦截器
+	// 超时时间覆盖所有重试，熔断器只记录重试后的最终结果，每次重试单独记录链路与指标
+	builders := []interceptor.Builder{timeoutBuilder}
+	if cfg.Grpc.Client.CircuitBreaker.Enabled {
+		builders = append(builders, breakerBuilder)
+	}
+	builders = append(builders,
+		retryBuilder,
+		tracingBuilder,
+		requestIdBuilder,
+		bizIdBuilder,
+		authBuilder,
+		metricsBuilder,
 	)
 
-	// 注册负载均衡
-	balancer.Register(bb)
-
-	return client.NewManagerBuilder(
-		rr.NewResolverBuilder(r, time.Duration(cfg.Timeout)*time.Millisecond),
-		bb,
-		func(conn *grpc.ClientConn) configv1.BizConfigServiceClient {
-			return configv1.NewBizConfigServiceClient(conn)
-		},
-	).KeepAlive(keepalive.ClientParameters{
-		Time:                time.Duration(cfg.KeepAlive.Time) * time.Millisecond,
-		Timeout:             time.Duration(cfg.KeepAlive.Timeout) * time.Millisecond,
-		PermitWithoutStream: cfg.KeepAlive.PermitWithoutStream,
-	}).Insecure().Build()
+	interceptors := make([]grpc.UnaryClientInterceptor, 0, len(builders))
+	for _, builder := range builders {
+		interceptors = append(interceptors, builder.Build())
+	}
+	return interceptors
 }
 
-// InitProviderGrpcClients 初始化 provider grpc client 管理器
-func InitProviderGrpcClients(r registry.Registry) *client.Manager[providerv1.ProviderServiceClient] {
-	cfg := loadLoadBalanceConfig()
-	bb := base.NewBalancerBuilder(
-		cfg.Name,
-		br.NewRwWeightBalancerBuilder(),
-		base.Config{HealthCheck: true},
-	)
+// InitGrpcManagerFactory 初始化所有 grpc 客户端管理器共享的连接配置。
+// dialOpts 为其他模块通过 grpc-dial-option 组提供的额外连接配置，例如 fake 后端的 dialer。
+func InitGrpcManagerFactory(
+	r registry.Registry,
+	interceptors []grpc.UnaryClientInterceptor,
+	dialOpts []grpc.DialOption,
+	logger *zap.Logger,
+	appCfg *config.Config,
+) *pkggrpc.ManagerFactory {
+	cfg := appCfg.Grpc.Client
+
+	// 注册自定义负载均衡策略，只会注册一次，并且保证在创建连接之前完成
+	pkggrpc.RegisterBalancers()
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS.Enabled {
+		creds = credentials.NewTLS(newTLSConfig(logger, "grpc", cfg.TLS))
+	}
 
-	// 注册负载均衡
-	balancer.Register(bb)
-
-	return client.NewManagerBuilder(
-		rr.NewResolverBuilder(r, time.Duration(cfg.Timeout)*time.Millisecond),
-		bb,
-		func(conn *grpc.ClientConn) providerv1.ProviderServiceClient {
-			return providerv1.NewProviderServiceClient(conn)
-		},
-	).KeepAlive(keepalive.ClientParameters{
-		Time:                time.Duration(cfg.KeepAlive.Time) * time.Millisecond,
-		Timeout:             time.Duration(cfg.KeepAlive.Timeout) * time.Millisecond,
-		PermitWithoutStream: cfg.KeepAlive.PermitWithoutStream,
-	}).Insecure().Build()
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(cfg.LoadBalance.KeepAlive.Time) * time.Millisecond,
+			Timeout:             time.Duration(cfg.LoadBalance.KeepAlive.Timeout) * time.Millisecond,
+			PermitWithoutStream: cfg.LoadBalance.KeepAlive.PermitWithoutStream,
+		}),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	}, dialOpts...)
+
+	return pkggrpc.NewManagerFactory(
+		rr.NewResolverBuilder(r, time.Duration(cfg.LoadBalance.Timeout)*time.Millisecond),
+		opts...,
+	)
 }
 
-// InitNotificationGrpcClients 初始化 notification grpc client 管理器
-func InitNotificationGrpcClients(r registry.Registry) *client.Manager[notificationv1.NotificationServiceClient] {
-	cfg := loadLoadBalanceConfig()
-	bb := base.NewBalancerBuilder(
-		cfg.Name,
-		br.NewRwWeightBalancerBuilder(),
-		base.Config{HealthCheck: true},
-	)
+// grpcClients 返回用于创建指定 kuryr-api 客户端管理器的 provider。
+// service 为完整服务名，用于选择负载均衡策略，程序停止时关闭所有连接。
+func grpcClients[T any](
+	service string, creator func(cc grpc.ClientConnInterface) T,
+) func(fx.Lifecycle, *pkggrpc.ManagerFactory, *config.Config) *pkggrpc.Manager[T] {
+	return func(lc fx.Lifecycle, factory *pkggrpc.ManagerFactory, cfg *config.Config) *pkggrpc.Manager[T] {
+		policy := cfg.Grpc.Client.LoadBalance.PolicyFor(service)
+		manager := pkggrpc.NewManager(factory, creator, pkggrpc.WithBalancer(balancerName(policy)))
+		lc.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				return manager.CloseAll()
+			},
+		})
+		return manager
+	}
+}
 
-	// 注册负载均衡
-	balancer.Register(bb)
-
-	return client.NewManagerBuilder(
-		rr.NewResolverBuilder(r, time.Duration(cfg.Timeout)*time.Millisecond),
-		bb,
-		func(conn *grpc.ClientConn) notificationv1.NotificationServiceClient {
-			return notificationv1.NewNotificationServiceClient(conn)
-		},
-	).KeepAlive(keepalive.ClientParameters{
-		Time:                time.Duration(cfg.KeepAlive.Time) * time.Millisecond,
-		Timeout:             time.Duration(cfg.KeepAlive.Timeout) * time.Millisecond,
-		PermitWithoutStream: cfg.KeepAlive.PermitWithoutStream,
-	}).Insecure().Build()
+// balancerName 返回负载均衡策略对应的 grpc 负载均衡名称
+func balancerName(policy string) string {
+	switch policy {
+	case config.LoadBalancePolicyRwWeight:
+		return pkggrpc.BalancerRwWeight
+	case config.LoadBalancePolicyRoundRobin:
+		return roundrobin.Name
+	case config.LoadBalancePolicyPickFirst:
+		return pickfirst.Name
+	case config.LoadBalancePolicyConsistentHash:
+		return pkggrpc.BalancerConsistentHash
+	default:
+		panic(fmt.Errorf("invalid load balance policy %q", policy))
+	}
 }
@@ -0,0 +1,122 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/grpc/interceptor"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/health"
+	"github.com/JrMarcco/kuryr-admin/internal/web"
+	businessv1 "github.com/JrMarcco/kuryr-api/api/go/business/v1"
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	notificationv1 "github.com/JrMarcco/kuryr-api/api/go/notification/v1"
+	providerv1 "github.com/JrMarcco/kuryr-api/api/go/provider/v1"
+	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
+	"go.uber.org/fx"
+)
+
+var HealthFxOpt = fx.Module(
+	"health",
+	fx.Provide(
+		// 停机排空 checker，同时提供给 app 在停机时标记排空状态
+		health.NewDrainChecker,
+		fx.Annotate(
+			func(c *health.DrainChecker) health.Checker { return c },
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		// db checker
+		fx.Annotate(
+			health.NewDBChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		// redis checker
+		fx.Annotate(
+			health.NewRedisChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		// grpc checkers
+		fx.Annotate(
+			InitBizInfoGrpcChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		fx.Annotate(
+			InitBizConfigGrpcChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		fx.Annotate(
+			InitProviderGrpcChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		fx.Annotate(
+			InitTemplateGrpcChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+		fx.Annotate(
+			InitNotificationGrpcChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+
+		// grpc 熔断器 checker
+		fx.Annotate(
+			InitCircuitBreakerChecker,
+			fx.As(new(health.Checker)),
+			fx.ResultTags(`group:"health-checker"`),
+		),
+
+		// health handler
+		fx.Annotate(
+			InitHealthHandler,
+			fx.As(new(pkggin.RouteRegistry)),
+			fx.ParamTags(`group:"health-checker"`, ``),
+			fx.ResultTags(`group:"handler"`),
+		),
+	),
+)
+
+func InitHealthHandler(checkers []health.Checker, cfg *config.Config) *web.HealthHandler {
+	return web.NewHealthHandler(checkers, time.Duration(cfg.Health.Timeout)*time.Millisecond)
+}
+
+func InitBizInfoGrpcChecker(
+	manager *pkggrpc.Manager[businessv1.BusinessServiceClient], r registry.Registry, cfg *config.Config,
+) *health.GrpcChecker[businessv1.BusinessServiceClient] {
+	return health.NewGrpcChecker("grpc.business", cfg.Grpc.Server.Name, manager, r)
+}
+
+func InitBizConfigGrpcChecker(
+	manager *pkggrpc.Manager[configv1.BizConfigServiceClient], r registry.Registry, cfg *config.Config,
+) *health.GrpcChecker[configv1.BizConfigServiceClient] {
+	return health.NewGrpcChecker("grpc.biz_config", cfg.Grpc.Server.Name, manager, r)
+}
+
+func InitProviderGrpcChecker(
+	manager *pkggrpc.Manager[providerv1.ProviderServiceClient], r registry.Registry, cfg *config.Config,
+) *health.GrpcChecker[providerv1.ProviderServiceClient] {
+	return health.NewGrpcChecker("grpc.provider", cfg.Grpc.Server.Name, manager, r)
+}
+
+func InitTemplateGrpcChecker(
+	manager *pkggrpc.Manager[templatev1.TemplateServiceClient], r registry.Registry, cfg *config.Config,
+) *health.GrpcChecker[templatev1.TemplateServiceClient] {
+	return health.NewGrpcChecker("grpc.template", cfg.Grpc.Server.Name, manager, r)
+}
+
+func InitNotificationGrpcChecker(
+	manager *pkggrpc.Manager[notificationv1.NotificationServiceClient], r registry.Registry, cfg *config.Config,
+) *health.GrpcChecker[notificationv1.NotificationServiceClient] {
+	return health.NewGrpcChecker("grpc.notification", cfg.Grpc.Server.Name, manager, r)
+}
+
+func InitCircuitBreakerChecker(builder *interceptor.BreakerBuilder) *health.CircuitBreakerChecker {
+	return health.NewCircuitBreakerChecker(builder.Group())
+}
@@ -0,0 +1,77 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var JobFxOpt = fx.Module(
+	"job",
+	fx.Invoke(InitQuotaAlertJob),
+)
+
+// InitQuotaAlertJob 开启配额告警时在后台定期检查配额用量，程序停止时等待正在进行的检查结束。
+// 多个实例同时检查时由告警记录去重，同一告警只会发送一次。
+// 没有接入用量来源时告警无法触发，只记录警告日志，不启动检查。
+func InitQuotaAlertJob(
+	lc fx.Lifecycle,
+	svc service.QuotaAlertService,
+	usageSvc service.QuotaUsageService,
+	logger *zap.Logger,
+	cfg *config.Config,
+) {
+	if !cfg.QuotaAlert.Enabled {
+		return
+	}
+	if !usageSvc.Available() {
+		logger.Warn("[kuryr-admin] quota alert is enabled but no quota usage source is available, quota alert job not started")
+		return
+	}
+
+	interval := time.Duration(cfg.QuotaAlert.Interval) * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			logger.Info("[kuryr-admin] quota alert job started", zap.Duration("interval", interval))
+
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						alerts, err := svc.Evaluate(ctx)
+						if len(alerts) > 0 {
+							logger.Info("[kuryr-admin] quota alerts sent", zap.Int("count", len(alerts)))
+						}
+						if err != nil && ctx.Err() == nil {
+							logger.Error("[kuryr-admin] failed to evaluate quota alerts", zap.Error(err))
+						}
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
@@ -4,8 +4,8 @@ import (
 	"time"
 
 	easyjwt "github.com/JrMarcco/easy-kit/jwt"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
-	"github.com/spf13/viper"
 	"go.uber.org/fx"
 )
 
@@ -23,30 +23,9 @@ var JwtManagerOpt = fx.Module(
 	),
 )
 
-type jwtTokenConfig struct {
-	Issuer     string `mapstructure:"issuer"`
-	Expiration int    `mapstructure:"expiration"`
-}
-
-type jwtConfig struct {
-	Private string         `mapstructure:"private"`
-	Public  string         `mapstructure:"public"`
-	Access  jwtTokenConfig `mapstructure:"access"`
-	Refresh jwtTokenConfig `mapstructure:"refresh"`
-}
-
-// loadJwtConfig 加载配置
-func loadJwtConfig() *jwtConfig {
-	cfg := &jwtConfig{}
-	if err := viper.UnmarshalKey("jwt", cfg); err != nil {
-		panic(err)
-	}
-	return cfg
-}
-
 // InitAccessTokenManager 创建用于 Access Token 的 Manager
-func InitAccessTokenManager() easyjwt.Manager[pkggin.AuthUser] {
-	jwtCfg := loadJwtConfig()
+func InitAccessTokenManager(cfg *config.Config) easyjwt.Manager[pkggin.AuthUser] {
+	jwtCfg := cfg.Jwt
 
 	claimsCfg := easyjwt.NewClaimsConfig(
 		time.Duration(jwtCfg.Access.Expiration)*time.Second,
@@ -63,8 +42,8 @@ func InitAccessTokenManager() easyjwt.Manager[pkggin.AuthUser] {
 }
 
 // InitRefreshTokenManager 创建用于 Refresh Token 的 Manager
-func InitRefreshTokenManager() easyjwt.Manager[pkggin.AuthUser] {
-	jwtCfg := loadJwtConfig()
+func InitRefreshTokenManager(cfg *config.Config) easyjwt.Manager[pkggin.AuthUser] {
+	jwtCfg := cfg.Jwt
 
 	claimsCfg := easyjwt.NewClaimsConfig(
 		time.Duration(jwtCfg.Refresh.Expiration)*time.Second,
@@ -4,38 +4,40 @@ import (
 	"context"
 	"log/slog"
 
-	"github.com/spf13/viper"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"go.uber.org/zap/exp/zapslog"
+	"go.uber.org/zap/zapcore"
 )
 
 var LoggerFxOpt = fx.Module("logger", fx.Provide(InitLogger))
 
-func InitLogger(lc fx.Lifecycle) *zap.Logger {
-	type config struct {
-		Env string `mapstructure:"env"`
-	}
-
-	cfg := config{}
-	if err := viper.UnmarshalKey("profile", &cfg); err != nil {
-		panic(err)
-	}
-
-	var zapLogger *zap.Logger
-	var err error
-	switch cfg.Env {
+func InitLogger(lc fx.Lifecycle, cfg *config.Config, reloader *config.Reloader) *zap.Logger {
+	var zapCfg zap.Config
+	switch cfg.Profile.Env {
 	case "prod":
-		zapLogger, err = zap.NewProduction()
+		zapCfg = zap.NewProductionConfig()
 	default:
-		zapLogger, err = zap.NewDevelopment()
+		zapCfg = zap.NewDevelopmentConfig()
 	}
+
+	// 使用 AtomicLevel 支持运行时修改日志级别
+	defaultLevel := zapCfg.Level.Level()
+	zapCfg.Level = zap.NewAtomicLevelAt(logLevel(cfg.Log.Level, defaultLevel))
+	reloader.OnReload(func(cfg *config.Config) {
+		zapCfg.Level.SetLevel(logLevel(cfg.Log.Level, defaultLevel))
+	})
+
+	zapLogger, err := zapCfg.Build()
 	if err != nil {
 		panic(err)
 	}
 
 	// 初始化 slog
-	slog.SetDefault(slog.New(zapslog.NewHandler(zapLogger.Core())))
+	// 使用 slog.XxxContext 记录日志时会自动追加 request id
+	slog.SetDefault(slog.New(reqid.NewSlogHandler(zapslog.NewHandler(zapLogger.Core()))))
 
 	// 注册生命周期 hook
 	lc.Append(fx.Hook{
@@ -48,3 +50,16 @@ func InitLogger(lc fx.Lifecycle) *zap.Logger {
 
 	return zapLogger
 }
+
+// logLevel 解析日志级别，未配置时使用 profile 对应的默认级别
+func logLevel(level string, defaultLevel zapcore.Level) zapcore.Level {
+	if level == "" {
+		return defaultLevel
+	}
+
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
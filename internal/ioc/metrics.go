@@ -0,0 +1,29 @@
+package ioc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/fx"
+)
+
+var MetricsFxOpt = fx.Module(
+	"metrics",
+	fx.Provide(
+		fx.Annotate(
+			InitMetricsRegistry,
+			fx.As(new(prometheus.Registerer)),
+			fx.As(new(prometheus.Gatherer)),
+		),
+	),
+)
+
+// InitMetricsRegistry 初始化 prometheus registry。
+// 不使用 prometheus.DefaultRegisterer，避免依赖包的全局指标混入。
+func InitMetricsRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
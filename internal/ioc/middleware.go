@@ -4,43 +4,50 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
-	"strings"
+	"sync/atomic"
 	"time"
 
 	easyjwt "github.com/JrMarcco/easy-kit/jwt"
-	"github.com/JrMarcco/easy-kit/set"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
 	"github.com/JrMarcco/kuryr-admin/internal/pkg/gin/middleware"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
 	ijwt "github.com/JrMarcco/kuryr-admin/internal/web/jwt"
-	"github.com/spf13/viper"
 	"go.uber.org/fx"
 )
 
 var MiddlewareBuilderOpt = fx.Module(
 	"middleware",
 	fx.Provide(
+		middleware.NewRequestIdBuilder,
+		middleware.NewTracingBuilder,
+		middleware.NewMetricsBuilder,
+		// 公开路由集合，由路由注册时声明
+		route.NewSet,
 		InitCorsBuilder,
 		fx.Annotate(
 			InitJwtBuilder,
-			fx.ParamTags(``, `name:"access-token-manager"`),
+			fx.ParamTags(``, `name:"access-token-manager"`, ``, ``, ``),
 		),
 	),
 )
 
-func InitCorsBuilder() *middleware.CorsBuilder {
-	type config struct {
-		MaxAge    int      `mapstructure:"max_age"`
-		Hostnames []string `mapstructure:"hostnames"`
-	}
-	cfg := config{}
-	if err := viper.UnmarshalKey("cors", &cfg); err != nil {
-		panic(err)
-	}
+func InitCorsBuilder(appCfg *config.Config, reloader *config.Reloader) *middleware.CorsBuilder {
+	cfg := appCfg.Cors
+
+	// 允许跨域的 hostname 支持运行时修改
+	hostnames := &atomic.Pointer[[]string]{}
+	hostnames.Store(&cfg.Hostnames)
+	reloader.OnReload(func(cfg *config.Config) {
+		hostnames.Store(&cfg.Cors.Hostnames)
+	})
 
 	builder := middleware.NewCorsBuilder().
 		AllowCredentials(true).
-		AllowMethods([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}).
-		AllowHeaders([]string{"Content-Length", "Content-Type", "Authorization", "Accept", "Origin", pkggin.HeaderNameAccessToken}).
+		AllowMethods([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}).
+		AllowHeaders([]string{"Content-Length", "Content-Type", "Authorization", "Accept", "Origin", pkggin.HeaderNameAccessToken, reqid.HeaderName}).
+		ExposeHeaders([]string{"Origin", "Content-Length", "Content-Type", reqid.HeaderName}).
 		MaxAge(time.Duration(cfg.MaxAge) * time.Second).
 		AllowOriginFunc(func(origin string) bool {
 			if origin == "" {
@@ -51,25 +58,34 @@ func InitCorsBuilder() *middleware.CorsBuilder {
 				return false
 			}
 			reqHostname := u.Hostname()
-			return slices.Contains(cfg.Hostnames, reqHostname)
+			return slices.Contains(*hostnames.Load(), reqHostname)
 		})
 	return builder
 }
 
-func InitJwtBuilder(handler ijwt.Handler, jwtManager easyjwt.Manager[pkggin.AuthUser]) *middleware.JwtBuilder {
-	var ignores []string
-	if err := viper.UnmarshalKey("ignores", &ignores); err != nil {
-		panic(err)
-	}
+// InitJwtBuilder 初始化 jwt 中间件。
+// 路由注册时声明的公开路由以及配置中的 ignores 规则不需要校验 token，ignores 支持热加载。
+func InitJwtBuilder(
+	handler ijwt.Handler,
+	jwtManager easyjwt.Manager[pkggin.AuthUser],
+	publicRoutes *route.Set,
+	cfg *config.Config,
+	reloader *config.Reloader,
+) *middleware.JwtBuilder {
+	matcher := route.NewMatcher(publicRoutes, ignoreRules(cfg.Ignores))
+	reloader.OnReload(func(cfg *config.Config) {
+		matcher.SetRules(ignoreRules(cfg.Ignores))
+	})
+	return middleware.NewJwtBuilder(handler, jwtManager, matcher)
+}
 
-	ts, err := set.NewTreeSet(strings.Compare)
+// ignoreRules 解析 ignores 规则，规则在加载配置时已经校验过
+func ignoreRules(ignores []string) *route.Rules {
+	rules, err := route.ParseRules(ignores)
 	if err != nil {
 		panic(err)
 	}
-	for _, ignore := range ignores {
-		ts.Add(ignore)
-	}
-	return middleware.NewJwtBuilder(handler, jwtManager, ts)
+	return rules
 }
 
 func InitAccessLogBuilder() *middleware.AccessLogBuilder {
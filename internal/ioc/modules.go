@@ -0,0 +1,58 @@
+package ioc
+
+import (
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"go.uber.org/fx"
+)
+
+// Modules 返回组成 kuryr-admin 的所有模块。
+// main 与 e2e 测试共用同一份模块列表，保证测试启动的依赖图与线上一致。
+func Modules(cfg *config.Config) fx.Option {
+	return fx.Options(
+		// 初始化 zap.Logger
+		LoggerFxOpt,
+		// 初始化 prometheus registry
+		MetricsFxOpt,
+		// 初始化 tracing
+		TracingFxOpt,
+		// 初始化 redis.Client
+		RedisFxOpt,
+		// 初始化 gorm.DB
+		DBFxOpt,
+		// 初始化 etcd 与 grpc registry，或使用 fake 后端代替
+		backendFxOpt(cfg),
+		// 初始化 grpc client manager
+		GrpcClientFxOpt,
+		// 初始化 jwt manager
+		JwtManagerOpt,
+		// 初始化 middleware builder
+		MiddlewareBuilderOpt,
+		// 初始化 repo
+		RepoFxOpt,
+		// 初始化 service
+		ServiceFxOpt,
+		// 初始化 handler
+		HandlerFxOpt,
+		// 初始化健康检查
+		HealthFxOpt,
+		// 初始化配置热加载
+		ConfigFxOpt,
+		// 初始化后台任务
+		JobFxOpt,
+
+		// 注册 gin 路由，需要在 app 启动前完成
+		// HandlerFxInvoke,
+
+		// 初始化 App
+		AppFxOpt,
+	)
+}
+
+// backendFxOpt 返回 kuryr 后端相关模块。
+// 开启 dev.fake_backend 时使用进程内的 fake 后端，不依赖 etcd 与 kuryr 服务。
+func backendFxOpt(cfg *config.Config) fx.Option {
+	if cfg.Dev.FakeBackend {
+		return FakeBackendFxOpt
+	}
+	return fx.Options(EtcdFxOpt, RegistryFxOpt)
+}
@@ -1,9 +1,16 @@
 package ioc
 
 import (
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/health"
+	pkgredis "github.com/JrMarcco/kuryr-admin/internal/pkg/redis"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
-	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 var RedisFxOpt = fx.Module(
@@ -16,17 +23,22 @@ var RedisFxOpt = fx.Module(
 	),
 )
 
-func InitRedis() *redis.Client {
-	type config struct {
-		Addr     string `mapstructure:"addr"`
-		Password string `mapstructure:"password"`
-	}
-	cfg := config{}
-	if err := viper.UnmarshalKey("redis", &cfg); err != nil {
-		panic(err)
-	}
-	return redis.NewClient(&redis.Options{
+func InitRedis(logger *zap.Logger, reg prometheus.Registerer, tp trace.TracerProvider, appCfg *config.Config) *redis.Client {
+	cfg := appCfg.Redis
+	client := redis.NewClient(&redis.Options{
 		Addr:     cfg.Addr,
 		Password: cfg.Password,
 	})
+
+	// 注册 redis 命令指标 hook
+	client.AddHook(pkgredis.NewMetricsHook(reg))
+	// 注册 redis 链路追踪 hook
+	client.AddHook(pkgredis.NewTracingHook(tp))
+
+	checker := health.NewRedisChecker(client)
+	if err := connectWithRetry(appCfg.Startup, logger, "redis", time.Second, checker.Check); err != nil {
+		_ = client.Close()
+		panic(err)
+	}
+	return client
 }
@@ -1,11 +1,16 @@
 package ioc
 
 import (
+	"context"
+	"time"
+
 	"github.com/JrMarcco/easy-grpc/registry"
 	"github.com/JrMarcco/easy-grpc/registry/etcd"
-	"github.com/spf13/viper"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	pkgregistry "github.com/JrMarcco/kuryr-admin/internal/pkg/registry"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 var RegistryFxOpt = fx.Module(
@@ -18,22 +23,38 @@ var RegistryFxOpt = fx.Module(
 	),
 )
 
-func InitRegistry(etcdClient *clientv3.Client) *etcd.Registry {
-	type config struct {
-		KeyPrefix string `mapstructure:"key_prefix"`
-		LeaseTTL  int    `mapstructure:"lease_ttl"`
-	}
-
-	cfg := config{}
-	if err := viper.UnmarshalKey("registry", &cfg); err != nil {
-		panic(err)
-	}
+// InitRegistry 初始化服务注册中心。
+// etcd 不可用时创建 etcd.Registry 会阻塞，所以这里使用 LazyRegistry 在后台持续重试创建。
+func InitRegistry(
+	lc fx.Lifecycle, etcdClient *clientv3.Client, logger *zap.Logger, cfg *config.Config,
+) *pkgregistry.LazyRegistry {
+	r := pkgregistry.NewLazyRegistry(func() (registry.Registry, error) {
+		return etcd.NewBuilder(etcdClient).
+			LeaseTTL(cfg.Registry.LeaseTTL).
+			Build()
+	}, startupStrategy(cfg.Startup.Retry, 0))
 
-	r, err := etcd.NewBuilder(etcdClient).
-		LeaseTTL(cfg.LeaseTTL).
-		Build()
+	timeout := time.Duration(cfg.Etcd.DialTimeout) * time.Millisecond
+	err := connectWithRetry(cfg.Startup, logger, "registry", timeout, func(ctx context.Context) error {
+		select {
+		case <-r.Ready():
+			return nil
+		case <-ctx.Done():
+			if err := r.Err(); err != nil {
+				return err
+			}
+			return ctx.Err()
+		}
+	})
 	if err != nil {
+		_ = r.Close()
 		panic(err)
 	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return r.Close()
+		},
+	})
 	return r
 }
@@ -15,6 +15,26 @@ var RepoFxOpt = fx.Module(
 			dao.NewUserDAO,
 			fx.As(new(dao.UserDao)),
 		),
+		// audit log dao
+		fx.Annotate(
+			dao.NewAuditLogDao,
+			fx.As(new(dao.AuditLogDao)),
+		),
+		// biz config version dao
+		fx.Annotate(
+			dao.NewBizConfigVersionDao,
+			fx.As(new(dao.BizConfigVersionDao)),
+		),
+		// biz config preset dao
+		fx.Annotate(
+			dao.NewBizConfigPresetDao,
+			fx.As(new(dao.BizConfigPresetDao)),
+		),
+		// quota alert dao
+		fx.Annotate(
+			dao.NewQuotaAlertDao,
+			fx.As(new(dao.QuotaAlertDao)),
+		),
 	),
 	// cache
 
@@ -25,5 +45,25 @@ var RepoFxOpt = fx.Module(
 			repository.NewUserRepo,
 			fx.As(new(repository.UserRepo)),
 		),
+		// audit log repo
+		fx.Annotate(
+			repository.NewAuditLogRepo,
+			fx.As(new(repository.AuditLogRepo)),
+		),
+		// biz config version repo
+		fx.Annotate(
+			repository.NewBizConfigVersionRepo,
+			fx.As(new(repository.BizConfigVersionRepo)),
+		),
+		// biz config preset repo
+		fx.Annotate(
+			repository.NewBizConfigPresetRepo,
+			fx.As(new(repository.BizConfigPresetRepo)),
+		),
+		// quota alert repo
+		fx.Annotate(
+			repository.NewQuotaAlertRepo,
+			fx.As(new(repository.QuotaAlertRepo)),
+		),
 	),
 )
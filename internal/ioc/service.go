@@ -1,18 +1,25 @@
 package ioc
 
 import (
-	"github.com/JrMarcco/easy-grpc/client"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
 	"github.com/JrMarcco/kuryr-admin/internal/pkg/secret"
 	"github.com/JrMarcco/kuryr-admin/internal/pkg/secret/passwd"
 	"github.com/JrMarcco/kuryr-admin/internal/repository"
 	"github.com/JrMarcco/kuryr-admin/internal/service"
 	businessv1 "github.com/JrMarcco/kuryr-api/api/go/business/v1"
 	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	notificationv1 "github.com/JrMarcco/kuryr-api/api/go/notification/v1"
 	providerv1 "github.com/JrMarcco/kuryr-api/api/go/provider/v1"
 	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
-	"github.com/spf13/viper"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 var ServiceFxOpt = fx.Module(
@@ -35,11 +42,44 @@ var ServiceFxOpt = fx.Module(
 		),
 
 		// biz config service
+		fx.Annotate(
+			service.NewDefaultBizConfigValidator,
+			fx.As(new(service.BizConfigValidator)),
+		),
 		fx.Annotate(
 			InitBizConfigService,
 			fx.As(new(service.BizConfigService)),
 		),
 
+		// biz config preset service
+		fx.Annotate(
+			service.NewDefaultBizConfigPresetService,
+			fx.As(new(service.BizConfigPresetService)),
+		),
+
+		// callback probe service
+		fx.Annotate(
+			InitCallbackProbeService,
+			fx.As(new(service.CallbackProbeService)),
+		),
+
+		// quota usage service
+		fx.Annotate(
+			service.NewUnavailableQuotaUsageSource,
+			fx.As(new(service.QuotaUsageSource)),
+		),
+		fx.Annotate(
+			service.NewDefaultQuotaUsageService,
+			fx.As(new(service.QuotaUsageService)),
+		),
+
+		// quota alert service
+		InitQuotaAlertNotifier,
+		fx.Annotate(
+			service.NewDefaultQuotaAlertService,
+			fx.As(new(service.QuotaAlertService)),
+		),
+
 		// provider service
 		fx.Annotate(
 			InitProviderService,
@@ -51,42 +91,72 @@ var ServiceFxOpt = fx.Module(
 			InitTemplateService,
 			fx.As(new(service.TemplateService)),
 		),
+
+		// audit service
+		fx.Annotate(
+			service.NewDefaultAuditService,
+			fx.As(new(service.AuditService)),
+		),
 	),
 )
 
-func grpcServerName() string {
-	var name string
-	if err := viper.UnmarshalKey("grpc.server.name", &name); err != nil {
-		panic(err)
-	}
-	return name
-}
-
 func InitBizInfoService(
-	grpcClients *client.Manager[businessv1.BusinessServiceClient],
+	grpcClients *pkggrpc.Manager[businessv1.BusinessServiceClient],
 	userRepo repository.UserRepo,
+	presetSvc service.BizConfigPresetService,
 	passwdGenerator secret.Generator,
 	logger *zap.Logger,
+	cfg *config.Config,
 ) *service.DefaultBizService {
 	return service.NewDefaultBizService(
-		grpcServerName(), grpcClients, userRepo, passwdGenerator, logger,
+		cfg.Grpc.Server.Name, grpcClients, userRepo, presetSvc, passwdGenerator, logger,
 	)
 }
 
-func InitBizConfigService(grpcClients *client.Manager[configv1.BizConfigServiceClient]) *service.DefaultBizConfigService {
+func InitBizConfigService(
+	grpcClients *pkggrpc.Manager[configv1.BizConfigServiceClient],
+	validator service.BizConfigValidator,
+	versionRepo repository.BizConfigVersionRepo,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *service.DefaultBizConfigService {
 	return service.NewDefaultBizConfigService(
-		grpcServerName(), grpcClients,
+		cfg.Grpc.Server.Name, grpcClients, validator, versionRepo, logger,
 	)
 }
 
-func InitProviderService(grpcClients *client.Manager[providerv1.ProviderServiceClient]) *service.DefaultProviderService {
+// InitCallbackProbeService 回调服务与 kuryr 使用相同的 tls 配置，
+// 不使用 grpc-dial-option 组内的连接配置，避免 fake 后端的 dialer 将探测请求转发到 fake 后端。
+func InitCallbackProbeService(r registry.Registry, logger *zap.Logger, cfg *config.Config) *service.DefaultCallbackProbeService {
+	creds := insecure.NewCredentials()
+	if cfg.Grpc.Client.TLS.Enabled {
+		creds = credentials.NewTLS(newTLSConfig(logger, "grpc", cfg.Grpc.Client.TLS))
+	}
+	return service.NewDefaultCallbackProbeService(
+		r, time.Duration(cfg.Health.Timeout)*time.Millisecond, grpc.WithTransportCredentials(creds),
+	)
+}
+
+// InitQuotaAlertNotifier 按配置选择配额告警的发送方式
+func InitQuotaAlertNotifier(
+	grpcClients *pkggrpc.Manager[notificationv1.NotificationServiceClient], logger *zap.Logger, cfg *config.Config,
+) service.QuotaAlertNotifier {
+	if cfg.QuotaAlert.Notifier == config.QuotaAlertNotifierKuryr {
+		return service.NewKuryrQuotaAlertNotifier(
+			cfg.Grpc.Server.Name, grpcClients, cfg.QuotaAlert.Kuryr.BizKey, cfg.QuotaAlert.Kuryr.TplId,
+		)
+	}
+	return service.NewLogQuotaAlertNotifier(logger)
+}
+
+func InitProviderService(grpcClients *pkggrpc.Manager[providerv1.ProviderServiceClient], cfg *config.Config) *service.DefaultProviderService {
 	return service.NewDefaultProviderService(
-		grpcServerName(), grpcClients,
+		cfg.Grpc.Server.Name, grpcClients,
 	)
 }
 
-func InitTemplateService(grpcClients *client.Manager[templatev1.TemplateServiceClient]) *service.DefaultTemplateService {
+func InitTemplateService(grpcClients *pkggrpc.Manager[templatev1.TemplateServiceClient], cfg *config.Config) *service.DefaultTemplateService {
 	return service.NewDefaultTemplateService(
-		grpcServerName(), grpcClients,
+		cfg.Grpc.Server.Name, grpcClients,
 	)
 }
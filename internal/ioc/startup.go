@@ -0,0 +1,61 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JrMarcco/easy-kit/retry"
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"go.uber.org/zap"
+)
+
+// startupStrategy 创建启动阶段依赖连接的重试策略，maxTimes 不大于 0 时一直重试
+func startupStrategy(cfg config.StartupRetryConfig, maxTimes int32) retry.Strategy {
+	strategy, err := retry.NewExponentialBackoffStrategy(
+		time.Duration(cfg.InitialInterval)*time.Millisecond,
+		time.Duration(cfg.MaxInterval)*time.Millisecond,
+		maxTimes,
+	)
+	if err != nil {
+		panic(err)
+	}
+	return strategy
+}
+
+// connectWithRetry 按指数退避策略检查依赖是否可用。
+// 重试耗尽后，降级模式下只记录日志并继续启动，否则返回错误。
+func connectWithRetry(
+	cfg config.StartupConfig, logger *zap.Logger, name string, timeout time.Duration, check func(ctx context.Context) error,
+) error {
+	attempt := 0
+	err := retry.Retry(context.Background(), startupStrategy(cfg.Retry, cfg.Retry.MaxTimes), func() error {
+		attempt++
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := check(ctx); err != nil {
+			logger.Warn("[kuryr-admin] dependency is not available, retrying",
+				zap.String("dependency", name),
+				zap.Int("attempt", attempt),
+				zap.Error(err),
+			)
+			return err
+		}
+		return nil
+	})
+	if err == nil {
+		logger.Info("[kuryr-admin] successfully connected to dependency", zap.String("dependency", name))
+		return nil
+	}
+
+	if cfg.Degraded {
+		logger.Error("[kuryr-admin] dependency is not available, starting in degraded mode",
+			zap.String("dependency", name),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return fmt.Errorf("[kuryr-admin] failed to connect to %s: %w", name, err)
+}
@@ -0,0 +1,79 @@
+package ioc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"go.uber.org/zap"
+)
+
+// newTLSConfig 根据配置创建客户端 tls 配置，etcd 与 grpc 客户端共用。
+// name 为使用方名称，只用于日志。
+func newTLSConfig(logger *zap.Logger, name string, cfg config.TLSConfig) *tls.Config {
+	tlsCfg := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+		CipherSuites: []uint16{
+			// 支持现代密码套件，包括 Ed25519
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		},
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			logger.Error("[kuryr] failed to load Ed25519 client certificate",
+				zap.String("client", name),
+				zap.String("cert_file", cfg.CertFile),
+				zap.String("key_file", cfg.KeyFile),
+				zap.Error(err),
+			)
+			panic(fmt.Errorf("failed to load %s client certificate: %w", name, err))
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+
+		// 检查证书的公钥算法
+		if len(cert.Certificate) > 0 {
+			parsedCert, err := x509.ParseCertificate(cert.Certificate[0])
+			if err == nil {
+				logger.Info("[kuryr] client certificate loaded successfully",
+					zap.String("client", name),
+					zap.String("public_key_algorithm", parsedCert.PublicKeyAlgorithm.String()),
+					zap.String("signature_algorithm", parsedCert.SignatureAlgorithm.String()))
+			}
+		}
+	}
+
+	// 加载CA证书
+	if cfg.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			logger.Error("[kuryr] failed to load CA certificate",
+				zap.String("client", name),
+				zap.String("ca_file", cfg.CAFile),
+				zap.Error(err),
+			)
+			panic(fmt.Errorf("failed to load %s CA certificate: %w", name, err))
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			logger.Error("[kuryr] failed to parse CA certificate", zap.String("client", name))
+			panic(fmt.Errorf("failed to parse %s CA certificate", name))
+		}
+		tlsCfg.RootCAs = caCertPool
+		logger.Info("[kuryr] CA certificate loaded successfully", zap.String("client", name))
+	}
+
+	logger.Info("[kuryr] TLS configuration enabled with Ed25519 support", zap.String("client", name))
+	return tlsCfg
+}
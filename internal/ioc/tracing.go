@@ -0,0 +1,107 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var TracingFxOpt = fx.Module("tracing", fx.Provide(InitTracerProvider))
+
+// InitTracerProvider 初始化 trace.TracerProvider。
+// 未配置 exporter 或 exporter 为 none 时使用 noop 实现，不产生任何开销。
+func InitTracerProvider(lc fx.Lifecycle, logger *zap.Logger, appCfg *config.Config) trace.TracerProvider {
+	cfg := appCfg.Tracing
+
+	// 无论是否启用链路追踪都设置 propagator，保证上游传入的链路上下文可以继续向下游透传
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Exporter == "" || cfg.Exporter == config.TracingExporterNone {
+		tp := noop.NewTracerProvider()
+		otel.SetTracerProvider(tp)
+		return tp
+	}
+
+	exporter, closer, err := newSpanExporter(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		panic(err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	logger.Info("[kuryr-admin] tracing enabled", zap.String("exporter", cfg.Exporter))
+
+	lc.Append(fx.Hook{
+		// 程序停止时 flush 未导出的 span
+		OnStop: func(ctx context.Context) error {
+			err := tp.Shutdown(ctx)
+			if closer != nil {
+				_ = closer.Close()
+			}
+			return err
+		},
+	})
+	return tp
+}
+
+func newSpanExporter(cfg config.TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case config.TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case config.TracingExporterFile:
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0o755); err != nil {
+			return nil, nil, err
+		}
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	case config.TracingExporterOtlp:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Otlp.Endpoint)}
+		if cfg.Otlp.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		// otlptracegrpc 延迟建立连接，这里不会阻塞启动
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		return exporter, nil, err
+	default:
+		return nil, nil, fmt.Errorf("[kuryr-admin] unknown tracing exporter: %s", cfg.Exporter)
+	}
+}
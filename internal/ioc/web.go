@@ -3,11 +3,11 @@ package ioc
 import (
 	"time"
 
+	"github.com/JrMarcco/kuryr-admin/internal/config"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
 	"github.com/JrMarcco/kuryr-admin/internal/web"
 	ijwt "github.com/JrMarcco/kuryr-admin/internal/web/jwt"
 	"github.com/redis/go-redis/v9"
-	"github.com/spf13/viper"
 	"go.uber.org/fx"
 )
 
@@ -39,6 +39,19 @@ var HandlerFxOpt = fx.Module(
 			fx.As(new(pkggin.RouteRegistry)),
 			fx.ResultTags(`group:"handler"`),
 		),
+		// biz config preset handler
+		fx.Annotate(
+			web.NewBizConfigPresetHandler,
+			fx.As(new(pkggin.RouteRegistry)),
+			fx.ResultTags(`group:"handler"`),
+		),
+
+		// quota handler
+		fx.Annotate(
+			web.NewQuotaHandler,
+			fx.As(new(pkggin.RouteRegistry)),
+			fx.ResultTags(`group:"handler"`),
+		),
 
 		// provider handler
 		fx.Annotate(
@@ -53,13 +66,23 @@ var HandlerFxOpt = fx.Module(
 			fx.As(new(pkggin.RouteRegistry)),
 			fx.ResultTags(`group:"handler"`),
 		),
+
+		// audit handler
+		fx.Annotate(
+			web.NewAuditHandler,
+			fx.As(new(pkggin.RouteRegistry)),
+			fx.ResultTags(`group:"handler"`),
+		),
+
+		// metrics handler
+		fx.Annotate(
+			web.NewMetricsHandler,
+			fx.As(new(pkggin.RouteRegistry)),
+			fx.ResultTags(`group:"handler"`),
+		),
 	),
 )
 
-func InitRedisJwtHandler(rc redis.Cmdable) ijwt.Handler {
-	var expiration int
-	if err := viper.UnmarshalKey("session.expiration", &expiration); err != nil {
-		panic(err)
-	}
-	return ijwt.NewRedisHandler(rc, time.Duration(expiration)*time.Second)
+func InitRedisJwtHandler(rc redis.Cmdable, cfg *config.Config) ijwt.Handler {
+	return ijwt.NewRedisHandler(rc, time.Duration(cfg.Session.Expiration)*time.Second)
 }
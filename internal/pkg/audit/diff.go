@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Redacted 脱敏字段的替换值。
+const Redacted = "******"
+
+// Change 字段变更前后的值，字段不存在时为 nil。
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Diff 对比变更前后的对象，返回发生变更的字段。
+// key 为 json 字段路径（例如 quota_config.daily.sms），before 或 after 为 nil 时表示新建或删除。
+//
+// 结构体字段使用以下 tag 控制脱敏：
+//
+//	secret:"true" 只记录字段是否变更，不记录原值
+func Diff(before, after any) map[string]Change {
+	bf := make(map[string]leaf)
+	flatten(reflect.ValueOf(before), "", false, bf)
+	af := make(map[string]leaf)
+	flatten(reflect.ValueOf(after), "", false, af)
+
+	changes := make(map[string]Change)
+	for key, b := range bf {
+		a, ok := af[key]
+		if !ok {
+			changes[key] = Change{Before: b.redacted()}
+			continue
+		}
+		if !reflect.DeepEqual(b.val, a.val) {
+			changes[key] = Change{Before: b.redacted(), After: a.redacted()}
+		}
+	}
+	for key, a := range af {
+		if _, ok := bf[key]; !ok {
+			changes[key] = Change{After: a.redacted()}
+		}
+	}
+	return changes
+}
+
+type leaf struct {
+	val    any
+	secret bool
+}
+
+func (l leaf) redacted() any {
+	if !l.secret || reflect.ValueOf(l.val).IsZero() {
+		return l.val
+	}
+	return Redacted
+}
+
+// flatten 将结构体按 json 字段路径展开，切片与 map 作为整体对比。
+func flatten(val reflect.Value, prefix string, secret bool, res map[string]leaf) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return
+	}
+
+	if val.Kind() != reflect.Struct || secret {
+		res[prefix] = leaf{val: val.Interface(), secret: secret}
+		return
+	}
+
+	typ := val.Type()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		flatten(val.Field(i), name, field.Tag.Get("secret") == "true", res)
+	}
+}
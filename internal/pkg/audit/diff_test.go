@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type credential struct {
+	Key    string `json:"key"`
+	Secret string `json:"secret" secret:"true"`
+}
+
+type quota struct {
+	Sms   int32 `json:"sms"`
+	Email int32 `json:"email"`
+}
+
+type target struct {
+	Id         uint64      `json:"id"`
+	Name       string      `json:"name"`
+	Tags       []string    `json:"tags"`
+	Quota      *quota      `json:"quota"`
+	Credential credential  `json:"credential"`
+	Ignored    string      `json:"-"`
+	Nested     *credential `json:"nested,omitempty"`
+	unexported string
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name   string
+		before any
+		after  any
+		want   map[string]Change
+	}{
+		{
+			name:   "no change",
+			before: target{Id: 1, Name: "a", Quota: &quota{Sms: 1}},
+			after:  &target{Id: 1, Name: "a", Quota: &quota{Sms: 1}, Ignored: "x", unexported: "y"},
+			want:   map[string]Change{},
+		}, {
+			name:   "create",
+			before: nil,
+			after:  target{Id: 1, Name: "a", Credential: credential{Key: "k", Secret: "s"}},
+			want: map[string]Change{
+				"id":                {After: uint64(1)},
+				"name":              {After: "a"},
+				"tags":              {After: []string(nil)},
+				"credential.key":    {After: "k"},
+				"credential.secret": {After: Redacted},
+			},
+		}, {
+			name:   "delete",
+			before: &target{Name: "a", Quota: &quota{Email: 2}},
+			after:  nil,
+			want: map[string]Change{
+				"id":                {Before: uint64(0)},
+				"name":              {Before: "a"},
+				"tags":              {Before: []string(nil)},
+				"quota.sms":         {Before: int32(0)},
+				"quota.email":       {Before: int32(2)},
+				"credential.key":    {Before: ""},
+				"credential.secret": {Before: ""},
+			},
+		}, {
+			name:   "update",
+			before: target{Name: "a", Tags: []string{"x"}, Quota: &quota{Sms: 1, Email: 2}},
+			after:  target{Name: "b", Tags: []string{"x", "y"}, Quota: &quota{Sms: 1, Email: 3}},
+			want: map[string]Change{
+				"name":        {Before: "a", After: "b"},
+				"tags":        {Before: []string{"x"}, After: []string{"x", "y"}},
+				"quota.email": {Before: int32(2), After: int32(3)},
+			},
+		}, {
+			name:   "secret changed",
+			before: target{Credential: credential{Key: "k", Secret: "old"}},
+			after:  target{Credential: credential{Key: "k", Secret: "new"}},
+			want: map[string]Change{
+				"credential.secret": {Before: Redacted, After: Redacted},
+			},
+		}, {
+			name:   "secret cleared",
+			before: target{Credential: credential{Secret: "old"}},
+			after:  target{},
+			want: map[string]Change{
+				"credential.secret": {Before: Redacted, After: ""},
+			},
+		}, {
+			name:   "nested pointer set",
+			before: target{},
+			after:  target{Nested: &credential{Key: "k", Secret: "s"}},
+			want: map[string]Change{
+				"nested.key":    {After: "k"},
+				"nested.secret": {After: Redacted},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, Diff(tc.before, tc.after))
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("[kuryr-admin] circuit breaker is open")
+
+// State 熔断器状态
+type State int32
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config 熔断器配置
+type Config struct {
+	// FailureThreshold 连续失败多少次后熔断
+	FailureThreshold int
+	// OpenTimeout 熔断持续时间，之后进入半开状态放行一个探测请求
+	OpenTimeout time.Duration
+}
+
+// Breaker 基于连续失败次数的熔断器。
+//
+//	closed    正常放行，连续失败达到阈值后进入 open
+//	open      直接拒绝请求，经过 OpenTimeout 后进入 half_open
+//	half_open 只放行一个探测请求，成功则进入 closed，失败则重新进入 open
+type Breaker struct {
+	cfg           Config
+	now           func() time.Time
+	onStateChange func(from State, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+	// generation 每次状态变化时递增，忽略状态变化前放行的请求上报的结果
+	generation uint64
+}
+
+// Allow 判断请求是否放行。
+// 放行时返回的 done 必须在请求结束后调用，用于上报请求结果。
+func (b *Breaker) Allow() (done func(success bool), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+
+	switch b.state {
+	case StateOpen:
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.probing {
+			return nil, ErrOpen
+		}
+		b.probing = true
+	}
+
+	generation := b.generation
+	return func(success bool) {
+		b.report(generation, success)
+	}, nil
+}
+
+// State 返回熔断器当前状态。
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+	return b.state
+}
+
+func (b *Breaker) report(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+		if success {
+			b.setState(StateClosed)
+		} else {
+			b.open()
+		}
+		return
+	}
+
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateClosed && b.failures >= b.cfg.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.setState(StateOpen)
+}
+
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	b.generation++
+	b.failures = 0
+	b.probing = false
+	if b.onStateChange != nil {
+		b.onStateChange(from, state)
+	}
+}
+
+func NewBreaker(cfg Config, onStateChange func(from State, to State)) *Breaker {
+	return &Breaker{
+		cfg:           cfg,
+		now:           time.Now,
+		onStateChange: onStateChange,
+		state:         StateClosed,
+	}
+}
+
+// Group 按名称管理一组熔断器，熔断器在第一次使用时创建。
+type Group struct {
+	cfg           Config
+	onStateChange func(name string, from State, to State)
+
+	mu       sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// Get 返回指定名称的熔断器，不存在时创建。
+func (g *Group) Get(name string) *Breaker {
+	g.mu.RLock()
+	b, ok := g.breakers[name]
+	g.mu.RUnlock()
+	if ok {
+		return b
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if b, ok = g.breakers[name]; ok {
+		return b
+	}
+
+	b = NewBreaker(g.cfg, func(from State, to State) {
+		if g.onStateChange != nil {
+			g.onStateChange(name, from, to)
+		}
+	})
+	g.breakers[name] = b
+	return b
+}
+
+// States 返回所有熔断器的当前状态。
+func (g *Group) States() map[string]State {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	res := make(map[string]State, len(g.breakers))
+	for name, b := range g.breakers {
+		res[name] = b.State()
+	}
+	return res
+}
+
+func NewGroup(cfg Config, onStateChange func(name string, from State, to State)) *Group {
+	return &Group{
+		cfg:           cfg,
+		onStateChange: onStateChange,
+		breakers:      make(map[string]*Breaker),
+	}
+}
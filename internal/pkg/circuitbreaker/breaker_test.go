@@ -0,0 +1,113 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transition struct {
+	from State
+	to   State
+}
+
+func newTestBreaker(threshold int) (*Breaker, *time.Time, *[]transition) {
+	now := time.Now()
+	var transitions []transition
+	b := NewBreaker(Config{FailureThreshold: threshold, OpenTimeout: time.Second}, func(from State, to State) {
+		transitions = append(transitions, transition{from: from, to: to})
+	})
+	b.now = func() time.Time { return now }
+	return b, &now, &transitions
+}
+
+func call(t *testing.T, b *Breaker, success bool) {
+	t.Helper()
+
+	done, err := b.Allow()
+	require.NoError(t, err)
+	done(success)
+}
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	b, now, transitions := newTestBreaker(3)
+
+	// 成功会重置连续失败次数
+	call(t, b, false)
+	call(t, b, false)
+	call(t, b, true)
+	call(t, b, false)
+	call(t, b, false)
+	assert.Equal(t, StateClosed, b.State())
+
+	call(t, b, false)
+	assert.Equal(t, StateOpen, b.State())
+	_, err := b.Allow()
+	assert.ErrorIs(t, err, ErrOpen)
+
+	// 熔断时间结束后只放行一个探测请求
+	*now = now.Add(time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+	probe, err := b.Allow()
+	require.NoError(t, err)
+	_, err = b.Allow()
+	assert.ErrorIs(t, err, ErrOpen)
+
+	// 探测失败重新熔断
+	probe(false)
+	assert.Equal(t, StateOpen, b.State())
+
+	// 探测成功恢复
+	*now = now.Add(time.Second)
+	call(t, b, true)
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.Equal(t, []transition{
+		{from: StateClosed, to: StateOpen},
+		{from: StateOpen, to: StateHalfOpen},
+		{from: StateHalfOpen, to: StateOpen},
+		{from: StateOpen, to: StateHalfOpen},
+		{from: StateHalfOpen, to: StateClosed},
+	}, *transitions)
+}
+
+func TestBreaker_StaleReport(t *testing.T) {
+	t.Parallel()
+
+	b, now, _ := newTestBreaker(1)
+
+	// 熔断前放行的请求在恢复后才返回，结果不影响新的状态
+	stale, err := b.Allow()
+	require.NoError(t, err)
+	call(t, b, false)
+	assert.Equal(t, StateOpen, b.State())
+
+	*now = now.Add(time.Second)
+	call(t, b, true)
+	assert.Equal(t, StateClosed, b.State())
+
+	stale(false)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	var changed []string
+	g := NewGroup(Config{FailureThreshold: 1, OpenTimeout: time.Minute}, func(name string, _ State, to State) {
+		changed = append(changed, name+":"+to.String())
+	})
+
+	assert.Same(t, g.Get("a"), g.Get("a"))
+
+	done, err := g.Get("b").Allow()
+	require.NoError(t, err)
+	done(false)
+
+	assert.Equal(t, map[string]State{"a": StateClosed, "b": StateOpen}, g.States())
+	assert.Equal(t, []string{"b:open"}, changed)
+}
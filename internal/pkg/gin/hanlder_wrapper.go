@@ -14,12 +14,12 @@ func W(bizFunc func(*gin.Context) (R, error)) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		r, err := bizFunc(ctx)
 		if errors.Is(err, errs.ErrUnauthorized) {
-			slog.Debug("unauthorized", slog.Any("err", err))
+			slog.DebugContext(ctx, "unauthorized", slog.Any("err", err))
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -35,18 +35,18 @@ func B[Req any](bizFunc func(*gin.Context, Req) (R, error)) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req Req
 		if err := ctx.BindJSON(&req); err != nil {
-			slog.Error("failed to bind request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to bind request", slog.Any("err", err))
 			return
 		}
 
 		r, err := bizFunc(ctx, req)
 		if errors.Is(err, errs.ErrUnauthorized) {
-			slog.Debug("unauthorized", slog.Any("err", err))
+			slog.DebugContext(ctx, "unauthorized", slog.Any("err", err))
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -62,18 +62,18 @@ func Q[Req any](bizFunc func(*gin.Context, Req) (R, error)) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req Req
 		if err := ctx.BindQuery(&req); err != nil {
-			slog.Error("failed to bind request from query", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to bind request from query", slog.Any("err", err))
 			return
 		}
 
 		r, err := bizFunc(ctx, req)
 		if errors.Is(err, errs.ErrUnauthorized) {
-			slog.Debug("unauthorized", slog.Any("err", err))
+			slog.DebugContext(ctx, "unauthorized", slog.Any("err", err))
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -89,18 +89,18 @@ func P[Req any](bizFunc func(*gin.Context, Req) (R, error)) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req Req
 		if err := ctx.BindUri(&req); err != nil {
-			slog.Error("failed to bind request from uri", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to bind request from uri", slog.Any("err", err))
 			return
 		}
 
 		r, err := bizFunc(ctx, req)
 		if errors.Is(err, errs.ErrUnauthorized) {
-			slog.Debug("unauthorized", slog.Any("err", err))
+			slog.DebugContext(ctx, "unauthorized", slog.Any("err", err))
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -116,7 +116,7 @@ func WU(bizFunc func(*gin.Context, AuthUser) (R, error)) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		rawVal, ok := ctx.Get(ContextKeyAuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
@@ -124,19 +124,19 @@ func WU(bizFunc func(*gin.Context, AuthUser) (R, error)) gin.HandlerFunc {
 		// 注意 gin.Context 内的值不能是 *AuthUser
 		au, ok := rawVal.(AuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
 		r, err := bizFunc(ctx, au)
 		if errors.Is(err, errs.ErrUnauthorized) {
-			slog.Debug("unauthorized", slog.Any("err", err))
+			slog.DebugContext(ctx, "unauthorized", slog.Any("err", err))
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -152,12 +152,12 @@ func BU[Req any](bizFunc func(*gin.Context, Req, AuthUser) (R, error)) gin.Handl
 	return func(ctx *gin.Context) {
 		var req Req
 		if err := ctx.BindJSON(&req); err != nil {
-			slog.Error("failed to bind request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to bind request", slog.Any("err", err))
 			return
 		}
 		rawVal, ok := ctx.Get(ContextKeyAuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
@@ -165,14 +165,14 @@ func BU[Req any](bizFunc func(*gin.Context, Req, AuthUser) (R, error)) gin.Handl
 		// 注意 gin.Context 内的值不能是 *AuthUser
 		au, ok := rawVal.(AuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
 		r, err := bizFunc(ctx, req, au)
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -188,12 +188,12 @@ func QU[Req any](bizFunc func(*gin.Context, Req, AuthUser) (R, error)) gin.Handl
 	return func(ctx *gin.Context) {
 		var req Req
 		if err := ctx.BindQuery(&req); err != nil {
-			slog.Error("failed to bind request from query", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to bind request from query", slog.Any("err", err))
 			return
 		}
 		rawVal, ok := ctx.Get(ContextKeyAuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
@@ -201,14 +201,14 @@ func QU[Req any](bizFunc func(*gin.Context, Req, AuthUser) (R, error)) gin.Handl
 		// 注意 gin.Context 内的值不能是 *AuthUser
 		au, ok := rawVal.(AuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
 		r, err := bizFunc(ctx, req, au)
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -224,12 +224,12 @@ func PU[Req any](bizFunc func(*gin.Context, Req, AuthUser) (R, error)) gin.Handl
 	return func(ctx *gin.Context) {
 		var req Req
 		if err := ctx.BindUri(&req); err != nil {
-			slog.Error("failed to bind request from uri", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to bind request from uri", slog.Any("err", err))
 			return
 		}
 		rawVal, ok := ctx.Get(ContextKeyAuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
@@ -237,14 +237,14 @@ func PU[Req any](bizFunc func(*gin.Context, Req, AuthUser) (R, error)) gin.Handl
 		// 注意 gin.Context 内的值不能是 *AuthUser
 		au, ok := rawVal.(AuthUser)
 		if !ok {
-			slog.Error("failed to get auth user")
+			slog.ErrorContext(ctx, "failed to get auth user")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
 		r, err := bizFunc(ctx, req, au)
 		if err != nil {
-			slog.Error("failed to handle request", slog.Any("err", err))
+			slog.ErrorContext(ctx, "failed to handle request", slog.Any("err", err))
 			ctx.PureJSON(http.StatusInternalServerError, gin.H{
 				"code": http.StatusInternalServerError,
 				"msg":  err.Error(),
@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	eawsyjwt "github.com/JrMarcco/easy-kit/jwt"
-	"github.com/JrMarcco/easy-kit/set"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
 	ijwt "github.com/JrMarcco/kuryr-admin/internal/web/jwt"
 	"github.com/gin-gonic/gin"
 )
@@ -15,12 +15,12 @@ var _ Builder = (*JwtBuilder)(nil)
 type JwtBuilder struct {
 	ijwt.Handler
 	atManager eawsyjwt.Manager[pkggin.AuthUser] // 这里是 access token manager
-	ignores   set.Set[string]
+	matcher   *route.Matcher                    // 判断请求是否可以跳过 token 校验
 }
 
 func (b *JwtBuilder) Build() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if b.ignores != nil && b.ignores.Exist(ctx.Request.URL.Path) {
+		if b.matcher.Skip(ctx.Request.Method, ctx.Request.URL.Path, ctx.FullPath()) {
 			ctx.Next()
 			return
 		}
@@ -54,11 +54,11 @@ func (b *JwtBuilder) Build() gin.HandlerFunc {
 }
 
 func NewJwtBuilder(
-	handler ijwt.Handler, atManager eawsyjwt.Manager[pkggin.AuthUser], ignores set.Set[string],
+	handler ijwt.Handler, atManager eawsyjwt.Manager[pkggin.AuthUser], matcher *route.Matcher,
 ) *JwtBuilder {
 	return &JwtBuilder{
 		Handler:   handler,
 		atManager: atManager,
-		ignores:   ignores,
+		matcher:   matcher,
 	}
 }
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ Builder = (*MetricsBuilder)(nil)
+
+// MetricsBuilder http 请求指标中间件，按路由模板与响应状态码统计请求耗时。
+type MetricsBuilder struct {
+	duration *prometheus.HistogramVec
+	inflight prometheus.Gauge
+}
+
+func (b *MetricsBuilder) Build() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		b.inflight.Inc()
+		defer b.inflight.Dec()
+
+		ctx.Next()
+
+		// 使用路由模板而不是真实路径，避免 label 基数爆炸
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		b.duration.WithLabelValues(
+			ctx.Request.Method,
+			route,
+			strconv.Itoa(ctx.Writer.Status()),
+		).Observe(time.Since(start).Seconds())
+	}
+}
+
+func NewMetricsBuilder(reg prometheus.Registerer) *MetricsBuilder {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	inflight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+
+	reg.MustRegister(duration, inflight)
+	return &MetricsBuilder{
+		duration: duration,
+		inflight: inflight,
+	}
+}
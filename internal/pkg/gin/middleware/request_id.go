@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/gin-gonic/gin"
+)
+
+var _ Builder = (*RequestIdBuilder)(nil)
+
+// requestIdRegexp 允许透传的 request id 字符集。
+// request id 会作为 grpc metadata 传给 kuryr，grpc metadata 只允许可打印的 ASCII 字符，
+// 否则该请求的所有 grpc 调用都会失败。
+var requestIdRegexp = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// RequestIdBuilder request id 中间件。
+// 优先使用请求头内的 X-Request-Id，不存在或不合法时生成新的 request id，
+// 并将其写入 context 和响应头，便于和 kuryr 的日志进行关联。
+type RequestIdBuilder struct {
+	maxLength int
+}
+
+func (b *RequestIdBuilder) Build() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(reqid.HeaderName)
+		if len(id) > b.maxLength || !requestIdRegexp.MatchString(id) {
+			id = reqid.New()
+		}
+
+		ctx.Request = ctx.Request.WithContext(reqid.WithContext(ctx.Request.Context(), id))
+		ctx.Header(reqid.HeaderName, id)
+		ctx.Next()
+	}
+}
+
+// MaxLength 设置允许透传的 request id 最大长度，超出时重新生成。
+func (b *RequestIdBuilder) MaxLength(maxLength int) *RequestIdBuilder {
+	b.maxLength = maxLength
+	return b
+}
+
+func NewRequestIdBuilder() *RequestIdBuilder {
+	return &RequestIdBuilder{
+		maxLength: 128,
+	}
+}
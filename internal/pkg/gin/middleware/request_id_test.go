@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIdBuilder(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string
+		header   string
+		wantKeep bool
+	}{
+		{name: "missing"},
+		{name: "uuid", header: "9f0c1d2e-3b4a-4c5d-8e6f-7a8b9c0d1e2f", wantKeep: true},
+		{name: "token chars", header: "svc.gw:req_01-A", wantKeep: true},
+		{name: "too long", header: strings.Repeat("a", 129)},
+		{name: "non ascii", header: "请求-1"},
+		{name: "control char", header: "req\x01id"},
+		{name: "space", header: "req id"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			engine := gin.New()
+			engine.Use(NewRequestIdBuilder().Build())
+			engine.GET("/", func(ctx *gin.Context) {
+				got, _ = reqid.FromContext(ctx.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header[reqid.HeaderName] = []string{tc.header}
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.NotEmpty(t, got)
+			assert.Equal(t, got, w.Header().Get(reqid.HeaderName))
+			if tc.wantKeep {
+				assert.Equal(t, tc.header, got)
+				return
+			}
+			_, err := uuid.Parse(got)
+			assert.NoError(t, err)
+		})
+	}
+}
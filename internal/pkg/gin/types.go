@@ -2,6 +2,7 @@ package gin
 
 import (
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,6 +17,13 @@ type RouteRegistry interface {
 	RegisterRoutes(engine *gin.Engine)
 }
 
+// PublicRouteRegistry 声明公开路由的路由注册器。
+// 公开路由不需要校验 token，路由注册时声明，Path 需要和注册时的完整路径一致。
+type PublicRouteRegistry interface {
+	RouteRegistry
+	PublicRoutes() []route.Route
+}
+
 // R 接口统一返回
 type R struct {
 	Code int    `json:"code"` // 使用 http.StatusXxx
@@ -29,3 +37,15 @@ type AuthUser struct {
 	Uid      uint64          `json:"uid"`       // user id
 	UserType domain.UserType `json:"user_type"` // user type
 }
+
+// AuthUserFromContext 获取 jwt 中间件写入 gin.Context 的登录用户信息。
+func AuthUserFromContext(ctx *gin.Context) (AuthUser, bool) {
+	rawVal, ok := ctx.Get(ContextKeyAuthUser)
+	if !ok {
+		return AuthUser{}, false
+	}
+
+	// 注意 gin.Context 内的值不能是 *AuthUser
+	au, ok := rawVal.(AuthUser)
+	return au, ok
+}
@@ -0,0 +1,34 @@
+package gorm
+
+import "gorm.io/gorm"
+
+// registerCallbacks 在 gorm 内置的 create / query / update / delete / row / raw 回调前后注册插件回调。
+// after 接收操作类型，用于为指标、链路等数据打标签。
+func registerCallbacks(db *gorm.DB, name string, before func(*gorm.DB), after func(operation string) func(*gorm.DB)) error {
+	cb := db.Callback()
+
+	type registrar struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}
+
+	registrars := []registrar{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, r := range registrars {
+		if err := r.before(name+":before_"+r.operation, before); err != nil {
+			return err
+		}
+		if err := r.after(name+":after_"+r.operation, after(r.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const metricsStartKey = "kuryr:metrics:start"
+
+var _ gorm.Plugin = (*MetricsPlugin)(nil)
+
+// MetricsPlugin gorm 查询指标插件，按操作类型与表名统计 sql 耗时。
+type MetricsPlugin struct {
+	duration *prometheus.HistogramVec
+}
+
+func (p *MetricsPlugin) Name() string {
+	return "kuryr:metrics"
+}
+
+func (p *MetricsPlugin) Initialize(db *gorm.DB) error {
+	return registerCallbacks(db, p.Name(), p.before, p.after)
+}
+
+func (p *MetricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p *MetricsPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		val, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := val.(time.Time)
+		if !ok {
+			return
+		}
+
+		result := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			result = "error"
+		}
+
+		p.duration.WithLabelValues(operation, db.Statement.Table, result).Observe(time.Since(start).Seconds())
+	}
+}
+
+func NewMetricsPlugin(reg prometheus.Registerer) *MetricsPlugin {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "SQL statement latency by operation, table and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "table", "result"})
+
+	reg.MustRegister(duration)
+	return &MetricsPlugin{duration: duration}
+}
@@ -0,0 +1,76 @@
+package gorm
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracerName     = "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	tracingSpanKey = "kuryr:tracing:span"
+)
+
+var _ gorm.Plugin = (*TracingPlugin)(nil)
+
+// TracingPlugin gorm 链路追踪插件，为每条 sql 语句创建一个 span。
+type TracingPlugin struct {
+	tracer trace.Tracer
+}
+
+func (p *TracingPlugin) Name() string {
+	return "kuryr:tracing"
+}
+
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	return registerCallbacks(db, p.Name(), p.before, p.after)
+}
+
+func (p *TracingPlugin) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		return
+	}
+
+	ctx, span := p.tracer.Start(ctx, "gorm", trace.WithSpanKind(trace.SpanKindClient))
+	db.Statement.Context = ctx
+	db.InstanceSet(tracingSpanKey, span)
+}
+
+func (p *TracingPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		val, ok := db.InstanceGet(tracingSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := val.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		span.SetName("gorm." + operation + " " + db.Statement.Table)
+		span.SetAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBCollectionName(db.Statement.Table),
+			semconv.DBQueryText(db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+		)
+
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
+
+func NewTracingPlugin(tp trace.TracerProvider) *TracingPlugin {
+	return &TracingPlugin{
+		tracer: tp.Tracer(tracerName),
+	}
+}
@@ -3,9 +3,11 @@ package gorm
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/JrMarcco/easy-kit/bean/option"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -13,45 +15,64 @@ import (
 
 var _ logger.Interface = (*ZapLogger)(nil)
 
-// ZapLogger gorm logger 的 zap 装饰器
+// ZapLogger gorm logger 的 zap 装饰器。
+// 日志级别和慢查询阈值支持运行时修改。
 type ZapLogger struct {
 	zLogger                   *zap.Logger
-	logLevel                  logger.LogLevel
-	slowThreshold             time.Duration
+	logLevel                  *atomic.Int32
+	slowThreshold             *atomic.Int64
 	ignoreRecordNotFoundError bool
 }
 
+// LogMode 返回使用指定日志级别的新 logger，慢查询阈值与原 logger 共享。
 func (zl *ZapLogger) LogMode(level logger.LogLevel) logger.Interface {
 	newLogger := *zl
-	newLogger.logLevel = level
+	newLogger.logLevel = &atomic.Int32{}
+	newLogger.logLevel.Store(int32(level))
 	return &newLogger
 }
 
-func (zl *ZapLogger) Info(_ context.Context, str string, args ...interface{}) {
-	if zl.logLevel < logger.Info {
+// SetLogLevel 修改日志级别。
+func (zl *ZapLogger) SetLogLevel(level logger.LogLevel) {
+	zl.logLevel.Store(int32(level))
+}
+
+// SetSlowThreshold 修改慢查询阈值。
+func (zl *ZapLogger) SetSlowThreshold(threshold time.Duration) {
+	zl.slowThreshold.Store(int64(threshold))
+}
+
+func (zl *ZapLogger) level() logger.LogLevel {
+	return logger.LogLevel(zl.logLevel.Load())
+}
+
+func (zl *ZapLogger) Info(ctx context.Context, str string, args ...interface{}) {
+	if zl.level() < logger.Info {
 		return
 	}
-	zl.zLogger.Sugar().Infof(str, args...)
+	zl.zLogger.With(reqid.Field(ctx)).Sugar().Infof(str, args...)
 }
 
-func (zl *ZapLogger) Warn(_ context.Context, str string, args ...interface{}) {
-	if zl.logLevel < logger.Warn {
+func (zl *ZapLogger) Warn(ctx context.Context, str string, args ...interface{}) {
+	if zl.level() < logger.Warn {
 		return
 	}
-	zl.zLogger.Sugar().Warnf(str, args...)
+	zl.zLogger.With(reqid.Field(ctx)).Sugar().Warnf(str, args...)
 }
 
-func (zl *ZapLogger) Error(_ context.Context, str string, args ...interface{}) {
-	if zl.logLevel < logger.Error {
+func (zl *ZapLogger) Error(ctx context.Context, str string, args ...interface{}) {
+	if zl.level() < logger.Error {
 		return
 	}
-	zl.zLogger.Sugar().Errorf(str, args...)
+	zl.zLogger.With(reqid.Field(ctx)).Sugar().Errorf(str, args...)
 }
 
-func (zl *ZapLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
-	if zl.logLevel <= logger.Silent {
+func (zl *ZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	level := zl.level()
+	if level <= logger.Silent {
 		return
 	}
+	slowThreshold := time.Duration(zl.slowThreshold.Load())
 
 	elapsed := time.Since(begin)
 	sql, rows := fc()
@@ -60,14 +81,15 @@ func (zl *ZapLogger) Trace(_ context.Context, begin time.Time, fc func() (string
 		zap.String("sql", sql),
 		zap.Duration("elapsed", elapsed),
 		zap.Int64("rows", rows),
+		reqid.Field(ctx),
 	}
 
 	switch {
-	case err != nil && zl.logLevel >= logger.Error && (!zl.ignoreRecordNotFoundError || !errors.Is(err, gorm.ErrRecordNotFound)):
+	case err != nil && level >= logger.Error && (!zl.ignoreRecordNotFoundError || !errors.Is(err, gorm.ErrRecordNotFound)):
 		zl.zLogger.Error("gorm query error", append(fields, zap.Error(err))...)
-	case zl.slowThreshold != 0 && elapsed > zl.slowThreshold && zl.logLevel >= logger.Warn:
+	case slowThreshold != 0 && elapsed > slowThreshold && level >= logger.Warn:
 		zl.zLogger.Warn("gorm slow query", fields...)
-	case zl.logLevel >= logger.Info:
+	case level >= logger.Info:
 		zl.zLogger.Info("gorm query", fields...)
 	}
 }
@@ -75,10 +97,12 @@ func (zl *ZapLogger) Trace(_ context.Context, begin time.Time, fc func() (string
 func NewZapLogger(zLogger *zap.Logger, opts ...option.Opt[ZapLogger]) *ZapLogger {
 	zl := &ZapLogger{
 		zLogger:                   zLogger,
-		logLevel:                  logger.Warn,
-		slowThreshold:             100 * time.Millisecond,
+		logLevel:                  &atomic.Int32{},
+		slowThreshold:             &atomic.Int64{},
 		ignoreRecordNotFoundError: false,
 	}
+	zl.logLevel.Store(int32(logger.Warn))
+	zl.slowThreshold.Store(int64(100 * time.Millisecond))
 
 	option.Apply(zl, opts...)
 	return zl
@@ -86,13 +110,13 @@ func NewZapLogger(zLogger *zap.Logger, opts ...option.Opt[ZapLogger]) *ZapLogger
 
 func WithLogLevel(level logger.LogLevel) option.Opt[ZapLogger] {
 	return func(zl *ZapLogger) {
-		zl.logLevel = level
+		zl.logLevel.Store(int32(level))
 	}
 }
 
 func WithSlowThreshold(threshold time.Duration) option.Opt[ZapLogger] {
 	return func(zl *ZapLogger) {
-		zl.slowThreshold = threshold
+		zl.slowThreshold.Store(int64(threshold))
 	}
 }
 
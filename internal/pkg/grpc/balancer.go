@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/JrMarcco/easy-grpc/client"
+	"github.com/JrMarcco/easy-grpc/client/br"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+// 自定义负载均衡策略名称，轮询与 pick first 直接使用 grpc 内置的 round_robin 与 pick_first。
+const (
+	// BalancerRwWeight 基于读写权重的负载均衡
+	BalancerRwWeight = "kuryr_rw_weight"
+	// BalancerConsistentHash 按 biz id 一致性哈希，context 中没有 biz id 时退化为轮询
+	BalancerConsistentHash = "kuryr_consistent_hash"
+)
+
+var registerOnce sync.Once
+
+// RegisterBalancers 向 grpc 全局注册自定义负载均衡策略，重复调用只会注册一次。
+// balancer.Register 不是并发安全的，并且同名注册会直接覆盖，所以必须在创建任何连接之前调用。
+func RegisterBalancers() {
+	registerOnce.Do(func() {
+		balancer.Register(newPerConnBuilder(BalancerRwWeight, func() base.PickerBuilder {
+			return br.NewRwWeightBalancerBuilder()
+		}))
+		balancer.Register(newPerConnBuilder(BalancerConsistentHash, func() base.PickerBuilder {
+			return &bizHashPickerBuilder{
+				ch: br.NewCHBalancerBuilder(),
+				rr: &br.RoundRobinBalancerBuilder{},
+			}
+		}))
+	})
+}
+
+var _ balancer.Builder = (*perConnBuilder)(nil)
+
+// perConnBuilder 为每个 grpc.ClientConn 创建独立的 PickerBuilder。
+// br.RwWeightBalancerBuilder 按节点名缓存 SubConn，多个连接共享同一个 PickerBuilder 时会选中其他连接的 SubConn。
+type perConnBuilder struct {
+	name             string
+	newPickerBuilder func() base.PickerBuilder
+}
+
+func (b *perConnBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	return base.NewBalancerBuilder(b.name, b.newPickerBuilder(), base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+func (b *perConnBuilder) Name() string {
+	return b.name
+}
+
+func newPerConnBuilder(name string, newPickerBuilder func() base.PickerBuilder) *perConnBuilder {
+	return &perConnBuilder{
+		name:             name,
+		newPickerBuilder: newPickerBuilder,
+	}
+}
+
+var _ base.PickerBuilder = (*bizHashPickerBuilder)(nil)
+
+// bizHashPickerBuilder 按 biz id 一致性哈希选择节点。
+// br.CHBalancer 在 context 中没有 biz id 时直接返回错误，这里退化为轮询，避免不带 biz id 的请求失败。
+type bizHashPickerBuilder struct {
+	ch *br.CHBalancerBuilder
+	rr *br.RoundRobinBalancerBuilder
+}
+
+func (b *bizHashPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	return &bizHashPicker{
+		ch: b.ch.Build(info),
+		rr: b.rr.Build(info),
+	}
+}
+
+var _ balancer.Picker = (*bizHashPicker)(nil)
+
+type bizHashPicker struct {
+	ch balancer.Picker
+	rr balancer.Picker
+}
+
+func (p *bizHashPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	if _, ok := client.ContextBizId(info.Ctx); ok {
+		return p.ch.Pick(info)
+	}
+	return p.rr.Pick(info)
+}
+
+// WithBalancer 指定连接使用的负载均衡策略。
+func WithBalancer(name string) grpc.DialOption {
+	return grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy": %q}`, name))
+}
@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/client"
+	"github.com/JrMarcco/easy-grpc/client/rr"
+	"github.com/JrMarcco/easy-grpc/registry"
+	pkgregistry "github.com/JrMarcco/kuryr-admin/internal/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/pickfirst"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// hitCounter 按服务端地址统计收到的请求数
+type hitCounter struct {
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func (c *hitCounter) hit(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hits[addr]++
+}
+
+func (c *hitCounter) snapshot() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make(map[string]int, len(c.hits))
+	for k, v := range c.hits {
+		res[k] = v
+	}
+	return res
+}
+
+func (c *hitCounter) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hits = make(map[string]int)
+}
+
+// startServer 启动一个提供 grpc health 服务的测试服务端，返回服务实例信息
+func startServer(t *testing.T, counter *hitCounter) registry.ServiceInstance {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+
+	svr := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			counter.hit(addr)
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(svr, health.NewServer())
+	go func() { _ = svr.Serve(lis) }()
+	t.Cleanup(svr.Stop)
+
+	return registry.ServiceInstance{Name: "kuryr", Addr: addr, ReadWeight: 10, WriteWeight: 10}
+}
+
+func newTestManager(t *testing.T, r registry.Registry, balancerName string) *Manager[healthpb.HealthClient] {
+	t.Helper()
+
+	RegisterBalancers()
+	factory := NewManagerFactory(
+		rr.NewResolverBuilder(r, time.Second),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	manager := NewManager(factory, healthpb.NewHealthClient, WithBalancer(balancerName))
+	t.Cleanup(func() { _ = manager.CloseAll() })
+	return manager
+}
+
+func check(t *testing.T, manager *Manager[healthpb.HealthClient], ctx context.Context, times int) {
+	t.Helper()
+
+	c, err := manager.Get("kuryr")
+	require.NoError(t, err)
+	for range times {
+		_, err = c.Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+	}
+}
+
+func TestBalancers(t *testing.T) {
+	t.Parallel()
+
+	counter := &hitCounter{hits: make(map[string]int)}
+	instances := []registry.ServiceInstance{
+		startServer(t, counter),
+		startServer(t, counter),
+		startServer(t, counter),
+	}
+	r := pkgregistry.NewMemoryRegistry(instances...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tcs := []struct {
+		name         string
+		balancerName string
+		ctx          context.Context
+		// wantServers 收到请求的服务端数量，为 0 时只校验请求成功
+		wantServers int
+	}{
+		// 读写权重的分布受成功率动态调整影响，这里只校验请求可以正常分发
+		{name: "rw weight", balancerName: BalancerRwWeight, ctx: ctx},
+		{name: "round robin", balancerName: roundrobin.Name, ctx: ctx, wantServers: 3},
+		{name: "pick first", balancerName: pickfirst.Name, ctx: ctx, wantServers: 1},
+		{name: "consistent hash by biz id", balancerName: BalancerConsistentHash, ctx: client.ContextWithBizId(ctx, 10086), wantServers: 1},
+		{name: "consistent hash without biz id", balancerName: BalancerConsistentHash, ctx: ctx, wantServers: 3},
+	}
+
+	for _, tc := range tcs {
+		// 共享服务端的请求计数，不能并发执行
+		t.Run(tc.name, func(t *testing.T) {
+			manager := newTestManager(t, r, tc.balancerName)
+
+			// 预热，等待所有 SubConn 就绪
+			check(t, manager, ctx, 1)
+			if tc.wantServers == 0 {
+				return
+			}
+			require.Eventually(t, func() bool {
+				counter.reset()
+				check(t, manager, tc.ctx, 30)
+				return len(counter.snapshot()) >= tc.wantServers
+			}, 5*time.Second, 50*time.Millisecond)
+
+			counter.reset()
+			check(t, manager, tc.ctx, 30)
+			assert.Len(t, counter.snapshot(), tc.wantServers)
+		})
+	}
+}
+
+func TestBalancers_RegistryChange(t *testing.T) {
+	t.Parallel()
+
+	counter := &hitCounter{hits: make(map[string]int)}
+	first := startServer(t, counter)
+	r := pkgregistry.NewMemoryRegistry(first)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 同一个负载均衡策略的多个连接各自维护节点，互不影响
+	rwManager := newTestManager(t, r, BalancerRwWeight)
+	another := newTestManager(t, r, BalancerRwWeight)
+	check(t, rwManager, ctx, 3)
+	check(t, another, ctx, 3)
+	assert.Equal(t, map[string]int{first.Addr: 6}, counter.snapshot())
+
+	manager := newTestManager(t, r, roundrobin.Name)
+
+	// 注册新实例后请求会分发到新实例
+	second := startServer(t, counter)
+	require.NoError(t, r.Register(ctx, second))
+	require.Eventually(t, func() bool {
+		check(t, manager, ctx, 10)
+		return counter.snapshot()[second.Addr] > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// 注销实例后不再分发到该实例
+	require.NoError(t, r.Unregister(ctx, first))
+	require.Eventually(t, func() bool {
+		counter.reset()
+		check(t, manager, ctx, 10)
+		return counter.snapshot()[first.Addr] == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,29 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/JrMarcco/easy-grpc/client"
+	"google.golang.org/grpc"
+)
+
+var _ Builder = (*BizIdBuilder)(nil)
+
+// BizIdBuilder 将请求中的 biz id 写入 context，供按 biz id 一致性哈希的负载均衡使用。
+// context 中已经存在 biz id 时以 context 为准。
+type BizIdBuilder struct{}
+
+func (b *BizIdBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := client.ContextBizId(ctx); !ok {
+			if r, ok := req.(interface{ GetBizId() uint64 }); ok && r.GetBizId() != 0 {
+				ctx = client.ContextWithBizId(ctx, r.GetBizId())
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func NewBizIdBuilder() *BizIdBuilder {
+	return &BizIdBuilder{}
+}
@@ -0,0 +1,64 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JrMarcco/easy-grpc/client"
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestBizIdBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		ctx       context.Context
+		req       any
+		wantBizId uint64
+		wantOk    bool
+	}{
+		{
+			name:      "biz id from request",
+			ctx:       context.Background(),
+			req:       &configv1.FindByBizIdRequest{BizId: 10086},
+			wantBizId: 10086,
+			wantOk:    true,
+		}, {
+			name:      "context biz id wins",
+			ctx:       client.ContextWithBizId(context.Background(), 1),
+			req:       &configv1.FindByBizIdRequest{BizId: 10086},
+			wantBizId: 1,
+			wantOk:    true,
+		}, {
+			name: "zero biz id ignored",
+			ctx:  context.Background(),
+			req:  &configv1.FindByBizIdRequest{},
+		}, {
+			name: "request without biz id",
+			ctx:  context.Background(),
+			req:  struct{}{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bizId uint64
+			var ok bool
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				bizId, ok = client.ContextBizId(ctx)
+				return nil
+			}
+
+			err := NewBizIdBuilder().Build()(tc.ctx, "/config.v1.BizConfigService/FindByBizId", tc.req, nil, nil, invoker)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantBizId, bizId)
+		})
+	}
+}
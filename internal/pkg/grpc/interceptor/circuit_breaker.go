@@ -0,0 +1,73 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/circuitbreaker"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ Builder = (*BreakerBuilder)(nil)
+
+// BreakerBuilder 按服务熔断的拦截器。
+// 下游返回 Unavailable / DeadlineExceeded / ResourceExhausted 视为失败，熔断期间直接返回 Unavailable，不再发起调用。
+type BreakerBuilder struct {
+	group    *circuitbreaker.Group
+	rejected *prometheus.CounterVec
+}
+
+func (b *BreakerBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, _ := splitMethod(method)
+
+		done, err := b.group.Get(service).Allow()
+		if err != nil {
+			b.rejected.WithLabelValues(service).Inc()
+			return status.Errorf(codes.Unavailable, "%s: %v", service, err)
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(!isBreakerFailure(err))
+		return err
+	}
+}
+
+// Group 返回所有服务的熔断器。
+func (b *BreakerBuilder) Group() *circuitbreaker.Group {
+	return b.group
+}
+
+func isBreakerFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewBreakerBuilder(cfg circuitbreaker.Config, reg prometheus.Registerer) *BreakerBuilder {
+	state := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "grpc_client",
+		Name:      "circuit_breaker_state",
+		Help:      "gRPC client circuit breaker state by service (0 closed, 1 half open, 2 open).",
+	}, []string{"service"})
+	rejected := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "grpc_client",
+		Name:      "circuit_breaker_rejected_total",
+		Help:      "gRPC client calls rejected by an open circuit breaker.",
+	}, []string{"service"})
+
+	reg.MustRegister(state, rejected)
+	return &BreakerBuilder{
+		group: circuitbreaker.NewGroup(cfg, func(name string, _ circuitbreaker.State, to circuitbreaker.State) {
+			state.WithLabelValues(name).Set(float64(to))
+		}),
+		rejected: rejected,
+	}
+}
@@ -0,0 +1,69 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/circuitbreaker"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreakerBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	builder := NewBreakerBuilder(circuitbreaker.Config{FailureThreshold: 2, OpenTimeout: time.Minute}, reg)
+	interceptor := builder.Build()
+
+	calls := 0
+	invokeErr := status.Error(codes.Unavailable, "unavailable")
+	invoker := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		calls++
+		return invokeErr
+	}
+	invoke := func(method string) error {
+		return interceptor(context.Background(), method, nil, nil, nil, invoker)
+	}
+
+	// 业务错误不计入失败
+	invokeErr = status.Error(codes.NotFound, "not found")
+	for range 3 {
+		assert.Equal(t, codes.NotFound, status.Code(invoke("/business.v1.BusinessService/FindById")))
+	}
+
+	invokeErr = status.Error(codes.Unavailable, "unavailable")
+	_ = invoke("/business.v1.BusinessService/FindById")
+	_ = invoke("/business.v1.BusinessService/Search")
+	assert.Equal(t, 5, calls)
+
+	// 熔断后直接拒绝，不再调用下游
+	err := invoke("/business.v1.BusinessService/FindById")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 5, calls)
+
+	// 其他服务不受影响
+	invokeErr = nil
+	assert.NoError(t, invoke("/config.v1.BizConfigService/FindByBizId"))
+	assert.Equal(t, 6, calls)
+
+	assert.Equal(t, map[string]circuitbreaker.State{
+		"business.v1.BusinessService": circuitbreaker.StateOpen,
+		"config.v1.BizConfigService":  circuitbreaker.StateClosed,
+	}, builder.Group().States())
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP kuryr_admin_grpc_client_circuit_breaker_rejected_total gRPC client calls rejected by an open circuit breaker.
+# TYPE kuryr_admin_grpc_client_circuit_breaker_rejected_total counter
+kuryr_admin_grpc_client_circuit_breaker_rejected_total{service="business.v1.BusinessService"} 1
+# HELP kuryr_admin_grpc_client_circuit_breaker_state gRPC client circuit breaker state by service (0 closed, 1 half open, 2 open).
+# TYPE kuryr_admin_grpc_client_circuit_breaker_state gauge
+kuryr_admin_grpc_client_circuit_breaker_state{service="business.v1.BusinessService"} 2
+`), "kuryr_admin_grpc_client_circuit_breaker_rejected_total", "kuryr_admin_grpc_client_circuit_breaker_state"))
+}
@@ -0,0 +1,40 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var _ Builder = (*MetricsBuilder)(nil)
+
+// MetricsBuilder grpc 客户端调用指标，按方法与响应码统计调用耗时。
+type MetricsBuilder struct {
+	duration *prometheus.HistogramVec
+}
+
+func (b *MetricsBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		b.duration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func NewMetricsBuilder(reg prometheus.Registerer) *MetricsBuilder {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "grpc_client",
+		Name:      "call_duration_seconds",
+		Help:      "gRPC client call latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	reg.MustRegister(duration)
+	return &MetricsBuilder{duration: duration}
+}
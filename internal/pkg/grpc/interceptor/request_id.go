@@ -0,0 +1,27 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ Builder = (*RequestIdBuilder)(nil)
+
+// RequestIdBuilder 将 context 内的 request id 通过 grpc metadata 透传给服务端。
+type RequestIdBuilder struct{}
+
+func (b *RequestIdBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id, ok := reqid.FromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, reqid.MetadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func NewRequestIdBuilder() *RequestIdBuilder {
+	return &RequestIdBuilder{}
+}
@@ -0,0 +1,81 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/JrMarcco/easy-kit/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ Builder = (*RetryBuilder)(nil)
+
+// RetryBuilder 幂等读请求重试拦截器。
+// 只重试配置的方法名（不区分服务），并且只在返回 Unavailable 时按指数退避重试，重试间隔不会超过 context 的 deadline。
+type RetryBuilder struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	maxTimes        int32
+	methods         map[string]struct{}
+}
+
+func (b *RetryBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.retryable(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		// 参数已经在创建时校验过
+		strategy, _ := retry.NewExponentialBackoffStrategy(b.initialInterval, b.maxInterval, b.maxTimes)
+		for {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable {
+				return err
+			}
+
+			next, ok := strategy.Next()
+			if !ok {
+				return err
+			}
+
+			timer := time.NewTimer(next)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+func (b *RetryBuilder) retryable(fullMethod string) bool {
+	if b.maxTimes <= 0 {
+		return false
+	}
+	_, method := splitMethod(fullMethod)
+	_, ok := b.methods[method]
+	return ok
+}
+
+// NewRetryBuilder 创建重试拦截器，maxTimes 不大于 0 时不重试。
+func NewRetryBuilder(initialInterval, maxInterval time.Duration, maxTimes int32, methods []string) (*RetryBuilder, error) {
+	if maxTimes > 0 {
+		if _, err := retry.NewExponentialBackoffStrategy(initialInterval, maxInterval, maxTimes); err != nil {
+			return nil, err
+		}
+	}
+
+	set := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		set[method] = struct{}{}
+	}
+	return &RetryBuilder{
+		initialInterval: initialInterval,
+		maxInterval:     maxInterval,
+		maxTimes:        maxTimes,
+		methods:         set,
+	}, nil
+}
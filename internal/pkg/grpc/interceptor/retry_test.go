@@ -0,0 +1,115 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		maxTimes  int32
+		method    string
+		errs      []error
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "retry unavailable until success",
+			maxTimes:  2,
+			method:    "/business.v1.BusinessService/FindById",
+			errs:      []error{status.Error(codes.Unavailable, "unavailable"), nil},
+			wantCalls: 2,
+			wantCode:  codes.OK,
+		}, {
+			name:     "retry times exhausted",
+			maxTimes: 2,
+			method:   "/business.v1.BusinessService/FindById",
+			errs: []error{
+				status.Error(codes.Unavailable, "unavailable"),
+				status.Error(codes.Unavailable, "unavailable"),
+				status.Error(codes.Unavailable, "unavailable"),
+			},
+			wantCalls: 3,
+			wantCode:  codes.Unavailable,
+		}, {
+			name:      "other codes not retried",
+			maxTimes:  2,
+			method:    "/business.v1.BusinessService/FindById",
+			errs:      []error{status.Error(codes.NotFound, "not found")},
+			wantCalls: 1,
+			wantCode:  codes.NotFound,
+		}, {
+			name:      "non idempotent method not retried",
+			maxTimes:  2,
+			method:    "/business.v1.BusinessService/Save",
+			errs:      []error{status.Error(codes.Unavailable, "unavailable")},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		}, {
+			name:      "retry disabled",
+			maxTimes:  0,
+			method:    "/business.v1.BusinessService/FindById",
+			errs:      []error{status.Error(codes.Unavailable, "unavailable")},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder, err := NewRetryBuilder(time.Millisecond, 5*time.Millisecond, tc.maxTimes, []string{"FindById", "Search"})
+			require.NoError(t, err)
+
+			calls := 0
+			invoker := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			}
+
+			err = builder.Build()(context.Background(), tc.method, nil, nil, nil, invoker)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func TestRetryBuilder_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	builder, err := NewRetryBuilder(time.Second, time.Second, 3, []string{"FindById"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	invoker := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	// 重试间隔超过 deadline 时直接返回最后一次的错误
+	err = builder.Build()(ctx, "/business.v1.BusinessService/FindById", nil, nil, nil, invoker)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestNewRetryBuilder_InvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRetryBuilder(0, time.Second, 2, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Timeouts grpc 调用超时时间配置。
+// Overrides 的 key 为完整服务名（business.v1.BusinessService）或完整方法名（business.v1.BusinessService/FindById），
+// 查找顺序为 方法 -> 服务 -> Default，不大于 0 时不设置超时时间。
+type Timeouts struct {
+	Default   time.Duration
+	Overrides map[string]time.Duration
+}
+
+// For 返回指定方法的超时时间，fullMethod 格式为 /package.Service/Method。
+func (t Timeouts) For(fullMethod string) time.Duration {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if timeout, ok := t.Overrides[fullMethod]; ok {
+		return timeout
+	}
+
+	service, _ := splitMethod(fullMethod)
+	if timeout, ok := t.Overrides[service]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+var _ Builder = (*TimeoutBuilder)(nil)
+
+// TimeoutBuilder 按服务或方法为 grpc 调用设置超时时间。
+// context 已经设置了更早的 deadline 时以 context 为准，超时时间支持运行时修改。
+type TimeoutBuilder struct {
+	timeouts atomic.Pointer[Timeouts]
+}
+
+func (b *TimeoutBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if timeout := b.Timeout(method); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Timeout 返回指定方法的超时时间。
+func (b *TimeoutBuilder) Timeout(fullMethod string) time.Duration {
+	return b.timeouts.Load().For(fullMethod)
+}
+
+// SetTimeouts 修改超时时间配置。
+func (b *TimeoutBuilder) SetTimeouts(timeouts Timeouts) {
+	b.timeouts.Store(&timeouts)
+}
+
+func NewTimeoutBuilder(timeouts Timeouts) *TimeoutBuilder {
+	b := &TimeoutBuilder{}
+	b.SetTimeouts(timeouts)
+	return b
+}
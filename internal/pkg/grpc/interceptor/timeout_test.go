@@ -0,0 +1,114 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestTimeoutBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name         string
+		timeouts     Timeouts
+		method       string
+		ctxTimeout   time.Duration
+		wantDeadline bool
+		wantTimeout  time.Duration
+	}{
+		{
+			name:         "default timeout",
+			timeouts:     Timeouts{Default: time.Second},
+			method:       "/business.v1.BusinessService/FindById",
+			wantDeadline: true,
+			wantTimeout:  time.Second,
+		}, {
+			name:         "service override",
+			timeouts:     Timeouts{Default: time.Second, Overrides: map[string]time.Duration{"business.v1.BusinessService": 300 * time.Millisecond}},
+			method:       "/business.v1.BusinessService/FindById",
+			wantDeadline: true,
+			wantTimeout:  300 * time.Millisecond,
+		}, {
+			name: "method override wins over service override",
+			timeouts: Timeouts{Default: time.Second, Overrides: map[string]time.Duration{
+				"business.v1.BusinessService":          300 * time.Millisecond,
+				"business.v1.BusinessService/FindById": 200 * time.Millisecond,
+			}},
+			method:       "/business.v1.BusinessService/FindById",
+			wantDeadline: true,
+			wantTimeout:  200 * time.Millisecond,
+		}, {
+			name:         "override of other service ignored",
+			timeouts:     Timeouts{Default: time.Second, Overrides: map[string]time.Duration{"config.v1.BizConfigService": 300 * time.Millisecond}},
+			method:       "/business.v1.BusinessService/FindById",
+			wantDeadline: true,
+			wantTimeout:  time.Second,
+		}, {
+			name:         "earlier context deadline wins",
+			timeouts:     Timeouts{Default: time.Second},
+			method:       "/business.v1.BusinessService/FindById",
+			ctxTimeout:   100 * time.Millisecond,
+			wantDeadline: true,
+			wantTimeout:  100 * time.Millisecond,
+		}, {
+			name:         "no timeout",
+			method:       "/business.v1.BusinessService/FindById",
+			wantDeadline: false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tc.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.ctxTimeout)
+				defer cancel()
+			}
+
+			var deadline time.Time
+			var ok bool
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				deadline, ok = ctx.Deadline()
+				return nil
+			}
+
+			start := time.Now()
+			err := NewTimeoutBuilder(tc.timeouts).Build()(ctx, tc.method, nil, nil, nil, invoker)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantDeadline, ok)
+			if tc.wantDeadline {
+				assert.WithinDuration(t, start.Add(tc.wantTimeout), deadline, 50*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestTimeoutBuilder_SetTimeouts(t *testing.T) {
+	t.Parallel()
+
+	b := NewTimeoutBuilder(Timeouts{Default: time.Second})
+	interceptor := b.Build()
+
+	b.SetTimeouts(Timeouts{Default: 200 * time.Millisecond})
+	assert.Equal(t, 200*time.Millisecond, b.Timeout("/business.v1.BusinessService/FindById"))
+
+	var deadline time.Time
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		deadline, _ = ctx.Deadline()
+		return nil
+	}
+
+	// 已经创建的拦截器使用修改后的超时时间
+	start := time.Now()
+	require.NoError(t, interceptor(context.Background(), "/business.v1.BusinessService/FindById", nil, nil, nil, invoker))
+	assert.WithinDuration(t, start.Add(200*time.Millisecond), deadline, 50*time.Millisecond)
+}
@@ -0,0 +1,103 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc/interceptor"
+
+var _ Builder = (*TracingBuilder)(nil)
+
+// TracingBuilder 链路追踪拦截器，为每次 grpc 调用创建一个 client span，
+// 并通过 grpc metadata 将链路上下文透传给服务端。
+type TracingBuilder struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+func (b *TracingBuilder) Build() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, rpcMethod := splitMethod(method)
+		ctx, span := b.tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(rpcMethod),
+			),
+		)
+		defer span.End()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		b.propagator.Inject(ctx, &metadataCarrier{md: md})
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, code.String())
+		}
+		return err
+	}
+}
+
+// splitMethod 将 /package.Service/Method 拆分为 service 和 method
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
+
+var _ propagation.TextMapCarrier = (*metadataCarrier)(nil)
+
+// metadataCarrier 基于 grpc metadata 的 propagation.TextMapCarrier 实现
+type metadataCarrier struct {
+	md metadata.MD
+}
+
+func (c *metadataCarrier) Get(key string) string {
+	vals := c.md.Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
+func (c *metadataCarrier) Set(key string, value string) {
+	c.md.Set(key, value)
+}
+
+func (c *metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.md))
+	for key := range c.md {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func NewTracingBuilder(tp trace.TracerProvider) *TracingBuilder {
+	return &TracingBuilder{
+		tracer:     tp.Tracer(tracerName),
+		propagator: otel.GetTextMapPropagator(),
+	}
+}
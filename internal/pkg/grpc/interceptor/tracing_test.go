@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTracingBuilder_Build(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var md metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return status.Error(codes.Unavailable, "kuryr unavailable")
+	}
+
+	interceptor := NewTracingBuilder(tp).Build()
+	err := interceptor(context.Background(), "/business.v1.BusinessService/FindById", nil, nil, nil, invoker)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "business.v1.BusinessService/FindById", span.Name())
+	assert.Equal(t, "Error", span.Status().Code.String())
+
+	// traceparent 需要透传给服务端
+	traceparent := md.Get("traceparent")
+	require.Len(t, traceparent, 1)
+	assert.Contains(t, traceparent[0], span.SpanContext().TraceID().String())
+}
@@ -0,0 +1,7 @@
+package interceptor
+
+import "google.golang.org/grpc"
+
+type Builder interface {
+	Build() grpc.UnaryClientInterceptor
+}
@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/JrMarcco/easy-kit/xsync"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+)
+
+// ManagerFactory 创建 grpc 客户端管理器，所有管理器共享 resolver 与 grpc.DialOption（传输凭证、负载均衡、拦截器等）。
+//
+// easy-grpc 的 client.Manager 不支持传入自定义 grpc.DialOption，只能使用明文连接，
+// 所以这里提供一个用法一致的 Manager 来支持 TLS 与拦截器。
+type ManagerFactory struct {
+	rb   resolver.Builder
+	opts []grpc.DialOption
+}
+
+func NewManagerFactory(rb resolver.Builder, opts ...grpc.DialOption) *ManagerFactory {
+	return &ManagerFactory{
+		rb:   rb,
+		opts: opts,
+	}
+}
+
+// NewManager 使用 factory 的连接配置创建客户端管理器，creator 通常为 kuryr-api 生成的 NewXxxClient。
+// opts 追加在 factory 的配置之后，用于单个管理器的配置，例如负载均衡策略。
+func NewManager[T any](f *ManagerFactory, creator func(cc grpc.ClientConnInterface) T, opts ...grpc.DialOption) *Manager[T] {
+	return &Manager[T]{
+		rb:      f.rb,
+		opts:    append(slices.Clip(f.opts), opts...),
+		creator: creator,
+	}
+}
+
+type clientEntry[T any] struct {
+	client T
+	conn   *grpc.ClientConn
+}
+
+// Manager grpc 客户端管理器，按服务名懒加载并缓存客户端。
+type Manager[T any] struct {
+	sg      singleflight.Group
+	clients xsync.Map[string, *clientEntry[T]]
+
+	rb      resolver.Builder
+	opts    []grpc.DialOption
+	creator func(cc grpc.ClientConnInterface) T
+}
+
+// Get 返回指定服务的客户端，不存在时创建连接。
+func (m *Manager[T]) Get(serviceName string) (T, error) {
+	if entry, ok := m.clients.Load(serviceName); ok {
+		return entry.client, nil
+	}
+
+	entry, err, _ := m.sg.Do(serviceName, func() (any, error) {
+		if entry, ok := m.clients.Load(serviceName); ok {
+			return entry, nil
+		}
+
+		cc, err := m.dial(serviceName)
+		if err != nil {
+			return nil, fmt.Errorf("[kuryr-admin] failed to create grpc client connection for service %s: %w", serviceName, err)
+		}
+
+		entry := &clientEntry[T]{
+			client: m.creator(cc),
+			conn:   cc,
+		}
+		m.clients.Store(serviceName, entry)
+		return entry, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return entry.(*clientEntry[T]).client, nil
+}
+
+func (m *Manager[T]) dial(serviceName string) (*grpc.ClientConn, error) {
+	opts := make([]grpc.DialOption, 0, len(m.opts)+2)
+	opts = append(opts, grpc.WithResolvers(m.rb), grpc.WithNoProxy())
+	opts = append(opts, m.opts...)
+
+	return grpc.NewClient(fmt.Sprintf("%s:///%s", m.rb.Scheme(), serviceName), opts...)
+}
+
+// Close 关闭指定服务的连接。
+func (m *Manager[T]) Close(serviceName string) error {
+	entry, ok := m.clients.LoadAndDelete(serviceName)
+	if !ok {
+		return nil
+	}
+	return entry.conn.Close()
+}
+
+// CloseAll 关闭所有连接。
+func (m *Manager[T]) CloseAll() error {
+	var errs []error
+	m.clients.Range(func(serviceName string, _ *clientEntry[T]) bool {
+		if err := m.Close(serviceName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close connection for service %s: %w", serviceName, err))
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/grpc/interceptor"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	// 记录服务端收到的 metadata
+	var md metadata.MD
+	svr := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ = metadata.FromIncomingContext(ctx)
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(svr, health.NewServer())
+	go func() { _ = svr.Serve(lis) }()
+	defer svr.Stop()
+
+	rb := manual.NewBuilderWithScheme("kuryr-test")
+	rb.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: lis.Addr().String()}}})
+
+	var order []string
+	record := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			order = append(order, name)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+
+	factory := NewManagerFactory(
+		rb,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			record("first"),
+			interceptor.NewRequestIdBuilder().Build(),
+			interceptor.NewAuthBuilder("service-token").Build(),
+			record("second"),
+		),
+	)
+	manager := NewManager(factory, healthpb.NewHealthClient)
+
+	client, err := manager.Get("kuryr")
+	require.NoError(t, err)
+
+	// 同一服务复用同一个客户端
+	cached, err := manager.Get("kuryr")
+	require.NoError(t, err)
+	assert.Same(t, client, cached)
+
+	ctx := reqid.WithContext(context.Background(), "test-request-id")
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Equal(t, []string{"test-request-id"}, md.Get(reqid.MetadataKey))
+	assert.Equal(t, []string{"Bearer service-token"}, md.Get(interceptor.AuthMetadataKey))
+
+	// 关闭后重新获取会创建新的客户端
+	require.NoError(t, manager.CloseAll())
+	client, err = manager.Get("kuryr")
+	require.NoError(t, err)
+	assert.NotSame(t, cached, client)
+	require.NoError(t, manager.Close("kuryr"))
+}
@@ -0,0 +1,152 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/circuitbreaker"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	"github.com/redis/go-redis/v9"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"gorm.io/gorm"
+)
+
+var _ Checker = (*DBChecker)(nil)
+
+// DBChecker 数据库连接检查
+type DBChecker struct {
+	db *gorm.DB
+}
+
+func (c *DBChecker) Name() string {
+	return "db"
+}
+
+func (c *DBChecker) Check(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func NewDBChecker(db *gorm.DB) *DBChecker {
+	return &DBChecker{db: db}
+}
+
+var _ Checker = (*RedisChecker)(nil)
+
+// RedisChecker redis 连接检查
+type RedisChecker struct {
+	rc redis.Cmdable
+}
+
+func (c *RedisChecker) Name() string {
+	return "redis"
+}
+
+func (c *RedisChecker) Check(ctx context.Context) error {
+	return c.rc.Ping(ctx).Err()
+}
+
+func NewRedisChecker(rc redis.Cmdable) *RedisChecker {
+	return &RedisChecker{rc: rc}
+}
+
+var _ Checker = (*EtcdChecker)(nil)
+
+// EtcdChecker etcd 集群检查，任一节点可用即视为可用
+type EtcdChecker struct {
+	client *clientv3.Client
+}
+
+func (c *EtcdChecker) Name() string {
+	return "etcd"
+}
+
+func (c *EtcdChecker) Check(ctx context.Context) error {
+	var errs []error
+	for _, endpoint := range c.client.Endpoints() {
+		if _, err := c.client.Status(ctx, endpoint); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+			continue
+		}
+		return nil
+	}
+	if len(errs) == 0 {
+		return errors.New("no etcd endpoint configured")
+	}
+	return errors.Join(errs...)
+}
+
+func NewEtcdChecker(client *clientv3.Client) *EtcdChecker {
+	return &EtcdChecker{client: client}
+}
+
+var _ Checker = (*GrpcChecker[any])(nil)
+
+// GrpcChecker grpc 客户端检查。
+// 检查注册中心内是否存在可用的服务实例，以及客户端管理器能否为服务创建客户端。
+type GrpcChecker[T any] struct {
+	name        string
+	serviceName string
+	manager     *pkggrpc.Manager[T]
+	registry    registry.Registry
+}
+
+func (c *GrpcChecker[T]) Name() string {
+	return c.name
+}
+
+func (c *GrpcChecker[T]) Check(ctx context.Context) error {
+	instances, err := c.registry.ListServices(ctx, c.serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve service %s: %w", c.serviceName, err)
+	}
+	if len(instances) == 0 {
+		return fmt.Errorf("no instance registered for service %s", c.serviceName)
+	}
+
+	if _, err = c.manager.Get(c.serviceName); err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewGrpcChecker[T any](
+	name string, serviceName string, manager *pkggrpc.Manager[T], registry registry.Registry,
+) *GrpcChecker[T] {
+	return &GrpcChecker[T]{
+		name:        name,
+		serviceName: serviceName,
+		manager:     manager,
+		registry:    registry,
+	}
+}
+
+var _ Checker = (*CircuitBreakerChecker)(nil)
+
+// CircuitBreakerChecker grpc 客户端熔断器检查，任一服务熔断即视为不可用
+type CircuitBreakerChecker struct {
+	group *circuitbreaker.Group
+}
+
+func (c *CircuitBreakerChecker) Name() string {
+	return "grpc.circuit_breaker"
+}
+
+func (c *CircuitBreakerChecker) Check(_ context.Context) error {
+	var errs []error
+	for name, state := range c.group.States() {
+		if state == circuitbreaker.StateOpen {
+			errs = append(errs, fmt.Errorf("%s: circuit breaker is %s", name, state))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func NewCircuitBreakerChecker(group *circuitbreaker.Group) *CircuitBreakerChecker {
+	return &CircuitBreakerChecker{group: group}
+}
@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var ErrDraining = errors.New("server is draining")
+
+var _ Checker = (*DrainChecker)(nil)
+
+// DrainChecker 停机排空检查。
+// 服务开始停机后就绪检查直接失败，让负载均衡先摘除流量，再等待处理中的请求完成。
+type DrainChecker struct {
+	draining atomic.Bool
+}
+
+func (c *DrainChecker) Name() string {
+	return "drain"
+}
+
+func (c *DrainChecker) Check(_ context.Context) error {
+	if c.draining.Load() {
+		return ErrDraining
+	}
+	return nil
+}
+
+// StartDraining 标记服务进入排空状态。
+func (c *DrainChecker) StartDraining() {
+	c.draining.Store(true)
+}
+
+func NewDrainChecker() *DrainChecker {
+	return &DrainChecker{}
+}
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker 依赖健康检查器。
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// CheckResult 单个依赖的检查结果
+type CheckResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report 健康检查报告
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+func (r Report) IsUp() bool {
+	return r.Status == StatusUp
+}
+
+// Run 并发执行所有检查器，任一检查失败则整体状态为 down。
+func Run(ctx context.Context, timeout time.Duration, checkers []Checker) Report {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checkers))
+
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := checker.Check(ctx)
+
+			res := CheckResult{
+				Name:    checker.Name(),
+				Status:  StatusUp,
+				Latency: time.Since(start).String(),
+			}
+			if err != nil {
+				res.Status = StatusDown
+				res.Error = err.Error()
+			}
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: results,
+	}
+	for _, res := range results {
+		if res.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
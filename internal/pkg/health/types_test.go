@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChecker struct {
+	name string
+	err  error
+}
+
+func (m *mockChecker) Name() string {
+	return m.name
+}
+
+func (m *mockChecker) Check(ctx context.Context) error {
+	return m.err
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		checkers   []Checker
+		wantStatus string
+		wantChecks []string
+	}{
+		{
+			name:       "no checker",
+			checkers:   nil,
+			wantStatus: StatusUp,
+			wantChecks: []string{},
+		}, {
+			name: "all up",
+			checkers: []Checker{
+				&mockChecker{name: "db"},
+				&mockChecker{name: "redis"},
+			},
+			wantStatus: StatusUp,
+			wantChecks: []string{StatusUp, StatusUp},
+		}, {
+			name: "one down",
+			checkers: []Checker{
+				&mockChecker{name: "db"},
+				&mockChecker{name: "redis", err: errors.New("connection refused")},
+			},
+			wantStatus: StatusDown,
+			wantChecks: []string{StatusUp, StatusDown},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := Run(context.Background(), time.Second, tc.checkers)
+			assert.Equal(t, tc.wantStatus, report.Status)
+
+			statuses := make([]string, 0, len(report.Checks))
+			for i, check := range report.Checks {
+				assert.Equal(t, tc.checkers[i].Name(), check.Name)
+				statuses = append(statuses, check.Status)
+			}
+			assert.Equal(t, tc.wantChecks, statuses)
+		})
+	}
+}
+
+func TestDrainChecker(t *testing.T) {
+	t.Parallel()
+
+	checker := NewDrainChecker()
+	assert.NoError(t, checker.Check(context.Background()))
+
+	checker.StartDraining()
+	assert.ErrorIs(t, checker.Check(context.Background()), ErrDraining)
+
+	report := Run(context.Background(), time.Second, []Checker{checker})
+	assert.False(t, report.IsUp())
+}
+
+func TestCircuitBreakerChecker(t *testing.T) {
+	t.Parallel()
+
+	group := circuitbreaker.NewGroup(circuitbreaker.Config{FailureThreshold: 1, OpenTimeout: time.Minute}, nil)
+	checker := NewCircuitBreakerChecker(group)
+	assert.NoError(t, checker.Check(context.Background()))
+
+	done, err := group.Get("business.v1.BusinessService").Allow()
+	require.NoError(t, err)
+	done(false)
+
+	err = checker.Check(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "business.v1.BusinessService: circuit breaker is open")
+}
@@ -0,0 +1,285 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrNoChange   = errors.New("[kuryr-admin] no migration to apply")
+	ErrNoDown     = errors.New("[kuryr-admin] migration has no down script")
+	ErrBehind     = errors.New("[kuryr-admin] database schema is behind, run migrate up first")
+	ErrNoMigrated = errors.New("[kuryr-admin] no migration has been applied")
+)
+
+// TableName 记录已执行迁移的表
+const TableName = "schema_migrations"
+
+// fileRegexp 迁移文件名，例如 000001_init.up.sql / 000001_init.down.sql
+var fileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration 一个版本的迁移脚本
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status 迁移执行状态
+type Status struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// Unknown 数据库中已执行但当前程序内不存在的迁移，通常是由更新版本的程序执行的
+	Unknown bool
+}
+
+// Migrator 执行版本化的数据库迁移。
+//
+// 每个版本的迁移脚本与 schema_migrations 表的变更在同一个事务内执行，
+// 迁移脚本需要兼容事务执行（postgres 支持 DDL 事务）。
+// Migrator 不处理并发执行，多实例部署时需要作为单独的任务执行迁移。
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// Up 按版本顺序执行所有未执行的迁移，返回本次执行的迁移，没有需要执行的迁移时返回 ErrNoChange。
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err = m.exec(ctx, migration.Up, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO "+TableName+" (version, name, applied_at) VALUES ($1, $2, $3)",
+				migration.Version, migration.Name, time.Now().UnixMilli(),
+			)
+			return err
+		}); err != nil {
+			return res, fmt.Errorf("[kuryr-admin] failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		res = append(res, migration)
+	}
+
+	if len(res) == 0 {
+		return nil, ErrNoChange
+	}
+	return res, nil
+}
+
+// Down 回滚最近执行的一个迁移。
+func (m *Migrator) Down(ctx context.Context) (Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return Migration{}, err
+	}
+	if len(applied) == 0 {
+		return Migration{}, ErrNoMigrated
+	}
+
+	latest := slices.Max(slices.Collect(maps.Keys(applied)))
+	idx := slices.IndexFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == latest
+	})
+	if idx < 0 {
+		return Migration{}, fmt.Errorf("[kuryr-admin] migration %d is not known by this binary", latest)
+	}
+
+	migration := m.migrations[idx]
+	if migration.Down == "" {
+		return Migration{}, fmt.Errorf("%w: %d_%s", ErrNoDown, migration.Version, migration.Name)
+	}
+
+	if err = m.exec(ctx, migration.Down, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+TableName+" WHERE version = $1", migration.Version)
+		return err
+	}); err != nil {
+		return Migration{}, fmt.Errorf("[kuryr-admin] failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return migration, nil
+}
+
+// Status 返回所有迁移的执行状态，按版本排序。
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if s, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = s.AppliedAt
+			delete(applied, migration.Version)
+		}
+		res = append(res, status)
+	}
+	for _, s := range applied {
+		res = append(res, s)
+	}
+
+	slices.SortFunc(res, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return res, nil
+}
+
+// Check 检查是否所有迁移都已执行，存在未执行的迁移时返回 ErrBehind。
+// 数据库中存在当前程序未知的迁移时（例如滚动升级期间旧版本实例），不视为错误。
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, fmt.Sprintf("%d_%s", s.Version, s.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: pending migrations %v", ErrBehind, pending)
+	}
+	return nil
+}
+
+// exec 在事务内执行迁移脚本以及对 schema_migrations 的变更
+func (m *Migrator) exec(ctx context.Context, script string, record func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err = record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// applied 返回已执行的迁移，schema_migrations 表不存在时创建
+func (m *Migrator) applied(ctx context.Context) (map[uint64]Status, error) {
+	if _, err := m.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+TableName+" ("+
+		"version BIGINT PRIMARY KEY, "+
+		"name VARCHAR(255) NOT NULL, "+
+		"applied_at BIGINT NOT NULL)",
+	); err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to create %s: %w", TableName, err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, name, applied_at FROM "+TableName)
+	if err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to query %s: %w", TableName, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	known := make(map[uint64]struct{}, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = struct{}{}
+	}
+
+	res := make(map[uint64]Status)
+	for rows.Next() {
+		var (
+			s         Status
+			appliedAt int64
+		)
+		if err = rows.Scan(&s.Version, &s.Name, &appliedAt); err != nil {
+			return nil, err
+		}
+		s.Applied = true
+		s.AppliedAt = time.UnixMilli(appliedAt)
+		_, ok := known[s.Version]
+		s.Unknown = !ok
+		res[s.Version] = s
+	}
+	return res, rows.Err()
+}
+
+// Migrations 返回按版本排序的所有迁移
+func (m *Migrator) Migrations() []Migration {
+	return slices.Clone(m.migrations)
+}
+
+// NewMigrator 从 fsys 根目录加载迁移脚本。
+// 每个版本必须包含 up 脚本，down 脚本可选，不包含 down 脚本的版本不能回滚。
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		matches := fileRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("[kuryr-admin] invalid migration file name %s, want <version>_<name>.(up|down).sql", entry.Name())
+		}
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("[kuryr-admin] invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("[kuryr-admin] migration version %d has different names %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("[kuryr-admin] migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
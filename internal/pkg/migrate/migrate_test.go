@@ -0,0 +1,188 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+var testFS = fstest.MapFS{
+	"000001_init.up.sql":         file("CREATE TABLE t1 (id BIGINT PRIMARY KEY);"),
+	"000001_init.down.sql":       file("DROP TABLE t1;"),
+	"000002_add_t2.up.sql":       file("CREATE TABLE t2 (id BIGINT PRIMARY KEY); CREATE TABLE t3 (id BIGINT PRIMARY KEY);"),
+	"000002_add_t2.down.sql":     file("DROP TABLE t3; DROP TABLE t2;"),
+	"000003_no_down.up.sql":      file("CREATE TABLE t4 (id BIGINT PRIMARY KEY);"),
+	"README.md":                  file("ignored"),
+	"000010_later.up.sql.backup": file("ignored"),
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+
+	var cnt int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1", name).Scan(&cnt)
+	require.NoError(t, err)
+	return cnt > 0
+}
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	m, err := NewMigrator(db, testFS)
+	require.NoError(t, err)
+	require.Len(t, m.Migrations(), 3)
+
+	assert.ErrorIs(t, m.Check(ctx), ErrBehind)
+	_, err = m.Down(ctx)
+	assert.ErrorIs(t, err, ErrNoMigrated)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 3)
+	assert.Equal(t, []uint64{1, 2, 3}, []uint64{applied[0].Version, applied[1].Version, applied[2].Version})
+	for _, name := range []string{"t1", "t2", "t3", "t4"} {
+		assert.True(t, tableExists(t, db, name), name)
+	}
+	require.NoError(t, m.Check(ctx))
+
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, ErrNoChange)
+
+	// 最新的迁移没有 down 脚本，不能回滚
+	_, err = m.Down(ctx)
+	assert.ErrorIs(t, err, ErrNoDown)
+
+	_, err = db.Exec("DELETE FROM " + TableName + " WHERE version = 3")
+	require.NoError(t, err)
+
+	rolledBack, err := m.Down(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), rolledBack.Version)
+	assert.False(t, tableExists(t, db, "t2"))
+	assert.False(t, tableExists(t, db, "t3"))
+	assert.True(t, tableExists(t, db, "t1"))
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.False(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+
+	err = m.Check(ctx)
+	require.ErrorIs(t, err, ErrBehind)
+	assert.Contains(t, err.Error(), "2_add_t2")
+}
+
+func TestMigrator_FailedMigrationRolledBack(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	m, err := NewMigrator(db, fstest.MapFS{
+		"000001_init.up.sql":   file("CREATE TABLE t1 (id BIGINT PRIMARY KEY);"),
+		"000002_broken.up.sql": file("CREATE TABLE t2 (id BIGINT PRIMARY KEY); INSERT INTO missing VALUES (1);"),
+	})
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2_broken")
+	require.Len(t, applied, 1)
+
+	// 失败的迁移整体回滚，不记录版本
+	assert.True(t, tableExists(t, db, "t1"))
+	assert.False(t, tableExists(t, db, "t2"))
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigrator_UnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	m, err := NewMigrator(db, fstest.MapFS{"000001_init.up.sql": file("CREATE TABLE t1 (id BIGINT PRIMARY KEY);")})
+	require.NoError(t, err)
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	// 更新版本的程序执行过的迁移
+	_, err = db.Exec("INSERT INTO "+TableName+" (version, name, applied_at) VALUES ($1, $2, $3)", 2, "newer", 0)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Check(ctx))
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[1].Unknown)
+
+	_, err = m.Down(ctx)
+	assert.ErrorContains(t, err, "not known")
+}
+
+func TestNewMigrator(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr string
+	}{
+		{
+			name:    "invalid file name",
+			fsys:    fstest.MapFS{"init.up.sql": file("")},
+			wantErr: "invalid migration file name",
+		}, {
+			name:    "missing up script",
+			fsys:    fstest.MapFS{"000001_init.down.sql": file("DROP TABLE t1;")},
+			wantErr: "has no up script",
+		}, {
+			name: "different names",
+			fsys: fstest.MapFS{
+				"000001_init.up.sql":  file("CREATE TABLE t1 (id BIGINT);"),
+				"000001_other.up.sql": file("CREATE TABLE t2 (id BIGINT);"),
+			},
+			wantErr: "different names",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMigrator(nil, tc.fsys)
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
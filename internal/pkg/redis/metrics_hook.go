@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ redis.Hook = (*MetricsHook)(nil)
+
+// MetricsHook redis 命令指标 hook，按命令名统计执行耗时。
+type MetricsHook struct {
+	duration *prometheus.HistogramVec
+}
+
+func (h *MetricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h *MetricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+
+		h.duration.WithLabelValues(cmd.Name(), result(err)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (h *MetricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+
+		h.duration.WithLabelValues("pipeline", result(err)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// result redis.Nil 表示 key 不存在，不计为错误
+func result(err error) string {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "error"
+	}
+	return "ok"
+}
+
+func NewMetricsHook(reg prometheus.Registerer) *MetricsHook {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "redis",
+		Name:      "command_duration_seconds",
+		Help:      "Redis command latency by command and result.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command", "result"})
+
+	reg.MustRegister(duration)
+	return &MetricsHook{duration: duration}
+}
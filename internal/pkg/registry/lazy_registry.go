@@ -0,0 +1,159 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/easy-kit/retry"
+)
+
+var ErrRegistryNotReady = errors.New("[kuryr-admin] registry is not ready")
+
+var _ registry.Registry = (*LazyRegistry)(nil)
+
+// LazyRegistry 延迟初始化的 registry.Registry。
+//
+// etcd 不可用时创建 etcd.Registry 会阻塞在租约申请上，导致服务无法启动。
+// LazyRegistry 在后台按重试策略创建真正的 registry，创建完成前服务发现相关方法直接返回 ErrRegistryNotReady，
+// 创建完成后通知所有订阅者重新解析服务，从而支持依赖不可用时以降级模式启动。
+type LazyRegistry struct {
+	once    sync.Once
+	ready   chan struct{}
+	closing chan struct{}
+
+	mu       sync.RWMutex
+	delegate registry.Registry
+	buildErr error
+}
+
+func (r *LazyRegistry) Register(ctx context.Context, si registry.ServiceInstance) error {
+	delegate, ok := r.load()
+	if !ok {
+		return ErrRegistryNotReady
+	}
+	return delegate.Register(ctx, si)
+}
+
+func (r *LazyRegistry) Unregister(ctx context.Context, si registry.ServiceInstance) error {
+	delegate, ok := r.load()
+	if !ok {
+		return ErrRegistryNotReady
+	}
+	return delegate.Unregister(ctx, si)
+}
+
+func (r *LazyRegistry) ListServices(ctx context.Context, serviceName string) ([]registry.ServiceInstance, error) {
+	delegate, ok := r.load()
+	if !ok {
+		return nil, ErrRegistryNotReady
+	}
+	return delegate.ListServices(ctx, serviceName)
+}
+
+func (r *LazyRegistry) Subscribe(serviceName string) <-chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		select {
+		case <-r.ready:
+		case <-r.closing:
+			return
+		}
+
+		delegate, _ := r.load()
+		events := delegate.Subscribe(serviceName)
+
+		// registry 就绪后先通知一次，触发订阅者重新解析
+		notify := struct{}{}
+		for {
+			select {
+			case ch <- notify:
+			case <-r.closing:
+				return
+			}
+
+			select {
+			case notify = <-events:
+			case <-r.closing:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Ready 返回在 registry 创建完成后关闭的 channel。
+func (r *LazyRegistry) Ready() <-chan struct{} {
+	return r.ready
+}
+
+// Err 返回最近一次创建 registry 失败的错误。
+func (r *LazyRegistry) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buildErr
+}
+
+func (r *LazyRegistry) Close() error {
+	r.once.Do(func() {
+		close(r.closing)
+	})
+
+	if delegate, ok := r.load(); ok {
+		return delegate.Close()
+	}
+	return nil
+}
+
+func (r *LazyRegistry) load() (registry.Registry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.delegate, r.delegate != nil
+}
+
+func (r *LazyRegistry) build(builder func() (registry.Registry, error), strategy retry.Strategy) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-r.closing:
+			cancel()
+		case <-r.ready:
+		}
+	}()
+
+	_ = retry.Retry(ctx, strategy, func() error {
+		delegate, err := builder()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if err != nil {
+			r.buildErr = err
+			return err
+		}
+
+		select {
+		case <-r.closing:
+			// 创建过程中 registry 已经被关闭
+			_ = delegate.Close()
+			return nil
+		default:
+		}
+
+		r.delegate = delegate
+		r.buildErr = nil
+		close(r.ready)
+		return nil
+	})
+}
+
+// NewLazyRegistry 创建 LazyRegistry 并立即在后台开始创建真正的 registry。
+// strategy 的最大重试次数不大于 0 时将一直重试直到成功或 registry 被关闭。
+func NewLazyRegistry(builder func() (registry.Registry, error), strategy retry.Strategy) *LazyRegistry {
+	r := &LazyRegistry{
+		ready:   make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+	go r.build(builder, strategy)
+	return r
+}
@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/easy-kit/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRegistry struct {
+	instances []registry.ServiceInstance
+	events    chan struct{}
+	closed    atomic.Bool
+}
+
+func (f *fakeRegistry) Register(_ context.Context, _ registry.ServiceInstance) error {
+	return nil
+}
+
+func (f *fakeRegistry) Unregister(_ context.Context, _ registry.ServiceInstance) error {
+	return nil
+}
+
+func (f *fakeRegistry) ListServices(_ context.Context, _ string) ([]registry.ServiceInstance, error) {
+	return f.instances, nil
+}
+
+func (f *fakeRegistry) Subscribe(_ string) <-chan struct{} {
+	return f.events
+}
+
+func (f *fakeRegistry) Close() error {
+	f.closed.Store(true)
+	return nil
+}
+
+func newStrategy(t *testing.T) retry.Strategy {
+	strategy, err := retry.NewFixedIntervalStrategy(10*time.Millisecond, 0)
+	require.NoError(t, err)
+	return strategy
+}
+
+func TestLazyRegistry(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeRegistry{
+		instances: []registry.ServiceInstance{{Name: "kuryr", Addr: "127.0.0.1:9090"}},
+		events:    make(chan struct{}),
+	}
+
+	var attempts atomic.Int32
+	r := NewLazyRegistry(func() (registry.Registry, error) {
+		if attempts.Add(1) < 3 {
+			return nil, errors.New("etcd unavailable")
+		}
+		return fake, nil
+	}, newStrategy(t))
+
+	events := r.Subscribe("kuryr")
+
+	select {
+	case <-r.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("registry is not ready")
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.NoError(t, r.Err())
+
+	// registry 就绪后订阅者会先收到一次通知
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+		t.Fatal("no notification after registry ready")
+	}
+
+	// 之后转发真正 registry 的通知
+	go func() { fake.events <- struct{}{} }()
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+		t.Fatal("no notification forwarded")
+	}
+
+	instances, err := r.ListServices(context.Background(), "kuryr")
+	require.NoError(t, err)
+	assert.Equal(t, fake.instances, instances)
+
+	require.NoError(t, r.Close())
+	assert.True(t, fake.closed.Load())
+}
+
+func TestLazyRegistry_NotReady(t *testing.T) {
+	t.Parallel()
+
+	buildErr := errors.New("etcd unavailable")
+	r := NewLazyRegistry(func() (registry.Registry, error) {
+		return nil, buildErr
+	}, newStrategy(t))
+
+	_, err := r.ListServices(context.Background(), "kuryr")
+	assert.ErrorIs(t, err, ErrRegistryNotReady)
+	assert.ErrorIs(t, r.Register(context.Background(), registry.ServiceInstance{}), ErrRegistryNotReady)
+	assert.ErrorIs(t, r.Unregister(context.Background(), registry.ServiceInstance{}), ErrRegistryNotReady)
+
+	assert.Eventually(t, func() bool {
+		return errors.Is(r.Err(), buildErr)
+	}, time.Second, 10*time.Millisecond)
+
+	events := r.Subscribe("kuryr")
+	require.NoError(t, r.Close())
+
+	select {
+	case <-events:
+		t.Fatal("unexpected notification")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
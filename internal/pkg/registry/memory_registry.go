@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+)
+
+var _ registry.Registry = (*MemoryRegistry)(nil)
+
+// MemoryRegistry 基于内存的 registry.Registry，用于测试以及不依赖 etcd 的本地开发。
+// 同一服务内以 Addr 区分实例，实例变化时通知该服务的所有订阅者。
+type MemoryRegistry struct {
+	mu          sync.RWMutex
+	services    map[string][]registry.ServiceInstance
+	subscribers map[string][]chan struct{}
+}
+
+func (r *MemoryRegistry) Register(_ context.Context, si registry.ServiceInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	instances := slices.DeleteFunc(r.services[si.Name], func(inst registry.ServiceInstance) bool {
+		return inst.Addr == si.Addr
+	})
+	r.services[si.Name] = append(instances, si)
+	r.notify(si.Name)
+	return nil
+}
+
+func (r *MemoryRegistry) Unregister(_ context.Context, si registry.ServiceInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.services[si.Name] = slices.DeleteFunc(r.services[si.Name], func(inst registry.ServiceInstance) bool {
+		return inst.Addr == si.Addr
+	})
+	r.notify(si.Name)
+	return nil
+}
+
+func (r *MemoryRegistry) ListServices(_ context.Context, serviceName string) ([]registry.ServiceInstance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.services[serviceName]), nil
+}
+
+func (r *MemoryRegistry) Subscribe(serviceName string) <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	r.subscribers[serviceName] = append(r.subscribers[serviceName], ch)
+	return ch
+}
+
+// notify 通知订阅者，订阅者还未处理上一次通知时合并通知
+func (r *MemoryRegistry) notify(serviceName string) {
+	for _, ch := range r.subscribers[serviceName] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close 不关闭订阅 channel，避免订阅者把已关闭的 channel 当作变更通知不断重新解析。
+func (r *MemoryRegistry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = make(map[string][]chan struct{})
+	return nil
+}
+
+func NewMemoryRegistry(instances ...registry.ServiceInstance) *MemoryRegistry {
+	r := &MemoryRegistry{
+		services:    make(map[string][]registry.ServiceInstance),
+		subscribers: make(map[string][]chan struct{}),
+	}
+	for _, si := range instances {
+		r.services[si.Name] = append(r.services[si.Name], si)
+	}
+	return r
+}
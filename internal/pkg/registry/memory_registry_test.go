@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewMemoryRegistry(registry.ServiceInstance{Name: "kuryr", Addr: "127.0.0.1:9001"})
+
+	events := r.Subscribe("kuryr")
+	other := r.Subscribe("other")
+
+	instances, err := r.ListServices(ctx, "kuryr")
+	require.NoError(t, err)
+	assert.Equal(t, []registry.ServiceInstance{{Name: "kuryr", Addr: "127.0.0.1:9001"}}, instances)
+
+	// 相同地址重复注册时更新实例
+	require.NoError(t, r.Register(ctx, registry.ServiceInstance{Name: "kuryr", Addr: "127.0.0.1:9002"}))
+	require.NoError(t, r.Register(ctx, registry.ServiceInstance{Name: "kuryr", Addr: "127.0.0.1:9001", ReadWeight: 10}))
+	instances, err = r.ListServices(ctx, "kuryr")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []registry.ServiceInstance{
+		{Name: "kuryr", Addr: "127.0.0.1:9001", ReadWeight: 10},
+		{Name: "kuryr", Addr: "127.0.0.1:9002"},
+	}, instances)
+
+	// 多次变化合并为一次通知，其他服务的订阅者不会收到通知
+	assert.Len(t, events, 1)
+	<-events
+	assert.Empty(t, other)
+
+	require.NoError(t, r.Unregister(ctx, registry.ServiceInstance{Name: "kuryr", Addr: "127.0.0.1:9002"}))
+	assert.Len(t, events, 1)
+	instances, err = r.ListServices(ctx, "kuryr")
+	require.NoError(t, err)
+	assert.Len(t, instances, 1)
+
+	require.NoError(t, r.Close())
+}
@@ -0,0 +1,43 @@
+package reqid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderName  = "X-Request-Id" // http 请求 / 响应头
+	MetadataKey = "x-request-id" // grpc metadata key，grpc 要求小写
+	FieldName   = "request_id"   // 日志字段名
+)
+
+type contextKey struct{}
+
+// New 生成新的 request id。
+func New() string {
+	return uuid.NewString()
+}
+
+// WithContext 在 context.Context 内写入 request id。
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext 从 context.Context 获取 request id。
+func FromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Field 返回携带 request id 的 zap.Field，context 内不存在 request id 时返回 zap.Skip()。
+func Field(ctx context.Context) zap.Field {
+	if id, ok := FromContext(ctx); ok {
+		return zap.String(FieldName, id)
+	}
+	return zap.Skip()
+}
@@ -0,0 +1,33 @@
+package reqid
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// SlogHandler slog.Handler 装饰器，自动为日志追加 context 内的 request id。
+// 需要使用 slog.XxxContext 系列方法记录日志才能生效。
+type SlogHandler struct {
+	slog.Handler
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id, ok := FromContext(ctx); ok {
+		record.AddAttrs(slog.String(FieldName, id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SlogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+func NewSlogHandler(handler slog.Handler) *SlogHandler {
+	return &SlogHandler{Handler: handler}
+}
@@ -0,0 +1,78 @@
+package route
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Route 路由，Path 为路由注册时的完整路径，例如 /api/v1/biz/:id。
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Set 路由集合。
+type Set struct {
+	mu     sync.RWMutex
+	routes map[Route]struct{}
+}
+
+func (s *Set) Add(routes ...Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range routes {
+		s.routes[r] = struct{}{}
+	}
+}
+
+func (s *Set) Contains(method string, fullPath string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.routes[Route{Method: method, Path: fullPath}]
+	return ok
+}
+
+func NewSet() *Set {
+	return &Set{routes: make(map[Route]struct{})}
+}
+
+// Matcher 判断请求是否可以跳过 token 校验。
+//
+// 优先级从高到低：
+//  1. 命中配置中 ! 开头的规则，必须校验 token
+//  2. 命中路由注册时声明的公开路由，跳过校验
+//  3. 命中配置中的普通规则，跳过校验
+//  4. 其他请求必须校验 token
+type Matcher struct {
+	public *Set
+	rules  atomic.Pointer[Rules]
+}
+
+// Skip 判断请求是否可以跳过 token 校验，fullPath 为请求命中的路由，未命中路由时为空。
+func (m *Matcher) Skip(method string, path string, fullPath string) bool {
+	rules := m.rules.Load()
+	if rules.Denied(method, path) {
+		return false
+	}
+	if fullPath != "" && m.public.Contains(method, fullPath) {
+		return true
+	}
+	return rules.Ignored(method, path)
+}
+
+// SetRules 替换配置中的规则，支持运行时修改。
+func (m *Matcher) SetRules(rules *Rules) {
+	if rules == nil {
+		rules = NewRules()
+	}
+	m.rules.Store(rules)
+}
+
+func NewMatcher(public *Set, rules *Rules) *Matcher {
+	if public == nil {
+		public = NewSet()
+	}
+	m := &Matcher{public: public}
+	m.SetRules(rules)
+	return m
+}
@@ -0,0 +1,167 @@
+package route
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	// anyMethod 匹配所有 http 方法
+	anyMethod = "*"
+	// anySegments 匹配任意层级路径，只能出现在规则的最后一段
+	anySegments = "**"
+	// denyPrefix 规则前缀，表示即使命中其他规则也必须校验 token
+	denyPrefix = "!"
+)
+
+var methods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// Rule 请求路径匹配规则，格式为 [!][METHOD ]PATTERN，例如：
+//
+//	/api/v1/user/login     精确匹配，忽略末尾的 /
+//	POST /api/v1/user/*    * 匹配一段路径，语法同 path.Match
+//	/static/**             ** 匹配 /static 本身以及其下任意层级的路径
+//	!/metrics/**           以 ! 开头的规则表示必须校验 token，优先级最高
+type Rule struct {
+	Deny     bool
+	Method   string
+	segments []string
+}
+
+// Match 判断请求是否命中规则。
+func (r Rule) Match(method string, p string) bool {
+	if r.Method != anyMethod && r.Method != method {
+		return false
+	}
+
+	segments := split(p)
+	for i, seg := range r.segments {
+		if seg == anySegments {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if ok, _ := path.Match(seg, segments[i]); !ok {
+			return false
+		}
+	}
+	return len(r.segments) == len(segments)
+}
+
+func (r Rule) String() string {
+	prefix := ""
+	if r.Deny {
+		prefix = denyPrefix
+	}
+	return fmt.Sprintf("%s%s /%s", prefix, r.Method, strings.Join(r.segments, "/"))
+}
+
+// ParseRule 解析规则。
+func ParseRule(spec string) (Rule, error) {
+	rule := Rule{Method: anyMethod}
+
+	s := strings.TrimSpace(spec)
+	if strings.HasPrefix(s, denyPrefix) {
+		rule.Deny = true
+		s = strings.TrimSpace(strings.TrimPrefix(s, denyPrefix))
+	}
+
+	if method, pattern, ok := strings.Cut(s, " "); ok {
+		method = strings.ToUpper(method)
+		if method != anyMethod && !slices.Contains(methods, method) {
+			return Rule{}, fmt.Errorf("[kuryr-admin] invalid rule %q: unknown method %s", spec, method)
+		}
+		rule.Method = method
+		s = strings.TrimSpace(pattern)
+	}
+
+	if !strings.HasPrefix(s, "/") {
+		return Rule{}, fmt.Errorf("[kuryr-admin] invalid rule %q: pattern must start with /", spec)
+	}
+
+	rule.segments = split(s)
+	for i, seg := range rule.segments {
+		if seg == anySegments {
+			if i != len(rule.segments)-1 {
+				return Rule{}, fmt.Errorf("[kuryr-admin] invalid rule %q: ** must be the last segment", spec)
+			}
+			continue
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return Rule{}, fmt.Errorf("[kuryr-admin] invalid rule %q: %w", spec, err)
+		}
+	}
+	return rule, nil
+}
+
+// Rules 一组路径匹配规则。
+type Rules struct {
+	deny   []Rule
+	ignore []Rule
+}
+
+// Denied 判断请求是否命中 ! 开头的规则。
+func (r *Rules) Denied(method string, p string) bool {
+	return matchAny(r.deny, method, p)
+}
+
+// Ignored 判断请求是否命中普通规则。
+func (r *Rules) Ignored(method string, p string) bool {
+	return matchAny(r.ignore, method, p)
+}
+
+func matchAny(rules []Rule, method string, p string) bool {
+	for _, rule := range rules {
+		if rule.Match(method, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewRules(rules ...Rule) *Rules {
+	res := &Rules{}
+	for _, rule := range rules {
+		if rule.Deny {
+			res.deny = append(res.deny, rule)
+			continue
+		}
+		res.ignore = append(res.ignore, rule)
+	}
+	return res
+}
+
+// ParseRules 解析一组规则，返回所有解析错误。
+func ParseRules(specs []string) (*Rules, error) {
+	rules := make([]Rule, 0, len(specs))
+	var errs []error
+	for _, spec := range specs {
+		rule, err := ParseRule(spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return NewRules(rules...), nil
+}
+
+// split 将路径拆分为路径段，忽略多余的 / 以及末尾的 /
+func split(p string) []string {
+	p = path.Clean("/" + p)
+	if p == "/" {
+		return []string{}
+	}
+	return strings.Split(p[1:], "/")
+}
@@ -0,0 +1,184 @@
+package route
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		spec       string
+		wantErr    bool
+		wantDeny   bool
+		wantMethod string
+	}{
+		{name: "path only", spec: "/api/v1/user/login", wantMethod: "*"},
+		{name: "with method", spec: "post /api/v1/user/login", wantMethod: http.MethodPost},
+		{name: "any method", spec: "* /healthz", wantMethod: "*"},
+		{name: "deny", spec: "!GET /metrics", wantDeny: true, wantMethod: http.MethodGet},
+		{name: "prefix", spec: "/static/**", wantMethod: "*"},
+		{name: "unknown method", spec: "FETCH /healthz", wantErr: true},
+		{name: "relative path", spec: "healthz", wantErr: true},
+		{name: "** in the middle", spec: "/static/**/index.html", wantErr: true},
+		{name: "bad pattern", spec: "/static/[", wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := ParseRule(tc.spec)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDeny, rule.Deny)
+			assert.Equal(t, tc.wantMethod, rule.Method)
+		})
+	}
+}
+
+func TestRule_Match(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name   string
+		spec   string
+		method string
+		path   string
+		want   bool
+	}{
+		{name: "exact", spec: "/healthz", method: http.MethodGet, path: "/healthz", want: true},
+		{name: "trailing slash", spec: "/healthz", method: http.MethodGet, path: "/healthz/", want: true},
+		{name: "trailing slash in rule", spec: "/healthz/", method: http.MethodGet, path: "/healthz", want: true},
+		{name: "duplicate slash", spec: "/api/v1/user/login", method: http.MethodPost, path: "/api//v1/user/login", want: true},
+		{name: "exact not match sub path", spec: "/healthz", method: http.MethodGet, path: "/healthz/db", want: false},
+		{name: "single segment glob", spec: "/healthz/*", method: http.MethodGet, path: "/healthz/db", want: true},
+		{name: "single segment glob not match deeper", spec: "/healthz/*", method: http.MethodGet, path: "/healthz/db/ping", want: false},
+		{name: "single segment glob not match parent", spec: "/healthz/*", method: http.MethodGet, path: "/healthz", want: false},
+		{name: "glob in segment", spec: "/static/*.js", method: http.MethodGet, path: "/static/app.js", want: true},
+		{name: "glob in segment not match", spec: "/static/*.js", method: http.MethodGet, path: "/static/app.css", want: false},
+		{name: "versioned path", spec: "/api/v*/user/login", method: http.MethodPost, path: "/api/v2/user/login", want: true},
+		{name: "prefix match itself", spec: "/static/**", method: http.MethodGet, path: "/static", want: true},
+		{name: "prefix match deeper", spec: "/static/**", method: http.MethodGet, path: "/static/js/app.js", want: true},
+		{name: "prefix not match sibling", spec: "/static/**", method: http.MethodGet, path: "/statics/app.js", want: false},
+		{name: "dot segments are cleaned", spec: "/static/**", method: http.MethodGet, path: "/static/../api/v1/biz", want: false},
+		{name: "match all", spec: "/**", method: http.MethodGet, path: "/api/v1/biz", want: true},
+		{name: "method match", spec: "GET /healthz", method: http.MethodGet, path: "/healthz", want: true},
+		{name: "method not match", spec: "GET /healthz", method: http.MethodPost, path: "/healthz", want: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := ParseRule(tc.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, rule.Match(tc.method, tc.path))
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseRules([]string{"/healthz", "FETCH /a", "b"})
+	require.Error(t, err)
+	// 返回所有解析错误
+	assert.Contains(t, err.Error(), "unknown method FETCH")
+	assert.Contains(t, err.Error(), "pattern must start with /")
+}
+
+func TestMatcher_Skip(t *testing.T) {
+	t.Parallel()
+
+	public := NewSet()
+	public.Add(
+		Route{Method: http.MethodPost, Path: "/api/v1/user/login"},
+		Route{Method: http.MethodGet, Path: "/metrics"},
+		Route{Method: http.MethodGet, Path: "/api/v1/template/:id"},
+	)
+
+	tcs := []struct {
+		name     string
+		rules    []string
+		method   string
+		path     string
+		fullPath string
+		want     bool
+	}{
+		{
+			name:   "no rule requires token",
+			method: http.MethodGet, path: "/api/v1/biz", fullPath: "/api/v1/biz",
+			want: false,
+		}, {
+			name:   "public route",
+			method: http.MethodPost, path: "/api/v1/user/login", fullPath: "/api/v1/user/login",
+			want: true,
+		}, {
+			name:   "public route matches registered full path",
+			method: http.MethodGet, path: "/api/v1/template/1", fullPath: "/api/v1/template/:id",
+			want: true,
+		}, {
+			name:   "public route is method specific",
+			method: http.MethodGet, path: "/api/v1/user/login", fullPath: "",
+			want: false,
+		}, {
+			name:   "ignore rule",
+			rules:  []string{"/static/**"},
+			method: http.MethodGet, path: "/static/app.js", fullPath: "",
+			want: true,
+		}, {
+			name:   "deny rule overrides public route",
+			rules:  []string{"!/metrics"},
+			method: http.MethodGet, path: "/metrics", fullPath: "/metrics",
+			want: false,
+		}, {
+			name:   "deny rule overrides ignore rule",
+			rules:  []string{"/api/v1/**", "!POST /api/v1/biz/**"},
+			method: http.MethodPost, path: "/api/v1/biz/save", fullPath: "/api/v1/biz/save",
+			want: false,
+		}, {
+			name:   "deny rule is method specific",
+			rules:  []string{"/api/v1/**", "!POST /api/v1/biz/**"},
+			method: http.MethodGet, path: "/api/v1/biz/list", fullPath: "/api/v1/biz/list",
+			want: true,
+		}, {
+			name:   "public route wins over non matching deny rule",
+			rules:  []string{"!/api/v1/biz/**"},
+			method: http.MethodPost, path: "/api/v1/user/login", fullPath: "/api/v1/user/login",
+			want: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rules, err := ParseRules(tc.rules)
+			require.NoError(t, err)
+
+			m := NewMatcher(public, rules)
+			assert.Equal(t, tc.want, m.Skip(tc.method, tc.path, tc.fullPath))
+		})
+	}
+}
+
+func TestMatcher_SetRules(t *testing.T) {
+	t.Parallel()
+
+	m := NewMatcher(nil, nil)
+	assert.False(t, m.Skip(http.MethodGet, "/static/app.js", ""))
+
+	rules, err := ParseRules([]string{"/static/**"})
+	require.NoError(t, err)
+	m.SetRules(rules)
+	assert.True(t, m.Skip(http.MethodGet, "/static/app.js", ""))
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+	"github.com/JrMarcco/kuryr-admin/internal/search"
+)
+
+type AuditLogRepo interface {
+	Save(ctx context.Context, l domain.AuditLog) (domain.AuditLog, error)
+
+	Search(ctx context.Context, criteria search.AuditCriteria, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[domain.AuditLog], error)
+	FindInBatches(ctx context.Context, criteria search.AuditCriteria, batchSize int, fn func([]domain.AuditLog) error) error
+}
+
+var _ AuditLogRepo = (*DefaultAuditLogRepo)(nil)
+
+type DefaultAuditLogRepo struct {
+	dao dao.AuditLogDao
+}
+
+func (r *DefaultAuditLogRepo) Save(ctx context.Context, l domain.AuditLog) (domain.AuditLog, error) {
+	el, err := r.dao.Insert(ctx, dao.AuditLog{
+		ActorUid:   l.ActorUid,
+		ActorBid:   l.ActorBid,
+		ActorSid:   l.ActorSid,
+		Action:     string(l.Action),
+		TargetType: string(l.TargetType),
+		TargetId:   l.TargetId,
+		Diff:       string(l.Diff),
+		ClientIp:   l.ClientIp,
+		RequestId:  l.RequestId,
+	})
+	if err != nil {
+		return domain.AuditLog{}, err
+	}
+	return r.toDomain(el), nil
+}
+
+func (r *DefaultAuditLogRepo) Search(
+	ctx context.Context, criteria search.AuditCriteria, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[domain.AuditLog], error) {
+	res, err := r.dao.Search(ctx, criteria, param)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.AuditLog, 0, len(res.Records))
+	for _, el := range res.Records {
+		records = append(records, r.toDomain(el))
+	}
+	return pkggorm.NewPaginationResult(records, res.Total), nil
+}
+
+func (r *DefaultAuditLogRepo) FindInBatches(
+	ctx context.Context, criteria search.AuditCriteria, batchSize int, fn func([]domain.AuditLog) error,
+) error {
+	return r.dao.FindInBatches(ctx, criteria, batchSize, func(batch []dao.AuditLog) error {
+		records := make([]domain.AuditLog, 0, len(batch))
+		for _, el := range batch {
+			records = append(records, r.toDomain(el))
+		}
+		return fn(records)
+	})
+}
+
+func (r *DefaultAuditLogRepo) toDomain(el dao.AuditLog) domain.AuditLog {
+	return domain.AuditLog{
+		Id:         el.Id,
+		ActorUid:   el.ActorUid,
+		ActorBid:   el.ActorBid,
+		ActorSid:   el.ActorSid,
+		Action:     domain.AuditAction(el.Action),
+		TargetType: domain.AuditTarget(el.TargetType),
+		TargetId:   el.TargetId,
+		Diff:       json.RawMessage(el.Diff),
+		ClientIp:   el.ClientIp,
+		RequestId:  el.RequestId,
+		CreatedAt:  el.CreatedAt,
+	}
+}
+
+func NewAuditLogRepo(dao dao.AuditLogDao) *DefaultAuditLogRepo {
+	return &DefaultAuditLogRepo{dao: dao}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+	"gorm.io/gorm"
+)
+
+// BizConfigPresetRepo 业务方配置预设 repo，查询不存在的记录时返回 errs.ErrRecordNotFound。
+type BizConfigPresetRepo interface {
+	Save(ctx context.Context, p domain.BizConfigPreset) (domain.BizConfigPreset, error)
+	Delete(ctx context.Context, id uint64) error
+
+	List(ctx context.Context, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[domain.BizConfigPreset], error)
+	FindById(ctx context.Context, id uint64) (domain.BizConfigPreset, error)
+	FindByName(ctx context.Context, name string) (domain.BizConfigPreset, error)
+	FindDefault(ctx context.Context) (domain.BizConfigPreset, error)
+
+	SaveBinding(ctx context.Context, b domain.BizConfigPresetBinding) (domain.BizConfigPresetBinding, error)
+	FindBinding(ctx context.Context, bizId uint64) (domain.BizConfigPresetBinding, error)
+}
+
+var _ BizConfigPresetRepo = (*DefaultBizConfigPresetRepo)(nil)
+
+type DefaultBizConfigPresetRepo struct {
+	dao dao.BizConfigPresetDao
+}
+
+// presetConfig 预设内的配置，以 json 保存
+type presetConfig struct {
+	ChannelConfig *domain.ChannelConfig `json:"channel_config"`
+	QuotaConfig   *domain.QuotaConfig   `json:"quota_config"`
+	RateLimit     int32                 `json:"rate_limit"`
+}
+
+func (r *DefaultBizConfigPresetRepo) Save(ctx context.Context, p domain.BizConfigPreset) (domain.BizConfigPreset, error) {
+	config, err := json.Marshal(presetConfig{
+		ChannelConfig: p.ChannelConfig,
+		QuotaConfig:   p.QuotaConfig,
+		RateLimit:     p.RateLimit,
+	})
+	if err != nil {
+		return domain.BizConfigPreset{}, fmt.Errorf("[kuryr-admin] failed to marshal biz config preset: %w", err)
+	}
+
+	ep, err := r.dao.Save(ctx, dao.BizConfigPreset{
+		Id:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Config:      string(config),
+		IsDefault:   p.IsDefault,
+	})
+	if err != nil {
+		return domain.BizConfigPreset{}, notFound(err)
+	}
+	return r.toDomain(ep)
+}
+
+func (r *DefaultBizConfigPresetRepo) Delete(ctx context.Context, id uint64) error {
+	return r.dao.Delete(ctx, id)
+}
+
+func (r *DefaultBizConfigPresetRepo) List(
+	ctx context.Context, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[domain.BizConfigPreset], error) {
+	res, err := r.dao.List(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.BizConfigPreset, 0, len(res.Records))
+	for _, ep := range res.Records {
+		p, err := r.toDomain(ep)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, p)
+	}
+	return pkggorm.NewPaginationResult(records, res.Total), nil
+}
+
+func (r *DefaultBizConfigPresetRepo) FindById(ctx context.Context, id uint64) (domain.BizConfigPreset, error) {
+	ep, err := r.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.BizConfigPreset{}, notFound(err)
+	}
+	return r.toDomain(ep)
+}
+
+func (r *DefaultBizConfigPresetRepo) FindByName(ctx context.Context, name string) (domain.BizConfigPreset, error) {
+	ep, err := r.dao.FindByName(ctx, name)
+	if err != nil {
+		return domain.BizConfigPreset{}, notFound(err)
+	}
+	return r.toDomain(ep)
+}
+
+func (r *DefaultBizConfigPresetRepo) FindDefault(ctx context.Context) (domain.BizConfigPreset, error) {
+	ep, err := r.dao.FindDefault(ctx)
+	if err != nil {
+		return domain.BizConfigPreset{}, notFound(err)
+	}
+	return r.toDomain(ep)
+}
+
+func (r *DefaultBizConfigPresetRepo) SaveBinding(
+	ctx context.Context, b domain.BizConfigPresetBinding,
+) (domain.BizConfigPresetBinding, error) {
+	b.AppliedAt = time.Now().UnixMilli()
+	err := r.dao.SaveBinding(ctx, dao.BizConfigPresetBinding{
+		BizId:     b.BizId,
+		PresetId:  b.PresetId,
+		AppliedBy: b.AppliedBy,
+		AppliedAt: b.AppliedAt,
+	})
+	if err != nil {
+		return domain.BizConfigPresetBinding{}, err
+	}
+	return b, nil
+}
+
+func (r *DefaultBizConfigPresetRepo) FindBinding(ctx context.Context, bizId uint64) (domain.BizConfigPresetBinding, error) {
+	eb, err := r.dao.FindBinding(ctx, bizId)
+	if err != nil {
+		return domain.BizConfigPresetBinding{}, notFound(err)
+	}
+	return domain.BizConfigPresetBinding{
+		BizId:     eb.BizId,
+		PresetId:  eb.PresetId,
+		AppliedBy: eb.AppliedBy,
+		AppliedAt: eb.AppliedAt,
+	}, nil
+}
+
+func (r *DefaultBizConfigPresetRepo) toDomain(ep dao.BizConfigPreset) (domain.BizConfigPreset, error) {
+	var config presetConfig
+	if err := json.Unmarshal([]byte(ep.Config), &config); err != nil {
+		return domain.BizConfigPreset{}, fmt.Errorf("[kuryr-admin] failed to unmarshal biz config preset %d: %w", ep.Id, err)
+	}
+
+	return domain.BizConfigPreset{
+		Id:            ep.Id,
+		Name:          ep.Name,
+		Description:   ep.Description,
+		ChannelConfig: config.ChannelConfig,
+		QuotaConfig:   config.QuotaConfig,
+		RateLimit:     config.RateLimit,
+		IsDefault:     ep.IsDefault,
+		CreatedAt:     ep.CreatedAt,
+		UpdatedAt:     ep.UpdatedAt,
+	}, nil
+}
+
+// notFound 将 gorm.ErrRecordNotFound 转换为 errs.ErrRecordNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errs.ErrRecordNotFound
+	}
+	return err
+}
+
+func NewBizConfigPresetRepo(dao dao.BizConfigPresetDao) *DefaultBizConfigPresetRepo {
+	return &DefaultBizConfigPresetRepo{dao: dao}
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+	"gorm.io/gorm"
+)
+
+type BizConfigVersionRepo interface {
+	Save(ctx context.Context, v domain.BizConfigVersion) (domain.BizConfigVersion, error)
+
+	Search(ctx context.Context, bizId uint64, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[domain.BizConfigVersion], error)
+	// FindByVersion 查询指定版本，版本不存在时返回 errs.ErrRecordNotFound
+	FindByVersion(ctx context.Context, bizId uint64, version uint64) (domain.BizConfigVersion, error)
+	Exists(ctx context.Context, bizId uint64) (bool, error)
+}
+
+var _ BizConfigVersionRepo = (*DefaultBizConfigVersionRepo)(nil)
+
+type DefaultBizConfigVersionRepo struct {
+	dao dao.BizConfigVersionDao
+}
+
+func (r *DefaultBizConfigVersionRepo) Save(ctx context.Context, v domain.BizConfigVersion) (domain.BizConfigVersion, error) {
+	snapshot, err := json.Marshal(v.Config)
+	if err != nil {
+		return domain.BizConfigVersion{}, fmt.Errorf("[kuryr-admin] failed to marshal biz config snapshot: %w", err)
+	}
+
+	ev, err := r.dao.Insert(ctx, dao.BizConfigVersion{
+		BizId:        v.BizId,
+		Snapshot:     string(snapshot),
+		AuthorId:     v.AuthorId,
+		Comment:      v.Comment,
+		RollbackFrom: v.RollbackFrom,
+	})
+	if err != nil {
+		return domain.BizConfigVersion{}, err
+	}
+	return r.toDomain(ev)
+}
+
+func (r *DefaultBizConfigVersionRepo) Search(
+	ctx context.Context, bizId uint64, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[domain.BizConfigVersion], error) {
+	res, err := r.dao.Search(ctx, bizId, param)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.BizConfigVersion, 0, len(res.Records))
+	for _, ev := range res.Records {
+		v, err := r.toDomain(ev)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, v)
+	}
+	return pkggorm.NewPaginationResult(records, res.Total), nil
+}
+
+func (r *DefaultBizConfigVersionRepo) FindByVersion(ctx context.Context, bizId uint64, version uint64) (domain.BizConfigVersion, error) {
+	ev, err := r.dao.FindByVersion(ctx, bizId, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.BizConfigVersion{}, errs.ErrRecordNotFound
+		}
+		return domain.BizConfigVersion{}, err
+	}
+	return r.toDomain(ev)
+}
+
+func (r *DefaultBizConfigVersionRepo) Exists(ctx context.Context, bizId uint64) (bool, error) {
+	return r.dao.Exists(ctx, bizId)
+}
+
+func (r *DefaultBizConfigVersionRepo) toDomain(ev dao.BizConfigVersion) (domain.BizConfigVersion, error) {
+	var cfg domain.BizConfig
+	if err := json.Unmarshal([]byte(ev.Snapshot), &cfg); err != nil {
+		return domain.BizConfigVersion{}, fmt.Errorf("[kuryr-admin] failed to unmarshal biz config snapshot of version %d: %w", ev.Id, err)
+	}
+
+	return domain.BizConfigVersion{
+		Id:           ev.Id,
+		BizId:        ev.BizId,
+		Version:      ev.Version,
+		Config:       cfg,
+		AuthorId:     ev.AuthorId,
+		Comment:      ev.Comment,
+		RollbackFrom: ev.RollbackFrom,
+		CreatedAt:    ev.CreatedAt,
+	}, nil
+}
+
+func NewBizConfigVersionRepo(dao dao.BizConfigVersionDao) *DefaultBizConfigVersionRepo {
+	return &DefaultBizConfigVersionRepo{dao: dao}
+}
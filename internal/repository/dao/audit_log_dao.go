@@ -0,0 +1,99 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/search"
+	"gorm.io/gorm"
+)
+
+type AuditLog struct {
+	Id         uint64 `gorm:"column:id"`
+	ActorUid   uint64 `gorm:"column:actor_uid"`
+	ActorBid   uint64 `gorm:"column:actor_bid"`
+	ActorSid   string `gorm:"column:actor_sid"`
+	Action     string `gorm:"column:action"`
+	TargetType string `gorm:"column:target_type"`
+	TargetId   uint64 `gorm:"column:target_id"`
+	Diff       string `gorm:"column:diff"`
+	ClientIp   string `gorm:"column:client_ip"`
+	RequestId  string `gorm:"column:request_id"`
+	CreatedAt  int64  `gorm:"column:created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+// AuditLogDao 审计日志 dao，审计日志只允许追加写入，不提供更新与删除。
+type AuditLogDao interface {
+	Insert(ctx context.Context, l AuditLog) (AuditLog, error)
+
+	// Search 按时间倒序分页查询
+	Search(ctx context.Context, criteria search.AuditCriteria, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[AuditLog], error)
+	// FindInBatches 按 id 顺序分批查询所有匹配的审计日志，fn 返回错误时停止查询
+	FindInBatches(ctx context.Context, criteria search.AuditCriteria, batchSize int, fn func([]AuditLog) error) error
+}
+
+var _ AuditLogDao = (*DefaultAuditLogDao)(nil)
+
+type DefaultAuditLogDao struct {
+	db *gorm.DB
+}
+
+func (d *DefaultAuditLogDao) Insert(ctx context.Context, l AuditLog) (AuditLog, error) {
+	l.CreatedAt = time.Now().UnixMilli()
+
+	if err := d.db.WithContext(ctx).Create(&l).Error; err != nil {
+		return AuditLog{}, err
+	}
+	return l, nil
+}
+
+func (d *DefaultAuditLogDao) Search(
+	ctx context.Context, criteria search.AuditCriteria, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[AuditLog], error) {
+	db := d.where(d.db.WithContext(ctx).Model(&AuditLog{}), criteria).Order("id DESC")
+	return pkggorm.Pagination(db, param, []AuditLog(nil))
+}
+
+func (d *DefaultAuditLogDao) FindInBatches(
+	ctx context.Context, criteria search.AuditCriteria, batchSize int, fn func([]AuditLog) error,
+) error {
+	var batch []AuditLog
+	return d.where(d.db.WithContext(ctx).Model(&AuditLog{}), criteria).
+		FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+func (d *DefaultAuditLogDao) where(db *gorm.DB, criteria search.AuditCriteria) *gorm.DB {
+	if criteria.ActorUid != 0 {
+		db = db.Where("actor_uid = ?", criteria.ActorUid)
+	}
+	if criteria.ActorBid != 0 {
+		db = db.Where("actor_bid = ?", criteria.ActorBid)
+	}
+	if criteria.Action != "" {
+		db = db.Where("action = ?", criteria.Action)
+	}
+	if criteria.TargetType != "" {
+		db = db.Where("target_type = ?", criteria.TargetType)
+	}
+	if criteria.TargetId != 0 {
+		db = db.Where("target_id = ?", criteria.TargetId)
+	}
+	if criteria.StartTime != 0 {
+		db = db.Where("created_at >= ?", criteria.StartTime)
+	}
+	if criteria.EndTime != 0 {
+		db = db.Where("created_at < ?", criteria.EndTime)
+	}
+	return db
+}
+
+func NewAuditLogDao(db *gorm.DB) *DefaultAuditLogDao {
+	return &DefaultAuditLogDao{db: db}
+}
@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BizConfigPreset struct {
+	Id          uint64 `gorm:"column:id"`
+	Name        string `gorm:"column:name"`
+	Description string `gorm:"column:description"`
+	Config      string `gorm:"column:config"`
+	IsDefault   bool   `gorm:"column:is_default"`
+	CreatedAt   int64  `gorm:"column:created_at"`
+	UpdatedAt   int64  `gorm:"column:updated_at"`
+}
+
+func (BizConfigPreset) TableName() string {
+	return "biz_config_preset"
+}
+
+type BizConfigPresetBinding struct {
+	BizId     uint64 `gorm:"column:biz_id;primaryKey"`
+	PresetId  uint64 `gorm:"column:preset_id"`
+	AppliedBy uint64 `gorm:"column:applied_by"`
+	AppliedAt int64  `gorm:"column:applied_at"`
+}
+
+func (BizConfigPresetBinding) TableName() string {
+	return "biz_config_preset_binding"
+}
+
+// BizConfigPresetDao 业务方配置预设 dao。
+type BizConfigPresetDao interface {
+	// Save 保存预设，id 为 0 时新建，预设为默认预设时取消其他预设的默认标记
+	Save(ctx context.Context, p BizConfigPreset) (BizConfigPreset, error)
+	// Delete 删除预设以及基于该预设的绑定关系
+	Delete(ctx context.Context, id uint64) error
+
+	List(ctx context.Context, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[BizConfigPreset], error)
+	FindById(ctx context.Context, id uint64) (BizConfigPreset, error)
+	FindByName(ctx context.Context, name string) (BizConfigPreset, error)
+	FindDefault(ctx context.Context) (BizConfigPreset, error)
+
+	// SaveBinding 保存业务方绑定的预设，业务方已绑定预设时覆盖
+	SaveBinding(ctx context.Context, b BizConfigPresetBinding) error
+	FindBinding(ctx context.Context, bizId uint64) (BizConfigPresetBinding, error)
+}
+
+var _ BizConfigPresetDao = (*DefaultBizConfigPresetDao)(nil)
+
+type DefaultBizConfigPresetDao struct {
+	db *gorm.DB
+}
+
+func (d *DefaultBizConfigPresetDao) Save(ctx context.Context, p BizConfigPreset) (BizConfigPreset, error) {
+	now := time.Now().UnixMilli()
+	p.UpdatedAt = now
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先取消其他预设的默认标记，保证任意时刻最多只有一个默认预设
+		if p.IsDefault {
+			err := tx.Model(&BizConfigPreset{}).
+				Where("id <> ? AND is_default = ?", p.Id, true).
+				Updates(map[string]any{
+					"is_default": false,
+					"updated_at": now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if p.Id == 0 {
+			p.CreatedAt = now
+			return tx.Create(&p).Error
+		}
+
+		res := tx.Model(&BizConfigPreset{}).
+			Where("id = ?", p.Id).
+			Updates(map[string]any{
+				"name":        p.Name,
+				"description": p.Description,
+				"config":      p.Config,
+				"is_default":  p.IsDefault,
+				"updated_at":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return BizConfigPreset{}, err
+	}
+	return d.FindById(ctx, p.Id)
+}
+
+func (d *DefaultBizConfigPresetDao) Delete(ctx context.Context, id uint64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("preset_id = ?", id).Delete(&BizConfigPresetBinding{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&BizConfigPreset{}).Error
+	})
+}
+
+func (d *DefaultBizConfigPresetDao) List(
+	ctx context.Context, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[BizConfigPreset], error) {
+	db := d.db.WithContext(ctx).Model(&BizConfigPreset{}).Order("id ASC")
+	return pkggorm.Pagination(db, param, []BizConfigPreset(nil))
+}
+
+func (d *DefaultBizConfigPresetDao) FindById(ctx context.Context, id uint64) (BizConfigPreset, error) {
+	var p BizConfigPreset
+	err := d.db.WithContext(ctx).Model(&BizConfigPreset{}).
+		Where("id = ?", id).
+		First(&p).Error
+	return p, err
+}
+
+func (d *DefaultBizConfigPresetDao) FindByName(ctx context.Context, name string) (BizConfigPreset, error) {
+	var p BizConfigPreset
+	err := d.db.WithContext(ctx).Model(&BizConfigPreset{}).
+		Where("name = ?", name).
+		First(&p).Error
+	return p, err
+}
+
+func (d *DefaultBizConfigPresetDao) FindDefault(ctx context.Context) (BizConfigPreset, error) {
+	var p BizConfigPreset
+	err := d.db.WithContext(ctx).Model(&BizConfigPreset{}).
+		Where("is_default = ?", true).
+		First(&p).Error
+	return p, err
+}
+
+func (d *DefaultBizConfigPresetDao) SaveBinding(ctx context.Context, b BizConfigPresetBinding) error {
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "biz_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"preset_id", "applied_by", "applied_at"}),
+	}).Create(&b).Error
+}
+
+func (d *DefaultBizConfigPresetDao) FindBinding(ctx context.Context, bizId uint64) (BizConfigPresetBinding, error) {
+	var b BizConfigPresetBinding
+	err := d.db.WithContext(ctx).Model(&BizConfigPresetBinding{}).
+		Where("biz_id = ?", bizId).
+		First(&b).Error
+	return b, err
+}
+
+func NewBizConfigPresetDao(db *gorm.DB) *DefaultBizConfigPresetDao {
+	return &DefaultBizConfigPresetDao{db: db}
+}
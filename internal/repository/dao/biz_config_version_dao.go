@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"gorm.io/gorm"
+)
+
+// insertVersionRetries 并发保存同一业务方配置时版本号冲突的重试次数
+const insertVersionRetries = 3
+
+type BizConfigVersion struct {
+	Id           uint64 `gorm:"column:id"`
+	BizId        uint64 `gorm:"column:biz_id"`
+	Version      uint64 `gorm:"column:version"`
+	Snapshot     string `gorm:"column:snapshot"`
+	AuthorId     uint64 `gorm:"column:author_id"`
+	Comment      string `gorm:"column:comment"`
+	RollbackFrom uint64 `gorm:"column:rollback_from"`
+	CreatedAt    int64  `gorm:"column:created_at"`
+}
+
+func (BizConfigVersion) TableName() string {
+	return "biz_config_version"
+}
+
+// BizConfigVersionDao 业务方配置版本 dao，版本只允许追加写入。
+type BizConfigVersionDao interface {
+	// Insert 写入新版本，版本号为该业务方当前最大版本号加 1
+	Insert(ctx context.Context, v BizConfigVersion) (BizConfigVersion, error)
+
+	// Search 按版本号倒序分页查询
+	Search(ctx context.Context, bizId uint64, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[BizConfigVersion], error)
+	FindByVersion(ctx context.Context, bizId uint64, version uint64) (BizConfigVersion, error)
+	// Exists 业务方是否存在配置版本
+	Exists(ctx context.Context, bizId uint64) (bool, error)
+}
+
+var _ BizConfigVersionDao = (*DefaultBizConfigVersionDao)(nil)
+
+type DefaultBizConfigVersionDao struct {
+	db *gorm.DB
+}
+
+func (d *DefaultBizConfigVersionDao) Insert(ctx context.Context, v BizConfigVersion) (BizConfigVersion, error) {
+	v.CreatedAt = time.Now().UnixMilli()
+
+	var err error
+	for range insertVersionRetries {
+		var latest uint64
+		err = d.db.WithContext(ctx).Model(&BizConfigVersion{}).
+			Select("COALESCE(MAX(version), 0)").
+			Where("biz_id = ?", v.BizId).
+			Scan(&latest).Error
+		if err != nil {
+			return BizConfigVersion{}, err
+		}
+
+		v.Id = 0
+		v.Version = latest + 1
+		if err = d.db.WithContext(ctx).Create(&v).Error; err == nil {
+			return v, nil
+		}
+
+		// 版本号已被并发写入占用时重新计算版本号，其他错误直接返回
+		if _, findErr := d.FindByVersion(ctx, v.BizId, v.Version); findErr != nil {
+			return BizConfigVersion{}, err
+		}
+	}
+	return BizConfigVersion{}, err
+}
+
+func (d *DefaultBizConfigVersionDao) Search(
+	ctx context.Context, bizId uint64, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[BizConfigVersion], error) {
+	db := d.db.WithContext(ctx).Model(&BizConfigVersion{}).
+		Where("biz_id = ?", bizId).
+		Order("version DESC")
+	return pkggorm.Pagination(db, param, []BizConfigVersion(nil))
+}
+
+func (d *DefaultBizConfigVersionDao) FindByVersion(ctx context.Context, bizId uint64, version uint64) (BizConfigVersion, error) {
+	var v BizConfigVersion
+	err := d.db.WithContext(ctx).Model(&BizConfigVersion{}).
+		Where("biz_id = ? AND version = ?", bizId, version).
+		First(&v).Error
+	return v, err
+}
+
+func (d *DefaultBizConfigVersionDao) Exists(ctx context.Context, bizId uint64) (bool, error) {
+	var v BizConfigVersion
+	err := d.db.WithContext(ctx).Model(&BizConfigVersion{}).
+		Select("id").
+		Where("biz_id = ?", bizId).
+		Take(&v).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func NewBizConfigVersionDao(db *gorm.DB) *DefaultBizConfigVersionDao {
+	return &DefaultBizConfigVersionDao{db: db}
+}
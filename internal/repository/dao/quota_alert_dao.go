@@ -0,0 +1,124 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuotaAlertRule struct {
+	BizId      uint64 `gorm:"column:biz_id;primaryKey"`
+	Channel    string `gorm:"column:channel;primaryKey"`
+	Thresholds string `gorm:"column:thresholds"`
+	Enabled    bool   `gorm:"column:enabled"`
+	CreatedAt  int64  `gorm:"column:created_at"`
+	UpdatedAt  int64  `gorm:"column:updated_at"`
+}
+
+func (QuotaAlertRule) TableName() string {
+	return "quota_alert_rule"
+}
+
+// QuotaAlertRecord 已发送的配额告警，主键保证同一周期内每个阈值只告警一次
+type QuotaAlertRecord struct {
+	BizId      uint64 `gorm:"column:biz_id;primaryKey"`
+	Channel    string `gorm:"column:channel;primaryKey"`
+	Period     string `gorm:"column:period;primaryKey"`
+	PeriodKey  string `gorm:"column:period_key;primaryKey"`
+	Threshold  int32  `gorm:"column:threshold;primaryKey"`
+	Used       int32  `gorm:"column:used"`
+	QuotaLimit int32  `gorm:"column:quota_limit"`
+	CreatedAt  int64  `gorm:"column:created_at"`
+}
+
+func (QuotaAlertRecord) TableName() string {
+	return "quota_alert_record"
+}
+
+// QuotaAlertDao 配额告警 dao。
+type QuotaAlertDao interface {
+	// SaveRule 保存告警阈值，业务方渠道已有阈值时覆盖
+	SaveRule(ctx context.Context, r QuotaAlertRule) (QuotaAlertRule, error)
+	DeleteRule(ctx context.Context, bizId uint64, channel string) error
+	FindRules(ctx context.Context, bizId uint64) ([]QuotaAlertRule, error)
+	FindEnabledRules(ctx context.Context) ([]QuotaAlertRule, error)
+
+	// SaveRecord 记录告警，同一周期内的阈值已经告警过时返回 false
+	SaveRecord(ctx context.Context, r QuotaAlertRecord) (bool, error)
+	DeleteRecord(ctx context.Context, r QuotaAlertRecord) error
+}
+
+var _ QuotaAlertDao = (*DefaultQuotaAlertDao)(nil)
+
+type DefaultQuotaAlertDao struct {
+	db *gorm.DB
+}
+
+func (d *DefaultQuotaAlertDao) SaveRule(ctx context.Context, r QuotaAlertRule) (QuotaAlertRule, error) {
+	now := time.Now().UnixMilli()
+	r.CreatedAt = now
+	r.UpdatedAt = now
+
+	err := d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "biz_id"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"thresholds", "enabled", "updated_at"}),
+	}).Create(&r).Error
+	if err != nil {
+		return QuotaAlertRule{}, err
+	}
+
+	// 覆盖已有阈值时 created_at 保持不变，这里重新查询
+	var saved QuotaAlertRule
+	err = d.db.WithContext(ctx).Model(&QuotaAlertRule{}).
+		Where("biz_id = ? AND channel = ?", r.BizId, r.Channel).
+		First(&saved).Error
+	return saved, err
+}
+
+func (d *DefaultQuotaAlertDao) DeleteRule(ctx context.Context, bizId uint64, channel string) error {
+	return d.db.WithContext(ctx).
+		Where("biz_id = ? AND channel = ?", bizId, channel).
+		Delete(&QuotaAlertRule{}).Error
+}
+
+func (d *DefaultQuotaAlertDao) FindRules(ctx context.Context, bizId uint64) ([]QuotaAlertRule, error) {
+	var rules []QuotaAlertRule
+	err := d.db.WithContext(ctx).Model(&QuotaAlertRule{}).
+		Where("biz_id = ?", bizId).
+		Order("channel ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (d *DefaultQuotaAlertDao) FindEnabledRules(ctx context.Context) ([]QuotaAlertRule, error) {
+	var rules []QuotaAlertRule
+	err := d.db.WithContext(ctx).Model(&QuotaAlertRule{}).
+		Where("enabled = ?", true).
+		Order("biz_id ASC, channel ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (d *DefaultQuotaAlertDao) SaveRecord(ctx context.Context, r QuotaAlertRecord) (bool, error) {
+	r.CreatedAt = time.Now().UnixMilli()
+
+	// 多个实例同时检查时只有一个实例可以写入成功
+	res := d.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&r)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (d *DefaultQuotaAlertDao) DeleteRecord(ctx context.Context, r QuotaAlertRecord) error {
+	return d.db.WithContext(ctx).
+		Where("biz_id = ? AND channel = ? AND period = ? AND period_key = ? AND threshold = ?",
+			r.BizId, r.Channel, r.Period, r.PeriodKey, r.Threshold).
+		Delete(&QuotaAlertRecord{}).Error
+}
+
+func NewQuotaAlertDao(db *gorm.DB) *DefaultQuotaAlertDao {
+	return &DefaultQuotaAlertDao{db: db}
+}
@@ -30,6 +30,7 @@ type UserDao interface {
 	FindByBizId(ctx context.Context, bizId uint64) (SysUser, error)
 	FindByEmail(ctx context.Context, email string) (SysUser, error)
 	FindByMobile(ctx context.Context, mobile string) (SysUser, error)
+	FindByUserType(ctx context.Context, userType string) ([]SysUser, error)
 }
 
 var _ UserDao = (*DefaultUserDao)(nil)
@@ -90,6 +91,15 @@ func (d *DefaultUserDao) FindByMobile(ctx context.Context, mobile string) (SysUs
 	return su, err
 }
 
+func (d *DefaultUserDao) FindByUserType(ctx context.Context, userType string) ([]SysUser, error) {
+	var users []SysUser
+	err := d.db.WithContext(ctx).Model(&SysUser{}).
+		Where("user_type = ?", userType).
+		Order("id ASC").
+		Find(&users).Error
+	return users, err
+}
+
 func NewUserDAO(db *gorm.DB) *DefaultUserDao {
 	return &DefaultUserDao{db: db}
 }
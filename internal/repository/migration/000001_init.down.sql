@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sys_user;
+DROP TYPE IF EXISTS biz_type_enum;
+DROP TYPE IF EXISTS user_type_enum;
@@ -1,6 +1,19 @@
+-- 初始表结构
+-- 兼容执行迁移前通过 scripts/sql 手动创建的表结构，已存在的对象不会重复创建
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'user_type_enum') THEN
+        CREATE TYPE user_type_enum AS ENUM ('administrator', 'operator');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'biz_type_enum') THEN
+        CREATE TYPE biz_type_enum AS ENUM ('individual', 'organization');
+    END IF;
+END
+$$;
+
 -- 系统用户信息表
-DROP TABLE IF EXISTS sys_user;
-CREATE TABLE sys_user (
+CREATE TABLE IF NOT EXISTS sys_user (
     id BIGSERIAL PRIMARY KEY,
     email VARCHAR(128) NOT NULL,
     password VARCHAR(128) NOT NULL,
@@ -21,22 +34,3 @@ COMMENT ON COLUMN sys_user.user_type IS '用户类型: administrator-系统管
 COMMENT ON COLUMN sys_user.biz_id IS '关联的业务方 id, 当 user_type 为 operator 时不应为 0';
 COMMENT ON COLUMN sys_user.created_at IS '创建时间戳 ( Unix 毫秒值 )';
 COMMENT ON COLUMN sys_user.updated_at IS '更新时间戳 ( Unix 毫秒值 )';
-
--- 插入初始用户数据
-INSERT INTO sys_user (
-    email,
-    password,
-    real_name,
-    user_type,
-    biz_id,
-    created_at,
-    updated_at
-) VALUES (
-    'jrmarcco@gmail.com',
-    '$2a$10$besICPqbCRWOocqlsaKXV.rniGRyCNPLHeFT.osXbhgisW4XSW/um',
-    'jrmarcco',
-    'administrator',
-    0,
-    EXTRACT(EPOCH FROM NOW()) * 1000,
-    EXTRACT(EPOCH FROM NOW()) * 1000
-);
@@ -0,0 +1,46 @@
+-- 审计日志表，只允许追加写入
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_uid BIGINT NOT NULL,
+    actor_bid BIGINT NOT NULL DEFAULT 0,
+    actor_sid VARCHAR(64) NOT NULL DEFAULT '',
+    action VARCHAR(32) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id BIGINT NOT NULL DEFAULT 0,
+    diff JSONB NOT NULL,
+    client_ip VARCHAR(64) NOT NULL DEFAULT '',
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor_uid, created_at);
+CREATE INDEX idx_audit_log_target ON audit_log (target_type, target_id, created_at);
+
+COMMENT ON TABLE audit_log IS '审计日志表';
+COMMENT ON COLUMN audit_log.id IS 'id';
+COMMENT ON COLUMN audit_log.actor_uid IS '操作人 user id';
+COMMENT ON COLUMN audit_log.actor_bid IS '操作人 biz id';
+COMMENT ON COLUMN audit_log.actor_sid IS '操作人 session id';
+COMMENT ON COLUMN audit_log.action IS '操作类型';
+COMMENT ON COLUMN audit_log.target_type IS '操作对象类型';
+COMMENT ON COLUMN audit_log.target_id IS '操作对象 id';
+COMMENT ON COLUMN audit_log.diff IS '变更字段，敏感字段已脱敏';
+COMMENT ON COLUMN audit_log.client_ip IS '客户端 ip';
+COMMENT ON COLUMN audit_log.request_id IS 'request id';
+COMMENT ON COLUMN audit_log.created_at IS '创建时间';
+
+-- 禁止修改与删除审计日志
+CREATE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER trg_audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS biz_config_version;
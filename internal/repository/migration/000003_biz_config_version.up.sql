@@ -0,0 +1,23 @@
+-- 业务方配置版本表，每次保存配置都会写入一个完整快照
+CREATE TABLE biz_config_version (
+    id BIGSERIAL PRIMARY KEY,
+    biz_id BIGINT NOT NULL,
+    version BIGINT NOT NULL,
+    snapshot JSONB NOT NULL,
+    author_id BIGINT NOT NULL DEFAULT 0,
+    comment VARCHAR(256) NOT NULL DEFAULT '',
+    rollback_from BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX uk_biz_config_version ON biz_config_version (biz_id, version);
+
+COMMENT ON TABLE biz_config_version IS '业务方配置版本表';
+COMMENT ON COLUMN biz_config_version.id IS 'id';
+COMMENT ON COLUMN biz_config_version.biz_id IS '业务方 id';
+COMMENT ON COLUMN biz_config_version.version IS '版本号，同一业务方内从 1 开始递增';
+COMMENT ON COLUMN biz_config_version.snapshot IS '配置快照';
+COMMENT ON COLUMN biz_config_version.author_id IS '操作人 user id，0 表示系统生成';
+COMMENT ON COLUMN biz_config_version.comment IS '变更说明';
+COMMENT ON COLUMN biz_config_version.rollback_from IS '回滚来源版本号，0 表示非回滚';
+COMMENT ON COLUMN biz_config_version.created_at IS '创建时间';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS biz_config_preset_binding;
+DROP TABLE IF EXISTS biz_config_preset;
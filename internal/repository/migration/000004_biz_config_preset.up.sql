@@ -0,0 +1,39 @@
+-- 业务方配置预设表
+CREATE TABLE biz_config_preset (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    description VARCHAR(256) NOT NULL DEFAULT '',
+    config JSONB NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX uk_biz_config_preset_name ON biz_config_preset (name);
+-- 最多只有一个默认预设
+CREATE UNIQUE INDEX uk_biz_config_preset_default ON biz_config_preset (is_default) WHERE is_default;
+
+COMMENT ON TABLE biz_config_preset IS '业务方配置预设表';
+COMMENT ON COLUMN biz_config_preset.id IS 'id';
+COMMENT ON COLUMN biz_config_preset.name IS '预设名称';
+COMMENT ON COLUMN biz_config_preset.description IS '预设说明';
+COMMENT ON COLUMN biz_config_preset.config IS '预设配置，包含渠道、配额与限流配置';
+COMMENT ON COLUMN biz_config_preset.is_default IS '是否为新建业务方的默认预设';
+COMMENT ON COLUMN biz_config_preset.created_at IS '创建时间';
+COMMENT ON COLUMN biz_config_preset.updated_at IS '更新时间';
+
+-- 业务方配置所基于的预设
+CREATE TABLE biz_config_preset_binding (
+    biz_id BIGINT PRIMARY KEY,
+    preset_id BIGINT NOT NULL REFERENCES biz_config_preset (id) ON DELETE CASCADE,
+    applied_by BIGINT NOT NULL DEFAULT 0,
+    applied_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_biz_config_preset_binding_preset ON biz_config_preset_binding (preset_id);
+
+COMMENT ON TABLE biz_config_preset_binding IS '业务方配置所基于的预设';
+COMMENT ON COLUMN biz_config_preset_binding.biz_id IS '业务方 id';
+COMMENT ON COLUMN biz_config_preset_binding.preset_id IS '预设 id';
+COMMENT ON COLUMN biz_config_preset_binding.applied_by IS '应用预设的用户 id';
+COMMENT ON COLUMN biz_config_preset_binding.applied_at IS '应用预设的时间';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS quota_alert_record;
+DROP TABLE IF EXISTS quota_alert_rule;
@@ -0,0 +1,43 @@
+-- 配额告警阈值表
+CREATE TABLE quota_alert_rule (
+    biz_id BIGINT NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    thresholds JSONB NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (biz_id, channel)
+);
+
+CREATE INDEX idx_quota_alert_rule_enabled ON quota_alert_rule (enabled) WHERE enabled;
+
+COMMENT ON TABLE quota_alert_rule IS '配额告警阈值表';
+COMMENT ON COLUMN quota_alert_rule.biz_id IS '业务方 id';
+COMMENT ON COLUMN quota_alert_rule.channel IS '渠道，sms / email';
+COMMENT ON COLUMN quota_alert_rule.thresholds IS '告警阈值，用量占配额的百分比，升序';
+COMMENT ON COLUMN quota_alert_rule.enabled IS '是否启用';
+COMMENT ON COLUMN quota_alert_rule.created_at IS '创建时间';
+COMMENT ON COLUMN quota_alert_rule.updated_at IS '更新时间';
+
+-- 配额告警记录表，主键保证同一周期内每个阈值只告警一次
+CREATE TABLE quota_alert_record (
+    biz_id BIGINT NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    period_key VARCHAR(16) NOT NULL,
+    threshold INT NOT NULL,
+    used INT NOT NULL,
+    quota_limit INT NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (biz_id, channel, period, period_key, threshold)
+);
+
+COMMENT ON TABLE quota_alert_record IS '配额告警记录表';
+COMMENT ON COLUMN quota_alert_record.biz_id IS '业务方 id';
+COMMENT ON COLUMN quota_alert_record.channel IS '渠道，sms / email';
+COMMENT ON COLUMN quota_alert_record.period IS '配额周期，daily / monthly';
+COMMENT ON COLUMN quota_alert_record.period_key IS '周期标识，例如 2026-10-19 / 2026-10';
+COMMENT ON COLUMN quota_alert_record.threshold IS '告警阈值';
+COMMENT ON COLUMN quota_alert_record.used IS '告警时的用量';
+COMMENT ON COLUMN quota_alert_record.quota_limit IS '告警时的配额';
+COMMENT ON COLUMN quota_alert_record.created_at IS '告警时间';
@@ -0,0 +1,9 @@
+// Package migration 内嵌的数据库迁移脚本，由 kuryr-admin migrate 命令执行。
+//
+// 文件名格式为 <version>_<name>.(up|down).sql，version 递增且不能修改已发布的迁移脚本。
+package migration
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
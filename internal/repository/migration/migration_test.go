@@ -0,0 +1,131 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/migrate"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresDSNEnv 执行迁移脚本使用的 Postgres 连接，未设置时跳过需要数据库的测试。
+// 测试在单独创建的 schema 内执行，结束后删除该 schema。
+const postgresDSNEnv = "KURYR_ADMIN_TEST_POSTGRES_DSN"
+
+var tables = []string{
+	"sys_user",
+	"audit_log",
+	"biz_config_version",
+	"biz_config_preset",
+	"biz_config_preset_binding",
+	"quota_alert_rule",
+	"quota_alert_record",
+}
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+
+	m, err := migrate.NewMigrator(nil, FS)
+	require.NoError(t, err)
+
+	migrations := m.Migrations()
+	versions := make([]uint64, 0, len(migrations))
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+		assert.NotEmpty(t, migration.Down, "migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, versions)
+}
+
+func TestFS_Postgres(t *testing.T) {
+	t.Parallel()
+
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	ctx := context.Background()
+	db := openSchema(t, dsn)
+
+	m, err := migrate.NewMigrator(db, FS)
+	require.NoError(t, err)
+	total := len(m.Migrations())
+
+	up := func() {
+		t.Helper()
+
+		applied, err := m.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, total)
+		require.NoError(t, m.Check(ctx))
+		for _, table := range tables {
+			assert.True(t, tableExists(t, db, table), "table %s should exist", table)
+		}
+	}
+
+	up()
+
+	for range total {
+		_, err = m.Down(ctx)
+		require.NoError(t, err)
+	}
+	_, err = m.Down(ctx)
+	require.ErrorIs(t, err, migrate.ErrNoMigrated)
+	for _, table := range tables {
+		assert.False(t, tableExists(t, db, table), "table %s should be dropped", table)
+	}
+
+	// down 脚本需要完整回滚，保证可以重新执行 up 脚本
+	up()
+}
+
+// openSchema 创建单独的 schema，返回 search_path 指向该 schema 的连接
+func openSchema(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+
+	admin, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = admin.Close() })
+
+	schema := fmt.Sprintf("migration_test_%d", time.Now().UnixNano())
+	_, err = admin.Exec("CREATE SCHEMA " + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	// lib/pq 将未知的连接参数作为运行时参数发送给 Postgres
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		require.NoError(t, err)
+		q := u.Query()
+		q.Set("search_path", schema)
+		u.RawQuery = q.Encode()
+		dsn = u.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+
+	var cnt int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1", name,
+	).Scan(&cnt)
+	require.NoError(t, err)
+	return cnt > 0
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/repository/dao"
+)
+
+// QuotaAlertRepo 配额告警 repo。
+type QuotaAlertRepo interface {
+	SaveRule(ctx context.Context, r domain.QuotaAlertRule) (domain.QuotaAlertRule, error)
+	DeleteRule(ctx context.Context, bizId uint64, channel string) error
+	FindRules(ctx context.Context, bizId uint64) ([]domain.QuotaAlertRule, error)
+	FindEnabledRules(ctx context.Context) ([]domain.QuotaAlertRule, error)
+
+	// SaveAlert 记录告警，同一业务方、渠道与周期内的阈值已经告警过时返回 false
+	SaveAlert(ctx context.Context, a domain.QuotaAlert) (bool, error)
+	// DeleteAlert 删除告警记录，告警发送失败时删除，下次检查时重新告警
+	DeleteAlert(ctx context.Context, a domain.QuotaAlert) error
+}
+
+var _ QuotaAlertRepo = (*DefaultQuotaAlertRepo)(nil)
+
+type DefaultQuotaAlertRepo struct {
+	dao dao.QuotaAlertDao
+}
+
+func (r *DefaultQuotaAlertRepo) SaveRule(ctx context.Context, rule domain.QuotaAlertRule) (domain.QuotaAlertRule, error) {
+	thresholds, err := json.Marshal(rule.Thresholds)
+	if err != nil {
+		return domain.QuotaAlertRule{}, fmt.Errorf("[kuryr-admin] failed to marshal quota alert thresholds: %w", err)
+	}
+
+	er, err := r.dao.SaveRule(ctx, dao.QuotaAlertRule{
+		BizId:      rule.BizId,
+		Channel:    rule.Channel,
+		Thresholds: string(thresholds),
+		Enabled:    rule.Enabled,
+	})
+	if err != nil {
+		return domain.QuotaAlertRule{}, err
+	}
+	return r.toDomain(er)
+}
+
+func (r *DefaultQuotaAlertRepo) DeleteRule(ctx context.Context, bizId uint64, channel string) error {
+	return r.dao.DeleteRule(ctx, bizId, channel)
+}
+
+func (r *DefaultQuotaAlertRepo) FindRules(ctx context.Context, bizId uint64) ([]domain.QuotaAlertRule, error) {
+	ers, err := r.dao.FindRules(ctx, bizId)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(ers)
+}
+
+func (r *DefaultQuotaAlertRepo) FindEnabledRules(ctx context.Context) ([]domain.QuotaAlertRule, error) {
+	ers, err := r.dao.FindEnabledRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(ers)
+}
+
+func (r *DefaultQuotaAlertRepo) SaveAlert(ctx context.Context, a domain.QuotaAlert) (bool, error) {
+	return r.dao.SaveRecord(ctx, r.toRecord(a))
+}
+
+func (r *DefaultQuotaAlertRepo) DeleteAlert(ctx context.Context, a domain.QuotaAlert) error {
+	return r.dao.DeleteRecord(ctx, r.toRecord(a))
+}
+
+func (r *DefaultQuotaAlertRepo) toRecord(a domain.QuotaAlert) dao.QuotaAlertRecord {
+	return dao.QuotaAlertRecord{
+		BizId:      a.BizId,
+		Channel:    a.Channel,
+		Period:     a.Period,
+		PeriodKey:  a.PeriodKey,
+		Threshold:  a.Threshold,
+		Used:       a.Used,
+		QuotaLimit: a.Limit,
+	}
+}
+
+func (r *DefaultQuotaAlertRepo) toDomains(ers []dao.QuotaAlertRule) ([]domain.QuotaAlertRule, error) {
+	rules := make([]domain.QuotaAlertRule, 0, len(ers))
+	for _, er := range ers {
+		rule, err := r.toDomain(er)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *DefaultQuotaAlertRepo) toDomain(er dao.QuotaAlertRule) (domain.QuotaAlertRule, error) {
+	var thresholds []int32
+	if err := json.Unmarshal([]byte(er.Thresholds), &thresholds); err != nil {
+		return domain.QuotaAlertRule{}, fmt.Errorf("[kuryr-admin] failed to unmarshal quota alert thresholds: %w", err)
+	}
+	return domain.QuotaAlertRule{
+		BizId:      er.BizId,
+		Channel:    er.Channel,
+		Thresholds: thresholds,
+		Enabled:    er.Enabled,
+		CreatedAt:  er.CreatedAt,
+		UpdatedAt:  er.UpdatedAt,
+	}, nil
+}
+
+func NewQuotaAlertRepo(dao dao.QuotaAlertDao) *DefaultQuotaAlertRepo {
+	return &DefaultQuotaAlertRepo{dao: dao}
+}
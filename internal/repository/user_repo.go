@@ -14,6 +14,7 @@ type UserRepo interface {
 	FindByBizId(ctx context.Context, bizId uint64) (domain.SysUser, error)
 	FindByEmail(ctx context.Context, email string) (domain.SysUser, error)
 	FindByMobile(ctx context.Context, mobile string) (domain.SysUser, error)
+	FindByUserType(ctx context.Context, userType domain.UserType) ([]domain.SysUser, error)
 }
 
 var _ UserRepo = (*DefaultUserRepo)(nil)
@@ -71,6 +72,19 @@ func (r *DefaultUserRepo) FindByMobile(ctx context.Context, mobile string) (doma
 	return r.toDomain(eu), nil
 }
 
+func (r *DefaultUserRepo) FindByUserType(ctx context.Context, userType domain.UserType) ([]domain.SysUser, error) {
+	eus, err := r.dao.FindByUserType(ctx, string(userType))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.SysUser, 0, len(eus))
+	for _, eu := range eus {
+		users = append(users, r.toDomain(eu))
+	}
+	return users, nil
+}
+
 func (r *DefaultUserRepo) toDomain(eu dao.SysUser) domain.SysUser {
 	return domain.SysUser{
 		Id:        eu.Id,
@@ -0,0 +1,12 @@
+package search
+
+// AuditCriteria 审计日志搜索条件，零值表示不限制
+type AuditCriteria struct {
+	ActorUid   uint64
+	ActorBid   uint64
+	Action     string
+	TargetType string
+	TargetId   uint64
+	StartTime  int64 // 单位：毫秒，包含
+	EndTime    int64 // 单位：毫秒，不包含
+}
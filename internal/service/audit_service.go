@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/audit"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository"
+	"github.com/JrMarcco/kuryr-admin/internal/search"
+)
+
+const auditExportBatchSize = 500
+
+type AuditService interface {
+	// Record 记录审计日志，before / after 为操作前后的对象，由 audit.Diff 计算变更字段并脱敏
+	Record(ctx context.Context, l domain.AuditLog, before, after any) error
+
+	Search(ctx context.Context, criteria search.AuditCriteria, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[domain.AuditLog], error)
+	// Export 按时间顺序分批导出所有匹配的审计日志
+	Export(ctx context.Context, criteria search.AuditCriteria, fn func([]domain.AuditLog) error) error
+}
+
+var _ AuditService = (*DefaultAuditService)(nil)
+
+type DefaultAuditService struct {
+	repo repository.AuditLogRepo
+}
+
+func (s *DefaultAuditService) Record(ctx context.Context, l domain.AuditLog, before, after any) error {
+	diff, err := json.Marshal(audit.Diff(before, after))
+	if err != nil {
+		return fmt.Errorf("[kuryr-admin] failed to marshal audit diff: %w", err)
+	}
+	l.Diff = diff
+
+	if _, err = s.repo.Save(ctx, l); err != nil {
+		return fmt.Errorf("[kuryr-admin] failed to save audit log: %w", err)
+	}
+	return nil
+}
+
+func (s *DefaultAuditService) Search(
+	ctx context.Context, criteria search.AuditCriteria, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[domain.AuditLog], error) {
+	res, err := s.repo.Search(ctx, criteria, param)
+	if err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to search audit log: %w", err)
+	}
+	return res, nil
+}
+
+func (s *DefaultAuditService) Export(ctx context.Context, criteria search.AuditCriteria, fn func([]domain.AuditLog) error) error {
+	return s.repo.FindInBatches(ctx, criteria, auditExportBatchSize, fn)
+}
+
+func NewDefaultAuditService(repo repository.AuditLogRepo) *DefaultAuditService {
+	return &DefaultAuditService{repo: repo}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/audit"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/repository"
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+)
+
+// presetPaths 应用预设时更新的配置字段，回调配置不属于预设，保持不变
+var presetPaths = []string{
+	configv1.FieldChannelConfig,
+	configv1.FieldQuotaConfig,
+	configv1.FieldRateLimit,
+}
+
+type BizConfigPresetService interface {
+	// Save 保存预设，id 为 0 时新建。
+	// 预设名称重复时返回 errs.ErrPresetNameConflict，预设配置不合法时返回包装 errs.ErrInvalidBizConfig 的错误。
+	Save(ctx context.Context, p domain.BizConfigPreset) (domain.BizConfigPreset, error)
+	Delete(ctx context.Context, id uint64) error
+
+	List(ctx context.Context, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[domain.BizConfigPreset], error)
+	FindById(ctx context.Context, id uint64) (domain.BizConfigPreset, error)
+	// FindDefault 查询默认预设，没有默认预设时返回 errs.ErrRecordNotFound
+	FindDefault(ctx context.Context) (domain.BizConfigPreset, error)
+
+	// Apply 将预设应用到业务方配置并记录业务方基于的预设。
+	// 业务方还没有配置时新建配置，否则覆盖渠道、配额与限流配置，回调配置保持不变。
+	Apply(ctx context.Context, bizId uint64, preset domain.BizConfigPreset, authorId uint64) (domain.BizConfig, error)
+	// Overrides 查询业务方当前配置相对于所基于预设的差异，业务方未基于预设时返回 errs.ErrRecordNotFound
+	Overrides(ctx context.Context, bizId uint64) (domain.BizConfigPresetOverrides, error)
+}
+
+var _ BizConfigPresetService = (*DefaultBizConfigPresetService)(nil)
+
+type DefaultBizConfigPresetService struct {
+	repo         repository.BizConfigPresetRepo
+	bizConfigSvc BizConfigService
+	validator    BizConfigValidator
+}
+
+func (s *DefaultBizConfigPresetService) Save(ctx context.Context, p domain.BizConfigPreset) (domain.BizConfigPreset, error) {
+	if err := s.validator.Validate(ctx, p.BizConfig(0), nil); err != nil {
+		return domain.BizConfigPreset{}, err
+	}
+
+	existing, err := s.repo.FindByName(ctx, p.Name)
+	switch {
+	case err == nil:
+		if existing.Id != p.Id {
+			return domain.BizConfigPreset{}, errs.ErrPresetNameConflict
+		}
+	case !errors.Is(err, errs.ErrRecordNotFound):
+		return domain.BizConfigPreset{}, fmt.Errorf("[kuryr-admin] failed to find biz config preset: %w", err)
+	}
+
+	saved, err := s.repo.Save(ctx, p)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return domain.BizConfigPreset{}, err
+		}
+		return domain.BizConfigPreset{}, fmt.Errorf("[kuryr-admin] failed to save biz config preset: %w", err)
+	}
+	return saved, nil
+}
+
+func (s *DefaultBizConfigPresetService) Delete(ctx context.Context, id uint64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("[kuryr-admin] failed to delete biz config preset: %w", err)
+	}
+	return nil
+}
+
+func (s *DefaultBizConfigPresetService) List(
+	ctx context.Context, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[domain.BizConfigPreset], error) {
+	res, err := s.repo.List(ctx, param)
+	if err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to list biz config presets: %w", err)
+	}
+	return res, nil
+}
+
+func (s *DefaultBizConfigPresetService) FindById(ctx context.Context, id uint64) (domain.BizConfigPreset, error) {
+	return s.repo.FindById(ctx, id)
+}
+
+func (s *DefaultBizConfigPresetService) FindDefault(ctx context.Context) (domain.BizConfigPreset, error) {
+	return s.repo.FindDefault(ctx)
+}
+
+func (s *DefaultBizConfigPresetService) Apply(
+	ctx context.Context, bizId uint64, preset domain.BizConfigPreset, authorId uint64,
+) (domain.BizConfig, error) {
+	comment := fmt.Sprintf("apply preset %s", preset.Name)
+
+	var applied domain.BizConfig
+	_, err := s.bizConfigSvc.FindByBizId(ctx, bizId)
+	switch {
+	case err == nil:
+		applied, err = s.bizConfigSvc.Patch(ctx, preset.BizConfig(bizId), presetPaths, authorId, comment)
+	case errors.Is(err, errs.ErrRecordNotFound):
+		applied, err = s.bizConfigSvc.Save(ctx, preset.BizConfig(bizId), authorId, comment)
+	}
+	if err != nil {
+		return domain.BizConfig{}, err
+	}
+
+	_, err = s.repo.SaveBinding(ctx, domain.BizConfigPresetBinding{
+		BizId:     bizId,
+		PresetId:  preset.Id,
+		AppliedBy: authorId,
+	})
+	if err != nil {
+		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to save biz config preset binding: %w", err)
+	}
+	return applied, nil
+}
+
+func (s *DefaultBizConfigPresetService) Overrides(ctx context.Context, bizId uint64) (domain.BizConfigPresetOverrides, error) {
+	binding, err := s.repo.FindBinding(ctx, bizId)
+	if err != nil {
+		return domain.BizConfigPresetOverrides{}, err
+	}
+
+	preset, err := s.repo.FindById(ctx, binding.PresetId)
+	if err != nil {
+		return domain.BizConfigPresetOverrides{}, err
+	}
+
+	current, err := s.bizConfigSvc.FindByBizId(ctx, bizId)
+	if err != nil {
+		return domain.BizConfigPresetOverrides{}, err
+	}
+
+	// 只对比预设包含的配置块
+	actual := domain.BizConfig{
+		BizId:         bizId,
+		ChannelConfig: current.ChannelConfig,
+		QuotaConfig:   current.QuotaConfig,
+		RateLimit:     current.RateLimit,
+	}
+	return domain.BizConfigPresetOverrides{
+		Binding:   binding,
+		Preset:    preset,
+		Overrides: audit.Diff(preset.BizConfig(bizId), actual),
+	}, nil
+}
+
+func NewDefaultBizConfigPresetService(
+	repo repository.BizConfigPresetRepo, bizConfigSvc BizConfigService, validator BizConfigValidator,
+) *DefaultBizConfigPresetService {
+	return &DefaultBizConfigPresetService{
+		repo:         repo,
+		bizConfigSvc: bizConfigSvc,
+		validator:    validator,
+	}
+}
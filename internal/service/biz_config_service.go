@@ -2,89 +2,411 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
-	"github.com/JrMarcco/easy-grpc/client"
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
 	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/audit"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/JrMarcco/kuryr-admin/internal/repository"
 	commonv1 "github.com/JrMarcco/kuryr-api/api/go/common/v1"
 	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type BizConfigService interface {
-	Save(ctx context.Context, bizConfig domain.BizConfig) (domain.BizConfig, error)
+	// Save 保存配置，保存成功后记录配置版本。
+	// 配置在发送到 kuryr 之前进行语义校验，不合法时返回包装 errs.ErrInvalidBizConfig 的错误，Patch 与 Rollback 同样如此。
+	Save(ctx context.Context, bizConfig domain.BizConfig, authorId uint64, comment string) (domain.BizConfig, error)
+	// Patch 局部更新配置，只更新 paths 指定的字段，字段值为空时清空该字段
+	Patch(ctx context.Context, bizConfig domain.BizConfig, paths []string, authorId uint64, comment string) (domain.BizConfig, error)
 	FindByBizId(ctx context.Context, id uint64) (domain.BizConfig, error)
+
+	// ListVersions 按版本号倒序分页查询配置版本
+	ListVersions(ctx context.Context, bizId uint64, param *pkggorm.PaginationParam) (*pkggorm.PaginationResult[domain.BizConfigVersion], error)
+	FindVersion(ctx context.Context, bizId uint64, version uint64) (domain.BizConfigVersion, error)
+	// DiffVersions 对比两个配置版本，返回从 from 到 to 发生变更的字段
+	DiffVersions(ctx context.Context, bizId uint64, from uint64, to uint64) (map[string]audit.Change, error)
+	// Rollback 将配置回滚到指定版本，回滚同样会生成新版本
+	Rollback(ctx context.Context, bizId uint64, version uint64, authorId uint64, comment string) (domain.BizConfig, error)
 }
 
 var _ BizConfigService = (*DefaultBizConfigService)(nil)
 
 type DefaultBizConfigService struct {
 	grpcServerName string
-	grpcClients    *client.Manager[configv1.BizConfigServiceClient]
+	grpcClients    *pkggrpc.Manager[configv1.BizConfigServiceClient]
+
+	validator   BizConfigValidator
+	versionRepo repository.BizConfigVersionRepo
+	logger      *zap.Logger
 }
 
-func (s *DefaultBizConfigService) Save(ctx context.Context, bizConfig domain.BizConfig) (domain.BizConfig, error) {
-	grpcClient, err := s.grpcClients.Get(s.grpcServerName)
+func (s *DefaultBizConfigService) Save(
+	ctx context.Context, bizConfig domain.BizConfig, authorId uint64, comment string,
+) (domain.BizConfig, error) {
+	var current *domain.BizConfig
+	if bizConfig.Id != 0 {
+		existing, err := s.FindByBizId(ctx, bizConfig.BizId)
+		switch {
+		case err == nil:
+			current = &existing
+		case !errors.Is(err, errs.ErrRecordNotFound):
+			return domain.BizConfig{}, err
+		}
+		// kuryr 优先按 id 更新配置，id 必须是该业务方当前配置的 id，避免覆盖其他业务方的配置
+		if current == nil || current.Id != bizConfig.Id {
+			return domain.BizConfig{}, fmt.Errorf("%w: id: config %d does not belong to biz %d",
+				errs.ErrInvalidBizConfig, bizConfig.Id, bizConfig.BizId)
+		}
+	}
+	if err := s.validator.Validate(ctx, bizConfig, current); err != nil {
+		return domain.BizConfig{}, err
+	}
+
+	if bizConfig.Id != 0 {
+		s.ensureBaseline(ctx, bizConfig.BizId)
+	}
+
+	saved, err := s.save(ctx, bizConfig)
 	if err != nil {
-		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
+		return domain.BizConfig{}, err
 	}
 
-	// 构建 grpc 请求
-	pb := &configv1.BizConfig{
-		Id:        bizConfig.Id,
-		BizId:     bizConfig.BizId,
-		OwnerType: string(bizConfig.OwnerType),
-		RateLimit: bizConfig.RateLimit,
+	s.recordVersion(ctx, domain.BizConfigVersion{
+		BizId:    saved.BizId,
+		Config:   saved,
+		AuthorId: authorId,
+		Comment:  comment,
+	})
+	return saved, nil
+}
+
+// bizConfigPaths 支持局部更新的字段路径，value 为对应的顶层字段。
+// kuryr 的 field mask 只支持顶层字段，嵌套字段在合并到当前配置后按顶层字段更新。
+var bizConfigPaths = map[string]string{
+	configv1.FieldRateLimit:               configv1.FieldRateLimit,
+	configv1.FieldChannelConfig:           configv1.FieldChannelConfig,
+	"channel_config.channels":             configv1.FieldChannelConfig,
+	"channel_config.retry_policy_config":  configv1.FieldChannelConfig,
+	configv1.FieldQuotaConfig:             configv1.FieldQuotaConfig,
+	"quota_config.daily":                  configv1.FieldQuotaConfig,
+	"quota_config.monthly":                configv1.FieldQuotaConfig,
+	configv1.FieldCallbackConfig:          configv1.FieldCallbackConfig,
+	"callback_config.service_name":        configv1.FieldCallbackConfig,
+	"callback_config.retry_policy_config": configv1.FieldCallbackConfig,
+}
+
+func (s *DefaultBizConfigService) Patch(
+	ctx context.Context, bizConfig domain.BizConfig, paths []string, authorId uint64, comment string,
+) (domain.BizConfig, error) {
+	current, err := s.FindByBizId(ctx, bizConfig.BizId)
+	if err != nil {
+		return domain.BizConfig{}, err
 	}
 
-	if bizConfig.ChannelConfig != nil {
-		pb.ChannelConfig = s.convertToPbChannel(bizConfig.ChannelConfig)
+	merged, mask, err := applyBizConfigPaths(current, bizConfig, paths)
+	if err != nil {
+		return domain.BizConfig{}, err
 	}
-	if bizConfig.QuotaConfig != nil {
-		pb.QuotaConfig = s.convertToQuota(bizConfig.QuotaConfig)
+	if len(mask) == 0 {
+		// 只清空了不存在的配置块内的字段，配置没有变化
+		return current, nil
+	}
+	if err = s.validator.Validate(ctx, merged, &current); err != nil {
+		return domain.BizConfig{}, err
 	}
 
-	if bizConfig.CallbackConfig != nil {
-		pb.CallbackConfig = s.convertToPbCallback(bizConfig.CallbackConfig)
+	s.ensureBaseline(ctx, bizConfig.BizId)
+
+	updated, err := s.update(ctx, merged, mask)
+	if err != nil {
+		return domain.BizConfig{}, err
+	}
+
+	s.recordVersion(ctx, domain.BizConfigVersion{
+		BizId:    updated.BizId,
+		Config:   updated,
+		AuthorId: authorId,
+		Comment:  comment,
+	})
+	return updated, nil
+}
+
+// applyBizConfigPaths 将 src 中 paths 指定的字段合并到 dst，返回合并后的配置与需要更新的顶层字段。
+// 清空 dst 中不存在的配置块内的字段时跳过该字段，不会创建空的配置块。
+func applyBizConfigPaths(dst domain.BizConfig, src domain.BizConfig, paths []string) (domain.BizConfig, []string, error) {
+	// 复制配置块，避免修改 dst 引用的对象
+	if dst.ChannelConfig != nil {
+		cc := *dst.ChannelConfig
+		dst.ChannelConfig = &cc
+	}
+	if dst.QuotaConfig != nil {
+		qc := *dst.QuotaConfig
+		dst.QuotaConfig = &qc
+	}
+	if dst.CallbackConfig != nil {
+		cc := *dst.CallbackConfig
+		dst.CallbackConfig = &cc
+	}
+
+	mask := make([]string, 0, len(paths))
+	for _, path := range paths {
+		field, ok := bizConfigPaths[path]
+		if !ok {
+			return domain.BizConfig{}, nil, fmt.Errorf("[kuryr-admin] unsupported biz config field path %q", path)
+		}
+
+		switch path {
+		case configv1.FieldRateLimit:
+			dst.RateLimit = src.RateLimit
+		case configv1.FieldChannelConfig:
+			dst.ChannelConfig = src.ChannelConfig
+		case configv1.FieldQuotaConfig:
+			dst.QuotaConfig = src.QuotaConfig
+		case configv1.FieldCallbackConfig:
+			dst.CallbackConfig = src.CallbackConfig
+		case "channel_config.channels", "channel_config.retry_policy_config":
+			from := src.ChannelConfig
+			if from == nil {
+				from = &domain.ChannelConfig{}
+			}
+			unset := from.Channels == nil
+			if path == "channel_config.retry_policy_config" {
+				unset = from.RetryPolicyConfig == nil
+			}
+			// 清空不存在的配置块内的字段时不需要更新，避免创建空的配置块
+			if dst.ChannelConfig == nil && unset {
+				continue
+			}
+			if dst.ChannelConfig == nil {
+				dst.ChannelConfig = &domain.ChannelConfig{}
+			}
+			if path == "channel_config.channels" {
+				dst.ChannelConfig.Channels = from.Channels
+			} else {
+				dst.ChannelConfig.RetryPolicyConfig = from.RetryPolicyConfig
+			}
+		case "quota_config.daily", "quota_config.monthly":
+			from := src.QuotaConfig
+			if from == nil {
+				from = &domain.QuotaConfig{}
+			}
+			unset := from.Daily == nil
+			if path == "quota_config.monthly" {
+				unset = from.Monthly == nil
+			}
+			if dst.QuotaConfig == nil && unset {
+				continue
+			}
+			if dst.QuotaConfig == nil {
+				dst.QuotaConfig = &domain.QuotaConfig{}
+			}
+			if path == "quota_config.daily" {
+				dst.QuotaConfig.Daily = from.Daily
+			} else {
+				dst.QuotaConfig.Monthly = from.Monthly
+			}
+		case "callback_config.service_name", "callback_config.retry_policy_config":
+			from := src.CallbackConfig
+			if from == nil {
+				from = &domain.CallbackConfig{}
+			}
+			unset := from.ServiceName == ""
+			if path == "callback_config.retry_policy_config" {
+				unset = from.RetryPolicyConfig == nil
+			}
+			if dst.CallbackConfig == nil && unset {
+				continue
+			}
+			if dst.CallbackConfig == nil {
+				dst.CallbackConfig = &domain.CallbackConfig{}
+			}
+			if path == "callback_config.service_name" {
+				dst.CallbackConfig.ServiceName = from.ServiceName
+			} else {
+				dst.CallbackConfig.RetryPolicyConfig = from.RetryPolicyConfig
+			}
+		}
+
+		if !slices.Contains(mask, field) {
+			mask = append(mask, field)
+		}
+	}
+	return dst, mask, nil
+}
+
+func (s *DefaultBizConfigService) Rollback(
+	ctx context.Context, bizId uint64, version uint64, authorId uint64, comment string,
+) (domain.BizConfig, error) {
+	target, err := s.versionRepo.FindByVersion(ctx, bizId, version)
+	if err != nil {
+		return domain.BizConfig{}, err
+	}
+
+	current, err := s.FindByBizId(ctx, bizId)
+	if err != nil {
+		return domain.BizConfig{}, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
+	// 使用版本快照覆盖当前配置的所有配置块
+	bizConfig := target.Config
+	bizConfig.Id = current.Id
+	bizConfig.BizId = bizId
+	if err = s.validator.Validate(ctx, bizConfig, &current); err != nil {
+		return domain.BizConfig{}, err
+	}
+
+	updated, err := s.save(ctx, bizConfig)
+	if err != nil {
+		return domain.BizConfig{}, err
+	}
+
+	s.recordVersion(ctx, domain.BizConfigVersion{
+		BizId:        bizId,
+		Config:       updated,
+		AuthorId:     authorId,
+		Comment:      comment,
+		RollbackFrom: version,
+	})
+	return updated, nil
+}
+
+func (s *DefaultBizConfigService) ListVersions(
+	ctx context.Context, bizId uint64, param *pkggorm.PaginationParam,
+) (*pkggorm.PaginationResult[domain.BizConfigVersion], error) {
+	res, err := s.versionRepo.Search(ctx, bizId, param)
+	if err != nil {
+		return nil, fmt.Errorf("[kuryr-admin] failed to list biz config versions: %w", err)
+	}
+	return res, nil
+}
+
+func (s *DefaultBizConfigService) FindVersion(ctx context.Context, bizId uint64, version uint64) (domain.BizConfigVersion, error) {
+	return s.versionRepo.FindByVersion(ctx, bizId, version)
+}
+
+func (s *DefaultBizConfigService) DiffVersions(ctx context.Context, bizId uint64, from uint64, to uint64) (map[string]audit.Change, error) {
+	fromVersion, err := s.versionRepo.FindByVersion(ctx, bizId, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := s.versionRepo.FindByVersion(ctx, bizId, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// id 与业务类型不属于配置内容
+	fromConfig, toConfig := fromVersion.Config, toVersion.Config
+	fromConfig.Id, toConfig.Id = 0, 0
+	fromConfig.OwnerType, toConfig.OwnerType = "", ""
+	return audit.Diff(fromConfig, toConfig), nil
+}
 
-	if bizConfig.Id == 0 {
-		// 创建配置
-		resp, err := grpcClient.Save(ctx, &configv1.SaveRequest{BizConfig: pb})
-		if err != nil {
-			return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to save biz config: %w", err)
+// ensureBaseline 业务方没有配置版本时（例如配置早于版本功能创建），将当前配置记录为基线版本，保证第一次修改也可以回滚。
+func (s *DefaultBizConfigService) ensureBaseline(ctx context.Context, bizId uint64) {
+	exists, err := s.versionRepo.Exists(ctx, bizId)
+	if err != nil {
+		s.logger.Error("[kuryr-admin] failed to check biz config versions", zap.Uint64("biz_id", bizId), zap.Error(err), reqid.Field(ctx))
+		return
+	}
+	if exists {
+		return
+	}
+
+	current, err := s.FindByBizId(ctx, bizId)
+	if err != nil {
+		if !errors.Is(err, errs.ErrRecordNotFound) {
+			s.logger.Error("[kuryr-admin] failed to find biz config", zap.Uint64("biz_id", bizId), zap.Error(err), reqid.Field(ctx))
 		}
-		return s.pbToDomain(resp.BizConfig), nil
+		return
 	}
+	s.recordVersion(ctx, domain.BizConfigVersion{
+		BizId:   bizId,
+		Config:  current,
+		Comment: "baseline",
+	})
+}
+
+// recordVersion 记录配置版本。
+// 记录时配置已经保存成功，版本写入失败只记录错误日志，不影响保存结果。
+func (s *DefaultBizConfigService) recordVersion(ctx context.Context, v domain.BizConfigVersion) {
+	if _, err := s.versionRepo.Save(ctx, v); err != nil {
+		s.logger.Error("[kuryr-admin] failed to record biz config version", zap.Uint64("biz_id", v.BizId), zap.Error(err), reqid.Field(ctx))
+	}
+}
 
-	fieldMask := &fieldmaskpb.FieldMask{
-		Paths: []string{
+// save 保存配置，id 为 0 时新建配置，否则使用配置的所有字段覆盖当前配置。
+func (s *DefaultBizConfigService) save(ctx context.Context, bizConfig domain.BizConfig) (domain.BizConfig, error) {
+	if bizConfig.Id != 0 {
+		return s.update(ctx, bizConfig, []string{
 			configv1.FieldChannelConfig,
 			configv1.FieldQuotaConfig,
 			configv1.FieldCallbackConfig,
 			configv1.FieldRateLimit,
-		},
+		})
+	}
+
+	grpcClient, err := s.grpcClients.Get(s.grpcServerName)
+	if err != nil {
+		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
+	}
+
+	// 创建配置
+	resp, err := grpcClient.Save(ctx, &configv1.SaveRequest{BizConfig: s.convertToPb(bizConfig)})
+	if err != nil {
+		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to save biz config: %w", err)
+	}
+	return s.pbToDomain(resp.BizConfig), nil
+}
+
+// update 更新配置，只更新 paths 指定的顶层字段。
+func (s *DefaultBizConfigService) update(ctx context.Context, bizConfig domain.BizConfig, paths []string) (domain.BizConfig, error) {
+	grpcClient, err := s.grpcClients.Get(s.grpcServerName)
+	if err != nil {
+		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	// 更新配置
 	resp, err := grpcClient.Update(ctx, &configv1.UpdateRequest{
-		FieldMask: fieldMask,
-		BizConfig: pb,
+		FieldMask: &fieldmaskpb.FieldMask{Paths: paths},
+		BizConfig: s.convertToPb(bizConfig),
 	})
-
 	if err != nil {
 		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to save biz config: %w", err)
 	}
 	return s.pbToDomain(resp.BizConfig), nil
 }
 
+// convertToPb 业务方配置 proto buf
+func (s *DefaultBizConfigService) convertToPb(bizConfig domain.BizConfig) *configv1.BizConfig {
+	pb := &configv1.BizConfig{
+		Id:        bizConfig.Id,
+		BizId:     bizConfig.BizId,
+		OwnerType: string(bizConfig.OwnerType),
+		RateLimit: bizConfig.RateLimit,
+	}
+
+	if bizConfig.ChannelConfig != nil {
+		pb.ChannelConfig = s.convertToPbChannel(bizConfig.ChannelConfig)
+	}
+	if bizConfig.QuotaConfig != nil {
+		pb.QuotaConfig = s.convertToQuota(bizConfig.QuotaConfig)
+	}
+
+	if bizConfig.CallbackConfig != nil {
+		pb.CallbackConfig = s.convertToPbCallback(bizConfig.CallbackConfig)
+	}
+	return pb
+}
+
 // convertToPbChannel 渠道配置 proto buf
 func (s *DefaultBizConfigService) convertToPbChannel(config *domain.ChannelConfig) *configv1.ChannelConfig {
 	pbItems := make([]*configv1.ChannelItem, len(config.Channels))
@@ -130,9 +452,12 @@ func (s *DefaultBizConfigService) convertToPbCallback(config *domain.CallbackCon
 
 // convertToPbRetry 重试机制 proto buf
 func (s *DefaultBizConfigService) convertToPbRetry(config *domain.RetryConfig) *configv1.RetryPolicyConfig {
+	if config == nil {
+		return nil
+	}
 	return &configv1.RetryPolicyConfig{
-		InitIntervalMs: int32(config.InitialInterval.Milliseconds()),
-		MaxIntervalMs:  int32(config.MaxInterval.Milliseconds()),
+		InitIntervalMs: int32(config.InitialInterval.Duration().Milliseconds()),
+		MaxIntervalMs:  int32(config.MaxInterval.Duration().Milliseconds()),
 		MaxRetryTimes:  config.MaxRetryTimes,
 	}
 }
@@ -143,9 +468,6 @@ func (s *DefaultBizConfigService) FindByBizId(ctx context.Context, id uint64) (d
 		return domain.BizConfig{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.FindByBizId(ctx, &configv1.FindByBizIdRequest{
 		// FieldMask 传空会返回所有字段
 		FieldMask: &fieldmaskpb.FieldMask{},
@@ -223,17 +545,24 @@ func (s *DefaultBizConfigService) pbToDomain(pb *configv1.BizConfig) domain.BizC
 
 func (s *DefaultBizConfigService) convertRetry(pbRetry *configv1.RetryPolicyConfig) *domain.RetryConfig {
 	return &domain.RetryConfig{
-		InitialInterval: time.Duration(pbRetry.InitIntervalMs),
-		MaxInterval:     time.Duration(pbRetry.MaxIntervalMs),
+		InitialInterval: domain.Duration(time.Duration(pbRetry.InitIntervalMs) * time.Millisecond),
+		MaxInterval:     domain.Duration(time.Duration(pbRetry.MaxIntervalMs) * time.Millisecond),
 		MaxRetryTimes:   pbRetry.MaxRetryTimes,
 	}
 }
 
 func NewDefaultBizConfigService(
-	grpcServerName string, grpcClients *client.Manager[configv1.BizConfigServiceClient],
+	grpcServerName string,
+	grpcClients *pkggrpc.Manager[configv1.BizConfigServiceClient],
+	validator BizConfigValidator,
+	versionRepo repository.BizConfigVersionRepo,
+	logger *zap.Logger,
 ) *DefaultBizConfigService {
 	return &DefaultBizConfigService{
 		grpcServerName: grpcServerName,
 		grpcClients:    grpcClients,
+		validator:      validator,
+		versionRepo:    versionRepo,
+		logger:         logger,
 	}
 }
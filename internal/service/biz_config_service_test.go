@@ -0,0 +1,174 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	configv1 "github.com/JrMarcco/kuryr-api/api/go/config/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDefaultBizConfigService_ConvertRetry(t *testing.T) {
+	t.Parallel()
+
+	s := &DefaultBizConfigService{}
+
+	cfg := &domain.RetryConfig{
+		InitialInterval: domain.Duration(1500 * time.Millisecond),
+		MaxInterval:     domain.Duration(2 * time.Minute),
+		MaxRetryTimes:   5,
+	}
+	pb := s.convertToPbRetry(cfg)
+	assert.True(t, proto.Equal(&configv1.RetryPolicyConfig{
+		InitIntervalMs: 1500,
+		MaxIntervalMs:  120000,
+		MaxRetryTimes:  5,
+	}, pb))
+	assert.Equal(t, cfg, s.convertRetry(pb))
+
+	assert.Nil(t, s.convertToPbRetry(nil))
+}
+
+func TestDefaultBizConfigService_ConvertRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s := &DefaultBizConfigService{}
+
+	tcs := []struct {
+		name   string
+		config domain.BizConfig
+	}{
+		{
+			name: "full",
+			config: domain.BizConfig{
+				Id:        1,
+				BizId:     2,
+				OwnerType: domain.BizTypeOrganization,
+				RateLimit: 100,
+				ChannelConfig: &domain.ChannelConfig{
+					Channels: []domain.ChannelItem{
+						{Channel: 1, Priority: 1, Enabled: true},
+						{Channel: 2, Priority: 2},
+					},
+					RetryPolicyConfig: &domain.RetryConfig{
+						InitialInterval: domain.Duration(500 * time.Millisecond),
+						MaxInterval:     domain.Duration(time.Minute),
+						MaxRetryTimes:   3,
+					},
+				},
+				QuotaConfig: &domain.QuotaConfig{
+					Daily:   &domain.Quota{Sms: 10, Email: 20},
+					Monthly: &domain.Quota{Sms: 300, Email: 600},
+				},
+				CallbackConfig: &domain.CallbackConfig{
+					ServiceName: "callback",
+					RetryPolicyConfig: &domain.RetryConfig{
+						InitialInterval: domain.Duration(2 * time.Second),
+						MaxInterval:     domain.Duration(time.Hour),
+						MaxRetryTimes:   10,
+					},
+				},
+			},
+		}, {
+			name: "empty sections",
+			config: domain.BizConfig{
+				BizId:          2,
+				ChannelConfig:  &domain.ChannelConfig{Channels: []domain.ChannelItem{}},
+				QuotaConfig:    &domain.QuotaConfig{},
+				CallbackConfig: &domain.CallbackConfig{ServiceName: "callback"},
+			},
+		}, {
+			name:   "no sections",
+			config: domain.BizConfig{BizId: 2, RateLimit: 1},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.config, s.pbToDomain(s.convertToPb(tc.config)))
+		})
+	}
+}
+
+func TestApplyBizConfigPaths(t *testing.T) {
+	t.Parallel()
+
+	daily := &domain.Quota{Sms: 10, Email: 20}
+	current := domain.BizConfig{
+		BizId:       1,
+		RateLimit:   100,
+		QuotaConfig: &domain.QuotaConfig{Daily: daily, Monthly: &domain.Quota{Sms: 300, Email: 600}},
+	}
+
+	tcs := []struct {
+		name     string
+		dst      domain.BizConfig
+		src      domain.BizConfig
+		paths    []string
+		want     domain.BizConfig
+		wantMask []string
+	}{
+		{
+			name:     "top level field",
+			dst:      current,
+			src:      domain.BizConfig{RateLimit: 200},
+			paths:    []string{configv1.FieldRateLimit},
+			want:     domain.BizConfig{BizId: 1, RateLimit: 200, QuotaConfig: current.QuotaConfig},
+			wantMask: []string{configv1.FieldRateLimit},
+		}, {
+			name:     "nested field",
+			dst:      current,
+			src:      domain.BizConfig{QuotaConfig: &domain.QuotaConfig{}},
+			paths:    []string{"quota_config.monthly"},
+			want:     domain.BizConfig{BizId: 1, RateLimit: 100, QuotaConfig: &domain.QuotaConfig{Daily: daily}},
+			wantMask: []string{configv1.FieldQuotaConfig},
+		}, {
+			name:     "set nested field on nil section",
+			dst:      domain.BizConfig{BizId: 1},
+			src:      domain.BizConfig{CallbackConfig: &domain.CallbackConfig{ServiceName: "callback"}},
+			paths:    []string{"callback_config.service_name"},
+			want:     domain.BizConfig{BizId: 1, CallbackConfig: &domain.CallbackConfig{ServiceName: "callback"}},
+			wantMask: []string{configv1.FieldCallbackConfig},
+		}, {
+			name: "clear nested field on nil section",
+			dst:  domain.BizConfig{BizId: 1, RateLimit: 100},
+			src:  domain.BizConfig{},
+			paths: []string{
+				"quota_config.daily",
+				"channel_config.channels",
+				"channel_config.retry_policy_config",
+				"callback_config.service_name",
+				"callback_config.retry_policy_config",
+			},
+			want:     domain.BizConfig{BizId: 1, RateLimit: 100},
+			wantMask: []string{},
+		}, {
+			name:     "clear nested field on nil section with other fields",
+			dst:      domain.BizConfig{BizId: 1},
+			src:      domain.BizConfig{RateLimit: 10},
+			paths:    []string{"quota_config.daily", configv1.FieldRateLimit},
+			want:     domain.BizConfig{BizId: 1, RateLimit: 10},
+			wantMask: []string{configv1.FieldRateLimit},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, mask, err := applyBizConfigPaths(tc.dst, tc.src, tc.paths)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantMask, mask)
+		})
+	}
+
+	// 合并不修改 dst 引用的配置块
+	assert.Equal(t, &domain.Quota{Sms: 300, Email: 600}, current.QuotaConfig.Monthly)
+
+	_, _, err := applyBizConfigPaths(current, domain.BizConfig{}, []string{"unknown"})
+	assert.Error(t, err)
+}
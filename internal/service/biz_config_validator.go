@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	commonv1 "github.com/JrMarcco/kuryr-api/api/go/common/v1"
+)
+
+// maxRetryInterval 重试间隔以 int32 毫秒传给 kuryr
+const maxRetryInterval = time.Duration(math.MaxInt32) * time.Millisecond
+
+// BizConfigValidator 业务方配置语义校验，在配置发送到 kuryr 之前执行。
+type BizConfigValidator interface {
+	// Validate 校验配置，配置不合法时返回包装 errs.ErrInvalidBizConfig 的错误，错误信息包含所有不合法的字段。
+	// current 为业务方当前的配置，没有配置时为 nil，回调服务与当前配置相同时不再检查注册中心。
+	Validate(ctx context.Context, bizConfig domain.BizConfig, current *domain.BizConfig) error
+}
+
+var _ BizConfigValidator = (*DefaultBizConfigValidator)(nil)
+
+type DefaultBizConfigValidator struct {
+	registry registry.Registry
+}
+
+func (v *DefaultBizConfigValidator) Validate(ctx context.Context, bizConfig domain.BizConfig, current *domain.BizConfig) error {
+	c := &bizConfigChecker{}
+
+	c.check(bizConfig.RateLimit >= 0, "rate_limit", "must not be negative, got %d", bizConfig.RateLimit)
+
+	if cc := bizConfig.ChannelConfig; cc != nil {
+		seen := make(map[int32]struct{}, len(cc.Channels))
+		for i, item := range cc.Channels {
+			key := fmt.Sprintf("channel_config.channels[%d]", i)
+
+			_, known := commonv1.Channel_name[item.Channel]
+			c.check(known && item.Channel != int32(commonv1.Channel_CHANNEL_UNSPECIFIED), key+".channel", "unknown channel %d", item.Channel)
+			c.check(item.Priority >= 0, key+".priority", "must not be negative, got %d", item.Priority)
+
+			if _, ok := seen[item.Channel]; ok {
+				c.check(false, key+".channel", "duplicate channel %d", item.Channel)
+			}
+			seen[item.Channel] = struct{}{}
+		}
+		c.retry(cc.RetryPolicyConfig, "channel_config.retry_policy_config")
+	}
+
+	if qc := bizConfig.QuotaConfig; qc != nil {
+		c.quota(qc.Daily, "quota_config.daily")
+		c.quota(qc.Monthly, "quota_config.monthly")
+		if qc.Daily != nil && qc.Monthly != nil {
+			c.check(qc.Daily.Sms <= qc.Monthly.Sms, "quota_config.daily.sms", "must not exceed monthly quota")
+			c.check(qc.Daily.Email <= qc.Monthly.Email, "quota_config.daily.email", "must not exceed monthly quota")
+		}
+	}
+
+	if cc := bizConfig.CallbackConfig; cc != nil {
+		c.retry(cc.RetryPolicyConfig, "callback_config.retry_policy_config")
+
+		switch {
+		case cc.ServiceName == "":
+			c.check(false, "callback_config.service_name", "is required")
+		case current != nil && current.CallbackConfig != nil && current.CallbackConfig.ServiceName == cc.ServiceName:
+			// 回调服务没有变化，服务实例暂时下线不影响其他配置的修改
+		default:
+			// 回调服务由 kuryr 通过注册中心发现，保存前确认服务已注册
+			instances, err := v.registry.ListServices(ctx, cc.ServiceName)
+			if err != nil {
+				return fmt.Errorf("[kuryr-admin] failed to resolve callback service %q: %w", cc.ServiceName, err)
+			}
+			c.check(len(instances) > 0, "callback_config.service_name", "service %q not found in registry", cc.ServiceName)
+		}
+	}
+
+	if len(c.msgs) > 0 {
+		return fmt.Errorf("%w: %s", errs.ErrInvalidBizConfig, strings.Join(c.msgs, "; "))
+	}
+	return nil
+}
+
+// bizConfigChecker 收集所有校验错误，保证一次请求就可以看到全部配置问题。
+type bizConfigChecker struct {
+	msgs []string
+}
+
+func (c *bizConfigChecker) check(ok bool, key string, format string, args ...any) {
+	if !ok {
+		c.msgs = append(c.msgs, fmt.Sprintf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (c *bizConfigChecker) retry(cfg *domain.RetryConfig, key string) {
+	if cfg == nil {
+		return
+	}
+	initial, maxInterval := cfg.InitialInterval.Duration(), cfg.MaxInterval.Duration()
+	c.check(initial > 0, key+".initial_interval", "must be greater than 0")
+	c.check(initial%time.Millisecond == 0, key+".initial_interval", "must be a whole number of milliseconds, got %s", initial)
+	c.check(maxInterval >= initial, key+".max_interval", "must not be less than initial_interval")
+	c.check(maxInterval <= maxRetryInterval, key+".max_interval", "must not exceed %s", maxRetryInterval)
+	c.check(maxInterval%time.Millisecond == 0, key+".max_interval", "must be a whole number of milliseconds, got %s", maxInterval)
+	c.check(cfg.MaxRetryTimes >= 0, key+".max_retry_times", "must not be negative, got %d", cfg.MaxRetryTimes)
+}
+
+func (c *bizConfigChecker) quota(q *domain.Quota, key string) {
+	if q == nil {
+		return
+	}
+	c.check(q.Sms >= 0, key+".sms", "must not be negative, got %d", q.Sms)
+	c.check(q.Email >= 0, key+".email", "must not be negative, got %d", q.Email)
+}
+
+func NewDefaultBizConfigValidator(r registry.Registry) *DefaultBizConfigValidator {
+	return &DefaultBizConfigValidator{registry: r}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	pkgregistry "github.com/JrMarcco/kuryr-admin/internal/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultBizConfigValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	r := pkgregistry.NewMemoryRegistry(registry.ServiceInstance{Name: "callback", Addr: "127.0.0.1:8080"})
+	t.Cleanup(func() { _ = r.Close() })
+	v := NewDefaultBizConfigValidator(r)
+
+	retry := func(initial, max time.Duration, times int32) *domain.RetryConfig {
+		return &domain.RetryConfig{InitialInterval: domain.Duration(initial), MaxInterval: domain.Duration(max), MaxRetryTimes: times}
+	}
+
+	tcs := []struct {
+		name    string
+		config  domain.BizConfig
+		current *domain.BizConfig
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			config: domain.BizConfig{
+				BizId:     1,
+				RateLimit: 100,
+				ChannelConfig: &domain.ChannelConfig{
+					Channels: []domain.ChannelItem{
+						{Channel: 1, Priority: 1, Enabled: true},
+						{Channel: 2, Priority: 2},
+					},
+					RetryPolicyConfig: retry(time.Second, time.Minute, 3),
+				},
+				QuotaConfig: &domain.QuotaConfig{
+					Daily:   &domain.Quota{Sms: 10, Email: 10},
+					Monthly: &domain.Quota{Sms: 100, Email: 100},
+				},
+				CallbackConfig: &domain.CallbackConfig{ServiceName: "callback"},
+			},
+		}, {
+			name: "empty sections",
+			config: domain.BizConfig{
+				BizId:         1,
+				ChannelConfig: &domain.ChannelConfig{},
+				QuotaConfig:   &domain.QuotaConfig{},
+			},
+		}, {
+			name:    "invalid rate limit",
+			config:  domain.BizConfig{BizId: 1, RateLimit: -1},
+			wantErr: []string{"rate_limit: must not be negative, got -1"},
+		}, {
+			name: "invalid channels",
+			config: domain.BizConfig{
+				BizId: 1,
+				ChannelConfig: &domain.ChannelConfig{
+					Channels: []domain.ChannelItem{
+						{Channel: 0},
+						{Channel: 1, Priority: -1},
+						{Channel: 1},
+						{Channel: 9},
+					},
+				},
+			},
+			wantErr: []string{
+				"channel_config.channels[0].channel: unknown channel 0",
+				"channel_config.channels[1].priority: must not be negative, got -1",
+				"channel_config.channels[2].channel: duplicate channel 1",
+				"channel_config.channels[3].channel: unknown channel 9",
+			},
+		}, {
+			name: "invalid retry",
+			config: domain.BizConfig{
+				BizId:         1,
+				ChannelConfig: &domain.ChannelConfig{RetryPolicyConfig: retry(time.Minute, time.Second, -1)},
+				CallbackConfig: &domain.CallbackConfig{
+					ServiceName:       "callback",
+					RetryPolicyConfig: retry(0, 30*24*time.Hour, 1),
+				},
+			},
+			wantErr: []string{
+				"channel_config.retry_policy_config.max_interval: must not be less than initial_interval",
+				"channel_config.retry_policy_config.max_retry_times: must not be negative, got -1",
+				"callback_config.retry_policy_config.initial_interval: must be greater than 0",
+				"callback_config.retry_policy_config.max_interval: must not exceed",
+			},
+		}, {
+			name: "sub millisecond retry interval",
+			config: domain.BizConfig{
+				BizId:         1,
+				ChannelConfig: &domain.ChannelConfig{RetryPolicyConfig: retry(1500*time.Microsecond, time.Second, 1)},
+			},
+			wantErr: []string{"channel_config.retry_policy_config.initial_interval: must be a whole number of milliseconds, got 1.5ms"},
+		}, {
+			name: "invalid quota",
+			config: domain.BizConfig{
+				BizId: 1,
+				QuotaConfig: &domain.QuotaConfig{
+					Daily:   &domain.Quota{Sms: -1, Email: 200},
+					Monthly: &domain.Quota{Sms: 100, Email: 100},
+				},
+			},
+			wantErr: []string{
+				"quota_config.daily.sms: must not be negative, got -1",
+				"quota_config.daily.email: must not exceed monthly quota",
+			},
+		}, {
+			name:    "callback without service name",
+			config:  domain.BizConfig{BizId: 1, CallbackConfig: &domain.CallbackConfig{}},
+			wantErr: []string{"callback_config.service_name: is required"},
+		}, {
+			name:    "callback service not registered",
+			config:  domain.BizConfig{BizId: 1, CallbackConfig: &domain.CallbackConfig{ServiceName: "unknown"}},
+			wantErr: []string{`callback_config.service_name: service "unknown" not found in registry`},
+		}, {
+			name:    "callback service unchanged",
+			config:  domain.BizConfig{BizId: 1, RateLimit: 10, CallbackConfig: &domain.CallbackConfig{ServiceName: "unknown"}},
+			current: &domain.BizConfig{BizId: 1, CallbackConfig: &domain.CallbackConfig{ServiceName: "unknown"}},
+		}, {
+			name:    "callback service changed",
+			config:  domain.BizConfig{BizId: 1, CallbackConfig: &domain.CallbackConfig{ServiceName: "unknown"}},
+			current: &domain.BizConfig{BizId: 1, CallbackConfig: &domain.CallbackConfig{ServiceName: "callback"}},
+			wantErr: []string{`callback_config.service_name: service "unknown" not found in registry`},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.Validate(context.Background(), tc.config, tc.current)
+			if len(tc.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, errs.ErrInvalidBizConfig)
+			for _, want := range tc.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
@@ -2,13 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
 
-	"github.com/JrMarcco/easy-grpc/client"
 	"github.com/JrMarcco/easy-kit/slice"
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
 	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
 	"github.com/JrMarcco/kuryr-admin/internal/pkg/secret"
 	"github.com/JrMarcco/kuryr-admin/internal/repository"
 	"github.com/JrMarcco/kuryr-admin/internal/search"
@@ -19,7 +21,9 @@ import (
 )
 
 type BizService interface {
-	Save(ctx context.Context, bi domain.BizInfo) (domain.BizInfo, error)
+	// Save 新建业务方并创建操作员，同时使用预设初始化业务方配置。
+	// presetId 为 0 时使用默认预设，没有默认预设时不初始化配置；指定的预设不存在时返回 errs.ErrRecordNotFound。
+	Save(ctx context.Context, bi domain.BizInfo, presetId uint64) (domain.BizInfo, error)
 	Update(ctx context.Context, bi domain.BizInfo) (domain.BizInfo, error)
 	Delete(ctx context.Context, id uint64) error
 
@@ -31,23 +35,27 @@ var _ BizService = (*DefaultBizService)(nil)
 
 type DefaultBizService struct {
 	grpcServerName string
-	grpcClients    *client.Manager[businessv1.BusinessServiceClient]
+	grpcClients    *pkggrpc.Manager[businessv1.BusinessServiceClient]
 
-	userRepo repository.UserRepo
+	userRepo  repository.UserRepo
+	presetSvc BizConfigPresetService
 
 	passwdGenerator secret.Generator
 	logger          *zap.Logger
 }
 
-func (s *DefaultBizService) Save(ctx context.Context, bi domain.BizInfo) (domain.BizInfo, error) {
+func (s *DefaultBizService) Save(ctx context.Context, bi domain.BizInfo, presetId uint64) (domain.BizInfo, error) {
+	// 创建业务方之前确认预设存在
+	preset, hasPreset, err := s.findPreset(ctx, presetId)
+	if err != nil {
+		return domain.BizInfo{}, err
+	}
+
 	grpcClient, err := s.grpcClients.Get(s.grpcServerName)
 	if err != nil {
 		return domain.BizInfo{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.Save(ctx, &businessv1.SaveRequest{BusinessInfo: s.domainToPb(bi)})
 
 	if err != nil {
@@ -56,16 +64,26 @@ func (s *DefaultBizService) Save(ctx context.Context, bi domain.BizInfo) (domain
 
 	rtnBi := s.pbToDomain(resp.BusinessInfo)
 
+	// 初始化业务方配置，失败时业务方已经创建，只记录错误日志，可以之后手动应用预设
+	if hasPreset {
+		if _, err = s.presetSvc.Apply(ctx, rtnBi.Id, preset, bi.CreatorId); err != nil {
+			s.logger.Error(
+				"[kuryr-admin] failed to apply biz config preset",
+				zap.Uint64("biz_id", rtnBi.Id), zap.Uint64("preset_id", preset.Id), zap.Error(err), reqid.Field(ctx),
+			)
+		}
+	}
+
 	// 创建操作员
 	passwd, err := s.passwdGenerator.Generate(16)
 	if err != nil {
-		s.logger.Error("[kuryr-admin] failed to generate password", zap.Error(err))
+		s.logger.Error("[kuryr-admin] failed to generate password", zap.Error(err), reqid.Field(ctx))
 		return rtnBi, nil
 	}
 
 	bcryptPwd, err := bcrypt.GenerateFromPassword([]byte(passwd), bcrypt.DefaultCost)
 	if err != nil {
-		s.logger.Error("[kuryr-admin] failed to generate password", zap.Error(err))
+		s.logger.Error("[kuryr-admin] failed to generate password", zap.Error(err), reqid.Field(ctx))
 		return rtnBi, nil
 	}
 
@@ -79,13 +97,33 @@ func (s *DefaultBizService) Save(ctx context.Context, bi domain.BizInfo) (domain
 
 	_, err = s.userRepo.Save(ctx, user)
 	if err != nil {
-		s.logger.Error("[kuryr-admin] failed to save user", zap.Error(err))
+		s.logger.Error("[kuryr-admin] failed to save user", zap.Error(err), reqid.Field(ctx))
 		return rtnBi, nil
 	}
 
 	return rtnBi, nil
 }
 
+// findPreset 查询新建业务方使用的预设，presetId 为 0 时查询默认预设
+func (s *DefaultBizService) findPreset(ctx context.Context, presetId uint64) (domain.BizConfigPreset, bool, error) {
+	if presetId != 0 {
+		preset, err := s.presetSvc.FindById(ctx, presetId)
+		if err != nil {
+			return domain.BizConfigPreset{}, false, err
+		}
+		return preset, true, nil
+	}
+
+	preset, err := s.presetSvc.FindDefault(ctx)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return domain.BizConfigPreset{}, false, nil
+		}
+		return domain.BizConfigPreset{}, false, fmt.Errorf("[kuryr-admin] failed to find default biz config preset: %w", err)
+	}
+	return preset, true, nil
+}
+
 func (s *DefaultBizService) Update(ctx context.Context, bi domain.BizInfo) (domain.BizInfo, error) {
 	grpcClient, err := s.grpcClients.Get(s.grpcServerName)
 	if err != nil {
@@ -100,9 +138,6 @@ func (s *DefaultBizService) Update(ctx context.Context, bi domain.BizInfo) (doma
 		},
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.Update(ctx, &businessv1.UpdateRequest{
 		FieldMask:    fieldMask,
 		BusinessInfo: s.domainToPb(bi),
@@ -118,7 +153,7 @@ func (s *DefaultBizService) Update(ctx context.Context, bi domain.BizInfo) (doma
 		// 更新操作员信息
 		user, err := s.userRepo.FindByBizId(ctx, bi.Id)
 		if err != nil {
-			s.logger.Error("[kuryr-admin] failed to find user by biz id", zap.Error(err))
+			s.logger.Error("[kuryr-admin] failed to find user by biz id", zap.Error(err), reqid.Field(ctx))
 			return rtnBi, nil
 		}
 
@@ -130,14 +165,14 @@ func (s *DefaultBizService) Update(ctx context.Context, bi domain.BizInfo) (doma
 
 		passwd, err := s.passwdGenerator.Generate(16)
 		if err != nil {
-			s.logger.Error("[kuryr-admin] failed to generate password", zap.Error(err))
+			s.logger.Error("[kuryr-admin] failed to generate password", zap.Error(err), reqid.Field(ctx))
 			return rtnBi, nil
 		}
 		user.Password = passwd
 
 		_, err = s.userRepo.Save(ctx, user)
 		if err != nil {
-			s.logger.Error("[kuryr-admin] failed to save user", zap.Error(err))
+			s.logger.Error("[kuryr-admin] failed to save user", zap.Error(err), reqid.Field(ctx))
 			return rtnBi, nil
 		}
 	}
@@ -151,9 +186,6 @@ func (s *DefaultBizService) Delete(ctx context.Context, id uint64) error {
 		return fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	_, err = grpcClient.Delete(ctx, &businessv1.DeleteRequest{BizId: id})
 
 	if err != nil {
@@ -168,9 +200,6 @@ func (s *DefaultBizService) Search(ctx context.Context, criteria search.BizSearc
 		return nil, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.Search(ctx, &businessv1.SearchRequest{
 		FieldMask: &fieldmaskpb.FieldMask{
 			Paths: []string{
@@ -224,9 +253,6 @@ func (s *DefaultBizService) FindById(ctx context.Context, id uint64) (domain.Biz
 		},
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.FindById(ctx, &businessv1.FindByIdRequest{
 		FieldMask: fieldMask,
 		BizId:     id,
@@ -239,7 +265,7 @@ func (s *DefaultBizService) FindById(ctx context.Context, id uint64) (domain.Biz
 
 	user, err := s.userRepo.FindById(ctx, bi.CreatorId)
 	if err != nil {
-		s.logger.Warn("[kuryr-admin] failed to find user", zap.Error(err))
+		s.logger.Warn("[kuryr-admin] failed to find user", zap.Error(err), reqid.Field(ctx))
 	}
 
 	user.Password = "" // 不返回密码
@@ -277,8 +303,9 @@ func (s *DefaultBizService) pbToDomain(pb *businessv1.BusinessInfo) domain.BizIn
 }
 func NewDefaultBizService(
 	grpcServerName string,
-	grpcClients *client.Manager[businessv1.BusinessServiceClient],
+	grpcClients *pkggrpc.Manager[businessv1.BusinessServiceClient],
 	userRepo repository.UserRepo,
+	presetSvc BizConfigPresetService,
 	passwdGenerator secret.Generator,
 	logger *zap.Logger,
 ) *DefaultBizService {
@@ -286,7 +313,8 @@ func NewDefaultBizService(
 		grpcServerName: grpcServerName,
 		grpcClients:    grpcClients,
 
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		presetSvc: presetSvc,
 
 		passwdGenerator: passwdGenerator,
 		logger:          logger,
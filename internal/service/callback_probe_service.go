@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JrMarcco/easy-grpc/registry"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// CallbackProbeService 回调服务连通性探测。
+type CallbackProbeService interface {
+	// Probe 通过注册中心解析回调服务，并发对每个实例执行 grpc 健康检查。
+	// 注册中心内没有实例时返回空的实例列表，而不是错误。
+	Probe(ctx context.Context, serviceName string) (domain.CallbackProbe, error)
+}
+
+var _ CallbackProbeService = (*DefaultCallbackProbeService)(nil)
+
+type DefaultCallbackProbeService struct {
+	registry registry.Registry
+	timeout  time.Duration
+	dialOpts []grpc.DialOption
+}
+
+func (s *DefaultCallbackProbeService) Probe(ctx context.Context, serviceName string) (domain.CallbackProbe, error) {
+	instances, err := s.registry.ListServices(ctx, serviceName)
+	if err != nil {
+		return domain.CallbackProbe{}, fmt.Errorf("[kuryr-admin] failed to resolve service %s: %w", serviceName, err)
+	}
+
+	res := domain.CallbackProbe{
+		ServiceName: serviceName,
+		Instances:   make([]domain.CallbackInstanceProbe, len(instances)),
+	}
+
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.Instances[i] = s.probe(ctx, instance)
+		}()
+	}
+	wg.Wait()
+
+	for _, instance := range res.Instances {
+		if instance.Status == domain.CallbackProbeStatusServing {
+			res.Serving++
+		}
+	}
+	return res, nil
+}
+
+// probe 直连单个实例执行健康检查，不经过负载均衡，每个实例单独计算超时时间
+func (s *DefaultCallbackProbeService) probe(ctx context.Context, instance registry.ServiceInstance) domain.CallbackInstanceProbe {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	probeStatus, err := s.check(ctx, instance.Addr)
+
+	res := domain.CallbackInstanceProbe{
+		Addr:    instance.Addr,
+		Group:   instance.Group,
+		Status:  probeStatus,
+		Latency: domain.Duration(time.Since(start)),
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res
+}
+
+func (s *DefaultCallbackProbeService) check(ctx context.Context, addr string) (string, error) {
+	conn, err := grpc.NewClient("passthrough:///"+addr, s.dialOpts...)
+	if err != nil {
+		return domain.CallbackProbeStatusUnreachable, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// service 为空表示检查服务整体的健康状态
+	resp, err := healthv1.NewHealthClient(conn).Check(ctx, &healthv1.HealthCheckRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return domain.CallbackProbeStatusUnimplemented, err
+		}
+		return domain.CallbackProbeStatusUnreachable, err
+	}
+
+	if resp.GetStatus() != healthv1.HealthCheckResponse_SERVING {
+		return domain.CallbackProbeStatusNotServing, fmt.Errorf("health check status is %s", resp.GetStatus())
+	}
+	return domain.CallbackProbeStatusServing, nil
+}
+
+// NewDefaultCallbackProbeService timeout 为单个实例的探测超时时间，
+// dialOpts 至少需要包含传输层凭证。
+func NewDefaultCallbackProbeService(
+	r registry.Registry, timeout time.Duration, dialOpts ...grpc.DialOption,
+) *DefaultCallbackProbeService {
+	return &DefaultCallbackProbeService{
+		registry: r,
+		timeout:  timeout,
+		dialOpts: dialOpts,
+	}
+}
@@ -3,11 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
-	"time"
 
-	"github.com/JrMarcco/easy-grpc/client"
 	"github.com/JrMarcco/easy-kit/slice"
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
 	commonv1 "github.com/JrMarcco/kuryr-api/api/go/common/v1"
 	providerv1 "github.com/JrMarcco/kuryr-api/api/go/provider/v1"
 )
@@ -22,7 +21,7 @@ var _ ProviderService = (*DefaultProviderService)(nil)
 
 type DefaultProviderService struct {
 	grpcServerName string
-	grpcClients    *client.Manager[providerv1.ProviderServiceClient]
+	grpcClients    *pkggrpc.Manager[providerv1.ProviderServiceClient]
 }
 
 func (s *DefaultProviderService) Save(ctx context.Context, provider domain.Provider) (domain.Provider, error) {
@@ -31,9 +30,6 @@ func (s *DefaultProviderService) Save(ctx context.Context, provider domain.Provi
 		return domain.Provider{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.Save(ctx, &providerv1.SaveRequest{Provider: s.domainToPb(provider)})
 
 	if err != nil {
@@ -65,9 +61,6 @@ func (s *DefaultProviderService) List(ctx context.Context) ([]domain.Provider, e
 		return nil, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.List(ctx, &providerv1.ListRequest{})
 
 	if err != nil {
@@ -90,9 +83,6 @@ func (s *DefaultProviderService) FindByChannel(ctx context.Context, channel int3
 		return nil, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.FindByChannel(ctx, &providerv1.FindByChannelRequest{Channel: commonv1.Channel(channel)})
 
 	if err != nil {
@@ -128,7 +118,7 @@ func (s *DefaultProviderService) pbToDomain(pb *providerv1.Provider) domain.Prov
 }
 
 func NewDefaultProviderService(
-	grpcServerName string, grpcClients *client.Manager[providerv1.ProviderServiceClient],
+	grpcServerName string, grpcClients *pkggrpc.Manager[providerv1.ProviderServiceClient],
 ) *DefaultProviderService {
 	return &DefaultProviderService{
 		grpcServerName: grpcServerName,
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
+	commonv1 "github.com/JrMarcco/kuryr-api/api/go/common/v1"
+	notificationv1 "github.com/JrMarcco/kuryr-api/api/go/notification/v1"
+	"go.uber.org/zap"
+)
+
+// QuotaAlertNotifier 配额告警发送。
+type QuotaAlertNotifier interface {
+	Notify(ctx context.Context, alert domain.QuotaAlert) error
+}
+
+var _ QuotaAlertNotifier = (*LogQuotaAlertNotifier)(nil)
+
+// LogQuotaAlertNotifier 只记录告警日志，用于没有配置告警邮件的环境。
+type LogQuotaAlertNotifier struct {
+	logger *zap.Logger
+}
+
+func (n *LogQuotaAlertNotifier) Notify(_ context.Context, alert domain.QuotaAlert) error {
+	n.logger.Warn("[kuryr-admin] quota alert",
+		zap.Uint64("biz_id", alert.BizId),
+		zap.String("biz_name", alert.BizName),
+		zap.String("channel", alert.Channel),
+		zap.String("period", alert.Period),
+		zap.String("period_key", alert.PeriodKey),
+		zap.Int32("threshold", alert.Threshold),
+		zap.Int32("used", alert.Used),
+		zap.Int32("limit", alert.Limit),
+		zap.Strings("receivers", alert.Receivers),
+	)
+	return nil
+}
+
+func NewLogQuotaAlertNotifier(logger *zap.Logger) *LogQuotaAlertNotifier {
+	return &LogQuotaAlertNotifier{logger: logger}
+}
+
+var _ QuotaAlertNotifier = (*KuryrQuotaAlertNotifier)(nil)
+
+// KuryrQuotaAlertNotifier 通过 kuryr 以指定业务方的身份发送告警邮件。
+// 模板参数为 biz_id / biz_name / channel / period / period_key / threshold / used / limit。
+type KuryrQuotaAlertNotifier struct {
+	grpcServerName string
+	grpcClients    *pkggrpc.Manager[notificationv1.NotificationServiceClient]
+
+	bizKey string
+	tplId  string
+}
+
+func (n *KuryrQuotaAlertNotifier) Notify(ctx context.Context, alert domain.QuotaAlert) error {
+	if len(alert.Receivers) == 0 {
+		return fmt.Errorf("[kuryr-admin] no receiver for quota alert of biz %d", alert.BizId)
+	}
+
+	grpcClient, err := n.grpcClients.Get(n.grpcServerName)
+	if err != nil {
+		return fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
+	}
+
+	resp, err := grpcClient.Send(ctx, &notificationv1.SendRequest{
+		Notification: &notificationv1.Notification{
+			BizKey:    n.bizKey,
+			Receivers: alert.Receivers,
+			Channel:   commonv1.Channel_EMAIL,
+			TplId:     n.tplId,
+			TplParams: map[string]string{
+				"biz_id":     strconv.FormatUint(alert.BizId, 10),
+				"biz_name":   alert.BizName,
+				"channel":    alert.Channel,
+				"period":     alert.Period,
+				"period_key": alert.PeriodKey,
+				"threshold":  strconv.Itoa(int(alert.Threshold)),
+				"used":       strconv.Itoa(int(alert.Used)),
+				"limit":      strconv.Itoa(int(alert.Limit)),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("[kuryr-admin] failed to send quota alert: %w", err)
+	}
+
+	if res := resp.GetResult(); res.GetStatus() == notificationv1.SendStatus_FAILURE {
+		return fmt.Errorf("[kuryr-admin] failed to send quota alert: %s", res.GetErrMsg())
+	}
+	return nil
+}
+
+func NewKuryrQuotaAlertNotifier(
+	grpcServerName string,
+	grpcClients *pkggrpc.Manager[notificationv1.NotificationServiceClient],
+	bizKey string,
+	tplId string,
+) *KuryrQuotaAlertNotifier {
+	return &KuryrQuotaAlertNotifier{
+		grpcServerName: grpcServerName,
+		grpcClients:    grpcClients,
+		bizKey:         bizKey,
+		tplId:          tplId,
+	}
+}
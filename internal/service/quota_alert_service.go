@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	"github.com/JrMarcco/kuryr-admin/internal/repository"
+	"go.uber.org/zap"
+)
+
+type QuotaAlertService interface {
+	// SaveRule 保存业务方渠道的告警阈值，阈值不合法时返回包装 errs.ErrInvalidQuotaAlertRule 的错误
+	SaveRule(ctx context.Context, rule domain.QuotaAlertRule) (domain.QuotaAlertRule, error)
+	DeleteRule(ctx context.Context, bizId uint64, channel string) error
+	FindRules(ctx context.Context, bizId uint64) ([]domain.QuotaAlertRule, error)
+
+	// Evaluate 检查所有启用告警的业务方的配额用量，用量达到阈值时发送告警，返回本次发送的告警。
+	// 同一业务方、渠道与周期内每个阈值只告警一次，一次检查同时达到多个阈值时只发送最高阈值的告警。
+	// 单个业务方检查失败不影响其他业务方，所有错误合并后返回。没有接入用量来源时不做任何检查。
+	Evaluate(ctx context.Context) ([]domain.QuotaAlert, error)
+}
+
+var _ QuotaAlertService = (*DefaultQuotaAlertService)(nil)
+
+type DefaultQuotaAlertService struct {
+	repo     repository.QuotaAlertRepo
+	usageSvc QuotaUsageService
+	bizSvc   BizService
+	userRepo repository.UserRepo
+	notifier QuotaAlertNotifier
+	logger   *zap.Logger
+}
+
+func (s *DefaultQuotaAlertService) SaveRule(ctx context.Context, rule domain.QuotaAlertRule) (domain.QuotaAlertRule, error) {
+	var msgs []string
+	if rule.BizId == 0 {
+		msgs = append(msgs, "biz_id: must be greater than 0")
+	}
+	if rule.Channel != domain.QuotaChannelSms && rule.Channel != domain.QuotaChannelEmail {
+		msgs = append(msgs, fmt.Sprintf("channel: must be one of [%s %s], got %q",
+			domain.QuotaChannelSms, domain.QuotaChannelEmail, rule.Channel))
+	}
+	if len(rule.Thresholds) == 0 {
+		msgs = append(msgs, "thresholds: is required")
+	}
+	for i, threshold := range rule.Thresholds {
+		if threshold < 1 || threshold > 100 {
+			msgs = append(msgs, fmt.Sprintf("thresholds[%d]: must be in [1, 100], got %d", i, threshold))
+		}
+	}
+	if len(msgs) > 0 {
+		return domain.QuotaAlertRule{}, fmt.Errorf("%w: %s", errs.ErrInvalidQuotaAlertRule, strings.Join(msgs, "; "))
+	}
+
+	thresholds := slices.Clone(rule.Thresholds)
+	slices.Sort(thresholds)
+	rule.Thresholds = slices.Compact(thresholds)
+	return s.repo.SaveRule(ctx, rule)
+}
+
+func (s *DefaultQuotaAlertService) DeleteRule(ctx context.Context, bizId uint64, channel string) error {
+	return s.repo.DeleteRule(ctx, bizId, channel)
+}
+
+func (s *DefaultQuotaAlertService) FindRules(ctx context.Context, bizId uint64) ([]domain.QuotaAlertRule, error) {
+	return s.repo.FindRules(ctx, bizId)
+}
+
+func (s *DefaultQuotaAlertService) Evaluate(ctx context.Context) ([]domain.QuotaAlert, error) {
+	// 没有用量数据时无法判断是否达到阈值，避免逐个查询业务方配置
+	if !s.usageSvc.Available() {
+		return nil, nil
+	}
+
+	rules, err := s.repo.FindEnabledRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 规则按 biz_id 排序，同一业务方的规则只查询一次用量
+	var sent []domain.QuotaAlert
+	var evalErrs []error
+	for start := 0; start < len(rules); {
+		end := start + 1
+		for end < len(rules) && rules[end].BizId == rules[start].BizId {
+			end++
+		}
+
+		alerts, err := s.evaluateBiz(ctx, rules[start].BizId, rules[start:end])
+		sent = append(sent, alerts...)
+		if err != nil {
+			evalErrs = append(evalErrs, fmt.Errorf("biz %d: %w", rules[start].BizId, err))
+		}
+		start = end
+	}
+	return sent, errors.Join(evalErrs...)
+}
+
+// pendingAlert 已经写入告警记录、等待发送的告警
+type pendingAlert struct {
+	alert   domain.QuotaAlert
+	claimed []domain.QuotaAlert // 本次写入的每个阈值的告警记录，发送失败时删除
+}
+
+func (s *DefaultQuotaAlertService) evaluateBiz(
+	ctx context.Context, bizId uint64, rules []domain.QuotaAlertRule,
+) ([]domain.QuotaAlert, error) {
+	usage, err := s.usageSvc.Usage(ctx, bizId)
+	if err != nil {
+		return nil, err
+	}
+	if !usage.Available {
+		return nil, nil
+	}
+
+	var pending []pendingAlert
+	for _, rule := range rules {
+		for _, item := range usage.Items {
+			if item.Channel != rule.Channel {
+				continue
+			}
+
+			p, err := s.claim(ctx, bizId, rule.Thresholds, item, usage.At)
+			if err != nil {
+				s.release(ctx, pending)
+				return nil, err
+			}
+			if len(p.claimed) > 0 {
+				pending = append(pending, p)
+			}
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	bizName, receivers, err := s.receivers(ctx, bizId)
+	if err != nil {
+		s.release(ctx, pending)
+		return nil, err
+	}
+
+	sent := make([]domain.QuotaAlert, 0, len(pending))
+	var notifyErrs []error
+	for _, p := range pending {
+		p.alert.BizName = bizName
+		p.alert.Receivers = receivers
+		if err = s.notifier.Notify(ctx, p.alert); err != nil {
+			s.release(ctx, []pendingAlert{p})
+			notifyErrs = append(notifyErrs, err)
+			continue
+		}
+		sent = append(sent, p.alert)
+	}
+	return sent, errors.Join(notifyErrs...)
+}
+
+// claim 为用量达到的每个阈值写入告警记录，已经告警过的阈值会被跳过。
+// 配额为 0 表示不允许使用该渠道，不告警。
+func (s *DefaultQuotaAlertService) claim(
+	ctx context.Context, bizId uint64, thresholds []int32, item domain.QuotaUsageItem, at int64,
+) (pendingAlert, error) {
+	if item.Limit <= 0 || item.Used == nil {
+		return pendingAlert{}, nil
+	}
+
+	base := domain.QuotaAlert{
+		BizId:     bizId,
+		Channel:   item.Channel,
+		Period:    item.Period,
+		PeriodKey: quotaPeriodKey(item.Period, time.UnixMilli(at)),
+		Limit:     item.Limit,
+		Used:      *item.Used,
+		CreatedAt: at,
+	}
+
+	percent := int64(*item.Used) * 100 / int64(item.Limit)
+
+	var p pendingAlert
+	for _, threshold := range thresholds {
+		if int64(threshold) > percent {
+			break
+		}
+
+		a := base
+		a.Threshold = threshold
+		created, err := s.repo.SaveAlert(ctx, a)
+		if err != nil {
+			s.release(ctx, []pendingAlert{p})
+			return pendingAlert{}, err
+		}
+		if created {
+			p.alert = a
+			p.claimed = append(p.claimed, a)
+		}
+	}
+	return p, nil
+}
+
+// release 删除未能发送的告警记录，下次检查时重新告警
+func (s *DefaultQuotaAlertService) release(ctx context.Context, pending []pendingAlert) {
+	for _, p := range pending {
+		for _, a := range p.claimed {
+			if err := s.repo.DeleteAlert(ctx, a); err != nil {
+				s.logger.Error("[kuryr-admin] failed to release quota alert record",
+					zap.Uint64("biz_id", a.BizId),
+					zap.String("channel", a.Channel),
+					zap.String("period_key", a.PeriodKey),
+					zap.Int32("threshold", a.Threshold),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// receivers 告警接收人为业务方联系人与所有管理员，查询业务方信息失败时只发送给管理员
+func (s *DefaultQuotaAlertService) receivers(ctx context.Context, bizId uint64) (string, []string, error) {
+	var bizName string
+	var receivers []string
+
+	bi, err := s.bizSvc.FindById(ctx, bizId)
+	if err != nil {
+		s.logger.Warn("[kuryr-admin] failed to find biz info for quota alert", zap.Uint64("biz_id", bizId), zap.Error(err))
+	} else {
+		bizName = bi.BizName
+		if bi.ContactEmail != "" {
+			receivers = append(receivers, bi.ContactEmail)
+		}
+	}
+
+	admins, err := s.userRepo.FindByUserType(ctx, domain.UserTypeAdmin)
+	if err != nil {
+		return "", nil, fmt.Errorf("[kuryr-admin] failed to find admins: %w", err)
+	}
+	for _, admin := range admins {
+		if admin.Email != "" && !slices.Contains(receivers, admin.Email) {
+			receivers = append(receivers, admin.Email)
+		}
+	}
+	return bizName, receivers, nil
+}
+
+// quotaPeriodKey 配额周期标识，与 quotaUsageItems 一致按自然日与自然月划分
+func quotaPeriodKey(period string, at time.Time) string {
+	if period == domain.QuotaPeriodMonthly {
+		return at.Format("2006-01")
+	}
+	return at.Format(time.DateOnly)
+}
+
+func NewDefaultQuotaAlertService(
+	repo repository.QuotaAlertRepo,
+	usageSvc QuotaUsageService,
+	bizSvc BizService,
+	userRepo repository.UserRepo,
+	notifier QuotaAlertNotifier,
+	logger *zap.Logger,
+) *DefaultQuotaAlertService {
+	return &DefaultQuotaAlertService{
+		repo:     repo,
+		usageSvc: usageSvc,
+		bizSvc:   bizSvc,
+		userRepo: userRepo,
+		notifier: notifier,
+		logger:   logger,
+	}
+}
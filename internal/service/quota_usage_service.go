@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	"go.uber.org/zap"
+)
+
+// QuotaUsageSource 配额用量来源。
+// kuryr-api 目前没有提供用量统计接口，通过该接口接入用量数据，无法获取用量时返回 errs.ErrQuotaUsageUnavailable。
+type QuotaUsageSource interface {
+	// Consumption 查询业务方在 at 所在自然日与自然月内已消耗的配额
+	Consumption(ctx context.Context, bizId uint64, at time.Time) (domain.QuotaConsumption, error)
+}
+
+var _ QuotaUsageSource = (*UnavailableQuotaUsageSource)(nil)
+
+// UnavailableQuotaUsageSource 默认的用量来源，kuryr 提供用量统计接口之前始终返回 errs.ErrQuotaUsageUnavailable。
+type UnavailableQuotaUsageSource struct{}
+
+func (s *UnavailableQuotaUsageSource) Consumption(_ context.Context, _ uint64, _ time.Time) (domain.QuotaConsumption, error) {
+	return domain.QuotaConsumption{}, errs.ErrQuotaUsageUnavailable
+}
+
+func NewUnavailableQuotaUsageSource() *UnavailableQuotaUsageSource {
+	return &UnavailableQuotaUsageSource{}
+}
+
+type QuotaUsageService interface {
+	// Usage 查询业务方配置的配额与当前周期内的用量。
+	// 业务方没有配额配置时返回空的用量列表，用量不可用时只返回配置的配额。
+	Usage(ctx context.Context, bizId uint64) (domain.QuotaUsage, error)
+	// Available 是否接入了用量来源，使用默认的 UnavailableQuotaUsageSource 时返回 false
+	Available() bool
+}
+
+var _ QuotaUsageService = (*DefaultQuotaUsageService)(nil)
+
+type DefaultQuotaUsageService struct {
+	configSvc BizConfigService
+	source    QuotaUsageSource
+	now       func() time.Time
+	logger    *zap.Logger
+}
+
+func (s *DefaultQuotaUsageService) Usage(ctx context.Context, bizId uint64) (domain.QuotaUsage, error) {
+	now := s.now()
+	res := domain.QuotaUsage{
+		BizId: bizId,
+		At:    now.UnixMilli(),
+		Items: []domain.QuotaUsageItem{},
+	}
+
+	bizConfig, err := s.configSvc.FindByBizId(ctx, bizId)
+	if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+		return domain.QuotaUsage{}, err
+	}
+	if bizConfig.QuotaConfig == nil {
+		return res, nil
+	}
+
+	consumption, err := s.source.Consumption(ctx, bizId, now)
+	switch {
+	case err == nil:
+		res.Available = true
+	case errors.Is(err, errs.ErrQuotaUsageUnavailable):
+	default:
+		// 用量来源不可用时仍然返回配置的配额
+		s.logger.Warn("[kuryr-admin] failed to get quota consumption", zap.Uint64("biz_id", bizId), zap.Error(err))
+	}
+
+	var consumed *domain.QuotaConsumption
+	if res.Available {
+		consumed = &consumption
+	}
+	res.Items = quotaUsageItems(bizConfig.QuotaConfig, consumed, now)
+	return res, nil
+}
+
+func (s *DefaultQuotaUsageService) Available() bool {
+	_, unavailable := s.source.(*UnavailableQuotaUsageSource)
+	return !unavailable
+}
+
+// quotaUsageItems 按渠道计算每个周期的配额用量，consumed 为空时只返回配置的配额。
+// 没有配置的周期不返回。
+func quotaUsageItems(qc *domain.QuotaConfig, consumed *domain.QuotaConsumption, now time.Time) []domain.QuotaUsageItem {
+	var daily, monthly domain.Quota
+	if consumed != nil {
+		daily, monthly = consumed.Daily, consumed.Monthly
+	}
+
+	periods := []struct {
+		name       string
+		quota      *domain.Quota
+		used       domain.Quota
+		start, end time.Time
+	}{
+		{domain.QuotaPeriodDaily, qc.Daily, daily, startOfDay(now), startOfDay(now).AddDate(0, 0, 1)},
+		{domain.QuotaPeriodMonthly, qc.Monthly, monthly, startOfMonth(now), startOfMonth(now).AddDate(0, 1, 0)},
+	}
+
+	items := make([]domain.QuotaUsageItem, 0, 4)
+	for _, p := range periods {
+		if p.quota == nil {
+			continue
+		}
+
+		channels := []struct {
+			name        string
+			limit, used int32
+		}{
+			{domain.QuotaChannelSms, p.quota.Sms, p.used.Sms},
+			{domain.QuotaChannelEmail, p.quota.Email, p.used.Email},
+		}
+		for _, c := range channels {
+			item := domain.QuotaUsageItem{
+				Period:    p.name,
+				Channel:   c.name,
+				Limit:     c.limit,
+				PeriodEnd: p.end.UnixMilli(),
+			}
+			if consumed != nil {
+				used, remaining := c.used, max(c.limit-c.used, 0)
+				item.Used = &used
+				item.Remaining = &remaining
+				item.Exhausted = remaining == 0
+				item.ExhaustAt = projectExhaustion(used, remaining, p.start, p.end, now)
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// projectExhaustion 按周期内的平均消耗速度线性预测配额耗尽的时间（unix 毫秒）。
+// 没有消耗、已经耗尽或预计周期结束前不会耗尽时返回 0。
+func projectExhaustion(used int32, remaining int32, start time.Time, end time.Time, now time.Time) int64 {
+	elapsed := now.Sub(start)
+	if used <= 0 || remaining <= 0 || elapsed <= 0 {
+		return 0
+	}
+
+	// 消耗剩余配额需要的时间 = 剩余配额 / 平均消耗速度
+	exhaustAt := now.Add(time.Duration(float64(elapsed) * float64(remaining) / float64(used)))
+	if !exhaustAt.Before(end) {
+		return 0
+	}
+	return exhaustAt.UnixMilli()
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func NewDefaultQuotaUsageService(
+	configSvc BizConfigService, source QuotaUsageSource, logger *zap.Logger,
+) *DefaultQuotaUsageService {
+	return &DefaultQuotaUsageService{
+		configSvc: configSvc,
+		source:    source,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaUsageItems(t *testing.T) {
+	t.Parallel()
+
+	// 10 月 11 日 12 点，自然日过去一半，自然月过去 10.5 天
+	now := time.Date(2026, 10, 11, 12, 0, 0, 0, time.UTC)
+	dayEnd := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC).UnixMilli()
+	monthEnd := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	n := func(val int32) *int32 { return &val }
+
+	qc := &domain.QuotaConfig{
+		Daily:   &domain.Quota{Sms: 100, Email: 1000},
+		Monthly: &domain.Quota{Sms: 3000, Email: 20000},
+	}
+
+	tcs := []struct {
+		name     string
+		qc       *domain.QuotaConfig
+		consumed *domain.QuotaConsumption
+		want     []domain.QuotaUsageItem
+	}{
+		{
+			name: "usage unavailable",
+			qc:   qc,
+			want: []domain.QuotaUsageItem{
+				{Period: domain.QuotaPeriodDaily, Channel: domain.QuotaChannelSms, Limit: 100, PeriodEnd: dayEnd},
+				{Period: domain.QuotaPeriodDaily, Channel: domain.QuotaChannelEmail, Limit: 1000, PeriodEnd: dayEnd},
+				{Period: domain.QuotaPeriodMonthly, Channel: domain.QuotaChannelSms, Limit: 3000, PeriodEnd: monthEnd},
+				{Period: domain.QuotaPeriodMonthly, Channel: domain.QuotaChannelEmail, Limit: 20000, PeriodEnd: monthEnd},
+			},
+		}, {
+			name: "projection",
+			qc:   qc,
+			consumed: &domain.QuotaConsumption{
+				// 短信半天用了 80，预计再过 3 小时耗尽；邮件按当前速度当天不会耗尽
+				Daily: domain.Quota{Sms: 80, Email: 100},
+				// 短信超出配额；邮件 10.5 天用了 10500，预计再过 9.5 天耗尽
+				Monthly: domain.Quota{Sms: 3100, Email: 10500},
+			},
+			want: []domain.QuotaUsageItem{
+				{
+					Period: domain.QuotaPeriodDaily, Channel: domain.QuotaChannelSms, Limit: 100, Used: n(80), Remaining: n(20),
+					ExhaustAt: now.Add(3 * time.Hour).UnixMilli(), PeriodEnd: dayEnd,
+				},
+				{
+					Period: domain.QuotaPeriodDaily, Channel: domain.QuotaChannelEmail, Limit: 1000, Used: n(100), Remaining: n(900),
+					PeriodEnd: dayEnd,
+				},
+				{
+					Period: domain.QuotaPeriodMonthly, Channel: domain.QuotaChannelSms, Limit: 3000, Used: n(3100), Remaining: n(0),
+					Exhausted: true, PeriodEnd: monthEnd,
+				},
+				{
+					Period: domain.QuotaPeriodMonthly, Channel: domain.QuotaChannelEmail, Limit: 20000, Used: n(10500), Remaining: n(9500),
+					ExhaustAt: now.Add(228 * time.Hour).UnixMilli(), PeriodEnd: monthEnd,
+				},
+			},
+		}, {
+			name:     "only daily configured",
+			qc:       &domain.QuotaConfig{Daily: &domain.Quota{Sms: 0, Email: 10}},
+			consumed: &domain.QuotaConsumption{},
+			want: []domain.QuotaUsageItem{
+				{
+					Period: domain.QuotaPeriodDaily, Channel: domain.QuotaChannelSms, Limit: 0, Used: n(0), Remaining: n(0),
+					Exhausted: true, PeriodEnd: dayEnd,
+				},
+				{
+					Period: domain.QuotaPeriodDaily, Channel: domain.QuotaChannelEmail, Limit: 10, Used: n(0), Remaining: n(10),
+					PeriodEnd: dayEnd,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, quotaUsageItems(tc.qc, tc.consumed, now))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JrMarcco/easy-kit/retry"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+)
+
+// maxSimulatedAttempts 不限制重试次数时模拟的重试次数
+const maxSimulatedAttempts = 100
+
+// SimulateRetry 按重试策略计算重试时间表。
+// 与 kuryr 一致使用指数退避：第 n 次重试的间隔为 initial_interval * 2^(n-1)，超过 max_interval 后固定为 max_interval。
+// 重试策略不合法时返回包装 errs.ErrInvalidBizConfig 的错误。
+func SimulateRetry(cfg domain.RetryConfig) (domain.RetrySchedule, error) {
+	c := &bizConfigChecker{}
+	c.retry(&cfg, "retry_policy_config")
+	if len(c.msgs) > 0 {
+		return domain.RetrySchedule{}, fmt.Errorf("%w: %s", errs.ErrInvalidBizConfig, strings.Join(c.msgs, "; "))
+	}
+
+	strategy, err := retry.NewExponentialBackoffStrategy(
+		cfg.InitialInterval.Duration(), cfg.MaxInterval.Duration(), cfg.MaxRetryTimes,
+	)
+	if err != nil {
+		return domain.RetrySchedule{}, fmt.Errorf("%w: %w", errs.ErrInvalidBizConfig, err)
+	}
+
+	schedule := domain.RetrySchedule{
+		Attempts:  make([]domain.RetryAttempt, 0, min(max(cfg.MaxRetryTimes, 0), maxSimulatedAttempts)),
+		Unlimited: cfg.MaxRetryTimes == 0,
+	}
+	for attempt := int32(1); attempt <= maxSimulatedAttempts; attempt++ {
+		interval, ok := strategy.Next()
+		if !ok {
+			break
+		}
+
+		schedule.TotalDelay += domain.Duration(interval)
+		schedule.Attempts = append(schedule.Attempts, domain.RetryAttempt{
+			Attempt:  attempt,
+			Interval: domain.Duration(interval),
+			Elapsed:  schedule.TotalDelay,
+		})
+	}
+	return schedule, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateRetry(t *testing.T) {
+	t.Parallel()
+
+	d := func(val time.Duration) domain.Duration { return domain.Duration(val) }
+
+	tcs := []struct {
+		name      string
+		cfg       domain.RetryConfig
+		want      []domain.RetryAttempt
+		wantTotal time.Duration
+		wantErr   bool
+	}{
+		{
+			name: "exponential backoff capped by max interval",
+			cfg:  domain.RetryConfig{InitialInterval: d(time.Second), MaxInterval: d(5 * time.Second), MaxRetryTimes: 5},
+			want: []domain.RetryAttempt{
+				{Attempt: 1, Interval: d(time.Second), Elapsed: d(time.Second)},
+				{Attempt: 2, Interval: d(2 * time.Second), Elapsed: d(3 * time.Second)},
+				{Attempt: 3, Interval: d(4 * time.Second), Elapsed: d(7 * time.Second)},
+				{Attempt: 4, Interval: d(5 * time.Second), Elapsed: d(12 * time.Second)},
+				{Attempt: 5, Interval: d(5 * time.Second), Elapsed: d(17 * time.Second)},
+			},
+			wantTotal: 17 * time.Second,
+		}, {
+			name: "fixed interval",
+			cfg:  domain.RetryConfig{InitialInterval: d(time.Second), MaxInterval: d(time.Second), MaxRetryTimes: 2},
+			want: []domain.RetryAttempt{
+				{Attempt: 1, Interval: d(time.Second), Elapsed: d(time.Second)},
+				{Attempt: 2, Interval: d(time.Second), Elapsed: d(2 * time.Second)},
+			},
+			wantTotal: 2 * time.Second,
+		}, {
+			name:    "invalid",
+			cfg:     domain.RetryConfig{InitialInterval: d(time.Minute), MaxInterval: d(time.Second), MaxRetryTimes: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := SimulateRetry(tc.cfg)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errs.ErrInvalidBizConfig)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, schedule.Attempts)
+			assert.Equal(t, d(tc.wantTotal), schedule.TotalDelay)
+			assert.False(t, schedule.Unlimited)
+		})
+	}
+}
+
+func TestSimulateRetry_Unlimited(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := SimulateRetry(domain.RetryConfig{
+		InitialInterval: domain.Duration(time.Second),
+		MaxInterval:     domain.Duration(time.Minute),
+	})
+	require.NoError(t, err)
+	assert.True(t, schedule.Unlimited)
+	require.Len(t, schedule.Attempts, maxSimulatedAttempts)
+	assert.Equal(t, domain.Duration(time.Minute), schedule.Attempts[maxSimulatedAttempts-1].Interval)
+}
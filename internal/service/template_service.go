@@ -3,10 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
-	"time"
 
-	"github.com/JrMarcco/easy-grpc/client"
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggrpc "github.com/JrMarcco/kuryr-admin/internal/pkg/grpc"
 	commonv1 "github.com/JrMarcco/kuryr-api/api/go/common/v1"
 	templatev1 "github.com/JrMarcco/kuryr-api/api/go/template/v1"
 )
@@ -21,7 +20,7 @@ var _ TemplateService = (*DefaultTemplateService)(nil)
 
 type DefaultTemplateService struct {
 	grpcServerName string
-	grpcClients    *client.Manager[templatev1.TemplateServiceClient]
+	grpcClients    *pkggrpc.Manager[templatev1.TemplateServiceClient]
 }
 
 func (s *DefaultTemplateService) Save(ctx context.Context, tpl domain.ChannelTemplate) (domain.ChannelTemplate, error) {
@@ -30,9 +29,6 @@ func (s *DefaultTemplateService) Save(ctx context.Context, tpl domain.ChannelTem
 		return domain.ChannelTemplate{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.SaveTemplate(ctx, &templatev1.SaveTemplateRequest{Template: s.domainToPb(tpl)})
 	if err != nil {
 		return domain.ChannelTemplate{}, fmt.Errorf("[kuryr-admin] failed to save template: %w", err)
@@ -46,9 +42,6 @@ func (s *DefaultTemplateService) SaveVersion(ctx context.Context, version domain
 		return domain.ChannelTemplateVersion{}, fmt.Errorf("[kuryr-admin] failed to get grpc client: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-	defer cancel()
-
 	resp, err := grpcClient.SaveTemplateVersion(
 		ctx,
 		&templatev1.SaveTemplateVersionRequest{Version: s.domainToPbVersion(version)},
@@ -116,7 +109,7 @@ func (s *DefaultTemplateService) pbToDomainVersion(pb *templatev1.TemplateVersio
 
 func NewDefaultTemplateService(
 	grpcServerName string,
-	grpcClients *client.Manager[templatev1.TemplateServiceClient],
+	grpcClients *pkggrpc.Manager[templatev1.TemplateServiceClient],
 ) *DefaultTemplateService {
 	return &DefaultTemplateService{
 		grpcServerName: grpcServerName,
@@ -0,0 +1,178 @@
+package web
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/JrMarcco/kuryr-admin/internal/search"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAuditPageSize = 20
+	maxAuditPageSize     = 200
+)
+
+var auditCSVHeader = []string{
+	"id", "created_at", "actor_uid", "actor_bid", "actor_sid",
+	"action", "target_type", "target_id", "client_ip", "request_id", "diff",
+}
+
+var _ pkggin.RouteRegistry = (*AuditHandler)(nil)
+
+// AuditHandler 审计日志 web handler，只有管理员可以查询与导出。
+type AuditHandler struct {
+	svc    service.AuditService
+	logger *zap.Logger
+}
+
+func (h *AuditHandler) RegisterRoutes(engine *gin.Engine) {
+	v1 := engine.Group("/api/v1/audit")
+
+	v1.Handle(http.MethodGet, "/search", pkggin.QU(h.Search))
+	v1.Handle(http.MethodGet, "/export", h.Export)
+}
+
+// auditCriteriaReq 审计日志查询条件，时间单位为毫秒，查询范围为 [start_time, end_time)。
+type auditCriteriaReq struct {
+	ActorUid   uint64 `json:"actor_uid" form:"actor_uid"`
+	ActorBid   uint64 `json:"actor_bid" form:"actor_bid"`
+	Action     string `json:"action" form:"action"`
+	TargetType string `json:"target_type" form:"target_type"`
+	TargetId   uint64 `json:"target_id" form:"target_id"`
+	StartTime  int64  `json:"start_time" form:"start_time"`
+	EndTime    int64  `json:"end_time" form:"end_time"`
+}
+
+func (r auditCriteriaReq) criteria() search.AuditCriteria {
+	return search.AuditCriteria{
+		ActorUid:   r.ActorUid,
+		ActorBid:   r.ActorBid,
+		Action:     r.Action,
+		TargetType: r.TargetType,
+		TargetId:   r.TargetId,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+	}
+}
+
+type searchAuditReq struct {
+	auditCriteriaReq
+	*pkggorm.PaginationParam
+}
+
+// Search 按时间倒序分页查询审计日志。
+func (h *AuditHandler) Search(ctx *gin.Context, req searchAuditReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if au.UserType != domain.UserTypeAdmin {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] only admin can search audit log",
+		}, nil
+	}
+
+	param := req.PaginationParam
+	if param == nil {
+		param = &pkggorm.PaginationParam{}
+	}
+	if param.Limit <= 0 {
+		param.Limit = defaultAuditPageSize
+	}
+	param.Limit = min(param.Limit, maxAuditPageSize)
+
+	res, err := h.svc.Search(ctx, req.criteria(), param)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: res,
+	}, nil
+}
+
+// Export 按时间顺序导出所有匹配的审计日志，返回 csv 文件。
+// 数据分批查询并直接写入响应，开始写入后出现的错误只能中断响应并记录日志。
+func (h *AuditHandler) Export(ctx *gin.Context) {
+	au, ok := pkggin.AuthUserFromContext(ctx)
+	if !ok {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if au.UserType != domain.UserTypeAdmin {
+		ctx.PureJSON(http.StatusForbidden, pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] only admin can export audit log",
+		})
+		return
+	}
+
+	var req auditCriteriaReq
+	if err := ctx.BindQuery(&req); err != nil {
+		return
+	}
+
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit_log_%d.csv"`, time.Now().UnixMilli()))
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.Write(auditCSVHeader)
+
+	err := h.svc.Export(ctx, req.criteria(), func(records []domain.AuditLog) error {
+		for _, l := range records {
+			if err := w.Write(auditCSVRow(l)); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	})
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	if err != nil {
+		h.logger.Error("[kuryr-admin] failed to export audit log", zap.Uint64("uid", au.Uid), zap.Error(err), reqid.Field(ctx))
+		ctx.Abort()
+	}
+}
+
+func auditCSVRow(l domain.AuditLog) []string {
+	return []string{
+		strconv.FormatUint(l.Id, 10),
+		time.UnixMilli(l.CreatedAt).UTC().Format(time.RFC3339Nano),
+		strconv.FormatUint(l.ActorUid, 10),
+		strconv.FormatUint(l.ActorBid, 10),
+		csvSafe(l.ActorSid),
+		csvSafe(string(l.Action)),
+		csvSafe(string(l.TargetType)),
+		strconv.FormatUint(l.TargetId, 10),
+		csvSafe(l.ClientIp),
+		csvSafe(l.RequestId),
+		csvSafe(string(l.Diff)),
+	}
+}
+
+// csvSafe 防止 csv 注入，表格软件会把 = + - @ 开头的单元格当作公式执行。
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsAny(s[:1], "=+-@\t\r") {
+		return "'" + s
+	}
+	return s
+}
+
+func NewAuditHandler(svc service.AuditService, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		svc:    svc,
+		logger: logger,
+	}
+}
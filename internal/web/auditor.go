@@ -0,0 +1,57 @@
+package web
+
+import (
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// auditor 记录管理操作的审计日志。
+// 记录时操作已经生效，审计日志写入失败只记录错误日志，不影响接口返回。
+type auditor struct {
+	svc    service.AuditService
+	logger *zap.Logger
+}
+
+func (a auditor) record(
+	ctx *gin.Context,
+	au pkggin.AuthUser,
+	action domain.AuditAction,
+	target domain.AuditTarget,
+	targetId uint64,
+	before, after any,
+) {
+	requestId, _ := reqid.FromContext(ctx)
+
+	l := domain.AuditLog{
+		ActorUid:   au.Uid,
+		ActorBid:   au.Bid,
+		ActorSid:   au.Sid,
+		Action:     action,
+		TargetType: target,
+		TargetId:   targetId,
+		ClientIp:   ctx.ClientIP(),
+		RequestId:  requestId,
+	}
+	if err := a.svc.Record(ctx, l, before, after); err != nil {
+		a.logger.Error(
+			"[kuryr-admin] failed to record audit log",
+			zap.Uint64("uid", au.Uid),
+			zap.String("action", string(action)),
+			zap.String("target_type", string(target)),
+			zap.Uint64("target_id", targetId),
+			zap.Error(err),
+			reqid.Field(ctx),
+		)
+	}
+}
+
+func newAuditor(svc service.AuditService, logger *zap.Logger) auditor {
+	return auditor{
+		svc:    svc,
+		logger: logger,
+	}
+}
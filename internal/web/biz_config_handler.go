@@ -1,28 +1,44 @@
 package web
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
 	"net/http"
-	"time"
+	"unicode/utf8"
 
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
 	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/audit"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
 	"github.com/JrMarcco/kuryr-admin/internal/service"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 var _ pkggin.RouteRegistry = (*BizConfigHandler)(nil)
 
 type BizConfigHandler struct {
-	svc service.BizConfigService
+	auditor
+	svc      service.BizConfigService
+	probeSvc service.CallbackProbeService
 }
 
 func (h *BizConfigHandler) RegisterRoutes(engine *gin.Engine) {
 	v1 := engine.Group("/api/v1/biz_config")
 
-	v1.Handle(http.MethodPost, "/save", pkggin.B(h.Save))
-	v1.Handle(http.MethodGet, "/find", pkggin.Q(h.Find))
+	v1.Handle(http.MethodPost, "/save", pkggin.BU(h.Save))
+	v1.Handle(http.MethodPatch, "/update", pkggin.BU(h.Patch))
+	v1.Handle(http.MethodGet, "/find", pkggin.QU(h.Find))
+
+	v1.Handle(http.MethodGet, "/versions", pkggin.QU(h.ListVersions))
+	v1.Handle(http.MethodGet, "/version", pkggin.QU(h.FindVersion))
+	v1.Handle(http.MethodGet, "/diff", pkggin.QU(h.Diff))
+	v1.Handle(http.MethodPost, "/rollback", pkggin.BU(h.Rollback))
+
+	v1.Handle(http.MethodPost, "/simulate_retry", pkggin.B(h.SimulateRetry))
+	v1.Handle(http.MethodGet, "/callback_probe", pkggin.QU(h.ProbeCallback))
 }
 
 type saveBizConfigReq struct {
@@ -32,6 +48,7 @@ type saveBizConfigReq struct {
 	QuotaConfig    *quotaConfig    `json:"quota_config,omitempty"`
 	CallbackConfig *callbackConfig `json:"callback_config,omitempty"`
 	RateLimit      int32           `json:"rate_limit"`
+	Comment        string          `json:"comment"` // 变更说明，记录在配置版本内
 }
 
 type channelConfig struct {
@@ -46,8 +63,8 @@ type channelItem struct {
 }
 
 type quotaConfig struct {
-	Daily   quota `json:"daily,omitempty"`
-	Monthly quota `json:"monthly,omitempty"`
+	Daily   *quota `json:"daily,omitempty"`
+	Monthly *quota `json:"monthly,omitempty"`
 }
 
 type quota struct {
@@ -61,18 +78,24 @@ type callbackConfig struct {
 }
 
 type retryConfig struct {
-	InitialInterval int32 `json:"initial_interval"`
-	MaxInterval     int32 `json:"max_interval"`
-	MaxRetryTimes   int32 `json:"max_retry_times"`
+	InitialInterval domain.Duration `json:"initial_interval"` // 例如 "500ms"，整数时单位为毫秒
+	MaxInterval     domain.Duration `json:"max_interval"`
+	MaxRetryTimes   int32           `json:"max_retry_times"`
 }
 
-func (h *BizConfigHandler) Save(ctx *gin.Context, req saveBizConfigReq) (pkggin.R, error) {
+func (h *BizConfigHandler) Save(ctx *gin.Context, req saveBizConfigReq, au pkggin.AuthUser) (pkggin.R, error) {
 	if req.BizId == 0 {
 		return pkggin.R{
 			Code: http.StatusBadRequest,
 			Msg:  "invalid biz_id, must be greater than 0",
 		}, nil
 	}
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only save own biz config",
+		}, nil
+	}
 
 	if req.RateLimit < 0 {
 		return pkggin.R{
@@ -80,6 +103,9 @@ func (h *BizConfigHandler) Save(ctx *gin.Context, req saveBizConfigReq) (pkggin.
 			Msg:  "invalid rate_limit, must be greater than or equal to 0",
 		}, nil
 	}
+	if !validComment(req.Comment) {
+		return invalidComment(), nil
+	}
 
 	// 构建 domain.BizConfig
 	bizConfig := domain.BizConfig{
@@ -90,68 +116,255 @@ func (h *BizConfigHandler) Save(ctx *gin.Context, req saveBizConfigReq) (pkggin.
 
 	// 转换 ChannelConfig
 	if req.ChannelConfig != nil {
-		channelItems := make([]domain.ChannelItem, len(req.ChannelConfig.Channels))
-		for i, item := range req.ChannelConfig.Channels {
-			channelItems[i] = domain.ChannelItem{
-				Channel:  item.Channel,
-				Priority: item.Priority,
-				Enabled:  item.Enabled,
-			}
-		}
 		bizConfig.ChannelConfig = &domain.ChannelConfig{
-			Channels: channelItems,
-			RetryPolicyConfig: &domain.RetryConfig{
-				InitialInterval: time.Duration(req.ChannelConfig.RetryPolicyConfig.InitialInterval) * time.Millisecond,
-				MaxInterval:     time.Duration(req.ChannelConfig.RetryPolicyConfig.MaxInterval) * time.Millisecond,
-				MaxRetryTimes:   req.ChannelConfig.RetryPolicyConfig.MaxRetryTimes,
-			},
+			Channels:          toDomainChannels(req.ChannelConfig.Channels),
+			RetryPolicyConfig: req.ChannelConfig.RetryPolicyConfig.toDomain(),
 		}
 	}
 
 	// 转换 Quota
 	if req.QuotaConfig != nil {
 		bizConfig.QuotaConfig = &domain.QuotaConfig{
-			Daily: &domain.Quota{
-				Sms:   req.QuotaConfig.Daily.Sms,
-				Email: req.QuotaConfig.Daily.Email,
-			},
-			Monthly: &domain.Quota{
-				Sms:   req.QuotaConfig.Monthly.Sms,
-				Email: req.QuotaConfig.Monthly.Email,
-			},
+			Daily:   req.QuotaConfig.Daily.toDomain(),
+			Monthly: req.QuotaConfig.Monthly.toDomain(),
 		}
 	}
 
 	// 转换 CallbackConfig
 	if req.CallbackConfig != nil {
 		bizConfig.CallbackConfig = &domain.CallbackConfig{
-			ServiceName: req.CallbackConfig.ServiceName,
-			RetryPolicyConfig: &domain.RetryConfig{
-				InitialInterval: time.Duration(req.CallbackConfig.RetryPolicyConfig.InitialInterval) * time.Millisecond,
-				MaxInterval:     time.Duration(req.CallbackConfig.RetryPolicyConfig.MaxInterval) * time.Millisecond,
-				MaxRetryTimes:   req.CallbackConfig.RetryPolicyConfig.MaxRetryTimes,
-			},
+			ServiceName:       req.CallbackConfig.ServiceName,
+			RetryPolicyConfig: req.CallbackConfig.RetryPolicyConfig.toDomain(),
 		}
 	}
 
-	_, err := h.svc.Save(ctx, bizConfig)
+	// 保存前查询当前配置，用于记录审计日志
+	var before any
+	current, err := h.svc.FindByBizId(ctx, req.BizId)
+	switch {
+	case err == nil:
+		before = current
+	case !errors.Is(err, errs.ErrRecordNotFound):
+		return pkggin.R{}, err
+	}
+
+	after, err := h.svc.Save(ctx, bizConfig, au.Uid, req.Comment)
 	if err != nil {
+		if errors.Is(err, errs.ErrInvalidBizConfig) {
+			return invalidBizConfig(err), nil
+		}
 		return pkggin.R{}, err
 	}
+
+	action := domain.AuditActionCreate
+	if before != nil {
+		action = domain.AuditActionUpdate
+	}
+	h.record(ctx, au, action, domain.AuditTargetBizConfig, req.BizId, before, after)
+
 	return pkggin.R{Code: http.StatusOK}, nil
 }
 
+// patchBizConfigReq 局部更新请求，只更新请求内出现的字段。
+// 字段未传时保持不变，字段传 null 时清空该字段，配置块内同样按字段处理（例如只传 quota_config.daily）。
+type patchBizConfigReq struct {
+	BizId          uint64                        `json:"biz_id"`
+	ChannelConfig  optional[patchChannelConfig]  `json:"channel_config"`
+	QuotaConfig    optional[patchQuotaConfig]    `json:"quota_config"`
+	CallbackConfig optional[patchCallbackConfig] `json:"callback_config"`
+	RateLimit      optional[int32]               `json:"rate_limit"`
+	Comment        string                        `json:"comment"`
+}
+
+type patchChannelConfig struct {
+	Channels          optional[[]channelItem] `json:"channels"`
+	RetryPolicyConfig optional[retryConfig]   `json:"retry_policy_config"`
+}
+
+type patchQuotaConfig struct {
+	Daily   optional[quota] `json:"daily"`
+	Monthly optional[quota] `json:"monthly"`
+}
+
+type patchCallbackConfig struct {
+	ServiceName       optional[string]      `json:"service_name"`
+	RetryPolicyConfig optional[retryConfig] `json:"retry_policy_config"`
+}
+
+// toDomain 转换为 domain.BizConfig，同时返回请求内出现的字段路径。
+func (r patchBizConfigReq) toDomain() (domain.BizConfig, []string) {
+	bizConfig := domain.BizConfig{BizId: r.BizId}
+	var paths []string
+
+	if r.RateLimit.Set {
+		paths = append(paths, "rate_limit")
+		if r.RateLimit.Val != nil {
+			bizConfig.RateLimit = *r.RateLimit.Val
+		}
+	}
+
+	if r.ChannelConfig.Set {
+		if cc := r.ChannelConfig.Val; cc == nil {
+			paths = append(paths, "channel_config")
+		} else {
+			bizConfig.ChannelConfig = &domain.ChannelConfig{}
+			if cc.Channels.Set {
+				paths = append(paths, "channel_config.channels")
+				if cc.Channels.Val != nil {
+					bizConfig.ChannelConfig.Channels = toDomainChannels(*cc.Channels.Val)
+				}
+			}
+			if cc.RetryPolicyConfig.Set {
+				paths = append(paths, "channel_config.retry_policy_config")
+				bizConfig.ChannelConfig.RetryPolicyConfig = cc.RetryPolicyConfig.Val.toDomain()
+			}
+		}
+	}
+
+	if r.QuotaConfig.Set {
+		if qc := r.QuotaConfig.Val; qc == nil {
+			paths = append(paths, "quota_config")
+		} else {
+			bizConfig.QuotaConfig = &domain.QuotaConfig{}
+			if qc.Daily.Set {
+				paths = append(paths, "quota_config.daily")
+				bizConfig.QuotaConfig.Daily = qc.Daily.Val.toDomain()
+			}
+			if qc.Monthly.Set {
+				paths = append(paths, "quota_config.monthly")
+				bizConfig.QuotaConfig.Monthly = qc.Monthly.Val.toDomain()
+			}
+		}
+	}
+
+	if r.CallbackConfig.Set {
+		if cc := r.CallbackConfig.Val; cc == nil {
+			paths = append(paths, "callback_config")
+		} else {
+			bizConfig.CallbackConfig = &domain.CallbackConfig{}
+			if cc.ServiceName.Set {
+				paths = append(paths, "callback_config.service_name")
+				if cc.ServiceName.Val != nil {
+					bizConfig.CallbackConfig.ServiceName = *cc.ServiceName.Val
+				}
+			}
+			if cc.RetryPolicyConfig.Set {
+				paths = append(paths, "callback_config.retry_policy_config")
+				bizConfig.CallbackConfig.RetryPolicyConfig = cc.RetryPolicyConfig.Val.toDomain()
+			}
+		}
+	}
+	return bizConfig, paths
+}
+
+func toDomainChannels(items []channelItem) []domain.ChannelItem {
+	res := make([]domain.ChannelItem, len(items))
+	for i, item := range items {
+		res[i] = domain.ChannelItem{
+			Channel:  item.Channel,
+			Priority: item.Priority,
+			Enabled:  item.Enabled,
+		}
+	}
+	return res
+}
+
+func (q *quota) toDomain() *domain.Quota {
+	if q == nil {
+		return nil
+	}
+	return &domain.Quota{
+		Sms:   q.Sms,
+		Email: q.Email,
+	}
+}
+
+func (c *retryConfig) toDomain() *domain.RetryConfig {
+	if c == nil {
+		return nil
+	}
+	return &domain.RetryConfig{
+		InitialInterval: c.InitialInterval,
+		MaxInterval:     c.MaxInterval,
+		MaxRetryTimes:   c.MaxRetryTimes,
+	}
+}
+
+// Patch 局部更新配置，返回更新后的配置。
+func (h *BizConfigHandler) Patch(ctx *gin.Context, req patchBizConfigReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if req.BizId == 0 {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "invalid biz_id, must be greater than 0",
+		}, nil
+	}
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only update own biz config",
+		}, nil
+	}
+
+	bizConfig, paths := req.toDomain()
+	if len(paths) == 0 {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "no field to update",
+		}, nil
+	}
+	if bizConfig.RateLimit < 0 {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "invalid rate_limit, must be greater than or equal to 0",
+		}, nil
+	}
+	if !validComment(req.Comment) {
+		return invalidComment(), nil
+	}
+
+	before, err := h.svc.FindByBizId(ctx, req.BizId)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return pkggin.R{
+				Code: http.StatusNotFound,
+				Msg:  "[kuryr-admin] biz config not found",
+			}, nil
+		}
+		return pkggin.R{}, err
+	}
+
+	after, err := h.svc.Patch(ctx, bizConfig, paths, au.Uid, req.Comment)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidBizConfig) {
+			return invalidBizConfig(err), nil
+		}
+		return pkggin.R{}, err
+	}
+	h.record(ctx, au, domain.AuditActionUpdate, domain.AuditTargetBizConfig, req.BizId, before, after)
+
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: after,
+	}, nil
+}
+
 type getBizConfigReq struct {
 	BizId uint64 `json:"biz_id" form:"biz_id"`
 }
 
-func (h *BizConfigHandler) Find(ctx *gin.Context, req getBizConfigReq) (pkggin.R, error) {
+func (h *BizConfigHandler) Find(ctx *gin.Context, req getBizConfigReq, au pkggin.AuthUser) (pkggin.R, error) {
 	if req.BizId == 0 {
 		return pkggin.R{
 			Code: http.StatusBadRequest,
 			Msg:  "invalid biz_id, must be greater than 0",
 		}, nil
 	}
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only find own biz config",
+		}, nil
+	}
 
 	bizConfig, err := h.svc.FindByBizId(ctx, req.BizId)
 	if err != nil {
@@ -166,8 +379,247 @@ func (h *BizConfigHandler) Find(ctx *gin.Context, req getBizConfigReq) (pkggin.R
 	}, nil
 }
 
-func NewBizConfigHandler(svc service.BizConfigService) *BizConfigHandler {
+type listBizConfigVersionsReq struct {
+	BizId uint64 `json:"biz_id" form:"biz_id"`
+	*pkggorm.PaginationParam
+}
+
+// ListVersions 按版本号倒序分页查询配置版本。
+func (h *BizConfigHandler) ListVersions(ctx *gin.Context, req listBizConfigVersionsReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only list own biz config versions",
+		}, nil
+	}
+
+	res, err := h.svc.ListVersions(ctx, req.BizId, req.PaginationParam)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: res,
+	}, nil
+}
+
+type findBizConfigVersionReq struct {
+	BizId   uint64 `json:"biz_id" form:"biz_id"`
+	Version uint64 `json:"version" form:"version"`
+}
+
+func (h *BizConfigHandler) FindVersion(ctx *gin.Context, req findBizConfigVersionReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only find own biz config version",
+		}, nil
+	}
+
+	v, err := h.svc.FindVersion(ctx, req.BizId, req.Version)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return versionNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: v,
+	}, nil
+}
+
+type diffBizConfigReq struct {
+	BizId uint64 `json:"biz_id" form:"biz_id"`
+	From  uint64 `json:"from" form:"from"`
+	To    uint64 `json:"to" form:"to"`
+}
+
+type diffBizConfigResp struct {
+	From    uint64                  `json:"from"`
+	To      uint64                  `json:"to"`
+	Changes map[string]audit.Change `json:"changes"` // key 为配置字段路径
+}
+
+// Diff 对比两个配置版本，返回从 from 到 to 发生变更的字段。
+func (h *BizConfigHandler) Diff(ctx *gin.Context, req diffBizConfigReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only diff own biz config versions",
+		}, nil
+	}
+
+	changes, err := h.svc.DiffVersions(ctx, req.BizId, req.From, req.To)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return versionNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: diffBizConfigResp{
+			From:    req.From,
+			To:      req.To,
+			Changes: changes,
+		},
+	}, nil
+}
+
+type rollbackBizConfigReq struct {
+	BizId   uint64 `json:"biz_id"`
+	Version uint64 `json:"version"`
+	Comment string `json:"comment"`
+}
+
+// Rollback 将配置回滚到指定版本，回滚后生成新的配置版本。
+func (h *BizConfigHandler) Rollback(ctx *gin.Context, req rollbackBizConfigReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only rollback own biz config",
+		}, nil
+	}
+	if !validComment(req.Comment) {
+		return invalidComment(), nil
+	}
+
+	before, err := h.svc.FindByBizId(ctx, req.BizId)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return pkggin.R{
+				Code: http.StatusNotFound,
+				Msg:  "[kuryr-admin] biz config not found",
+			}, nil
+		}
+		return pkggin.R{}, err
+	}
+
+	after, err := h.svc.Rollback(ctx, req.BizId, req.Version, au.Uid, req.Comment)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidBizConfig) {
+			return invalidBizConfig(err), nil
+		}
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return versionNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+	h.record(ctx, au, domain.AuditActionRollback, domain.AuditTargetBizConfig, req.BizId, before, after)
+
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: after,
+	}, nil
+}
+
+// SimulateRetry 按重试策略（渠道或回调）计算重试时间表与最坏情况下的投递延迟，用于保存前预览策略。
+func (h *BizConfigHandler) SimulateRetry(_ *gin.Context, req retryConfig) (pkggin.R, error) {
+	schedule, err := service.SimulateRetry(*req.toDomain())
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidBizConfig) {
+			return invalidBizConfig(err), nil
+		}
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: schedule,
+	}, nil
+}
+
+type probeCallbackReq struct {
+	BizId       uint64 `json:"biz_id" form:"biz_id"`
+	ServiceName string `json:"service_name" form:"service_name"` // 为空时探测业务方当前配置的回调服务
+}
+
+// ProbeCallback 解析回调服务并对每个实例执行 grpc 健康检查，用于保存回调配置前验证回调服务是否可用。
+// 操作员只能探测业务方当前配置的回调服务。
+func (h *BizConfigHandler) ProbeCallback(ctx *gin.Context, req probeCallbackReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only probe own biz callback",
+		}, nil
+	}
+
+	isAdmin := au.UserType == domain.UserTypeAdmin
+
+	var configured string
+	if req.BizId != 0 && (req.ServiceName == "" || !isAdmin) {
+		bizConfig, err := h.svc.FindByBizId(ctx, req.BizId)
+		if err != nil && !errors.Is(err, errs.ErrRecordNotFound) {
+			return pkggin.R{}, err
+		}
+		if bizConfig.CallbackConfig != nil {
+			configured = bizConfig.CallbackConfig.ServiceName
+		}
+	}
+
+	serviceName := cmp.Or(req.ServiceName, configured)
+	if serviceName == "" {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "invalid service_name, biz has no callback config",
+		}, nil
+	}
+	// 探测时使用 admin 的客户端证书连接回调服务实例，操作员只能探测业务方已配置的回调服务，避免借此探测注册中心内的其他服务
+	if !isAdmin && serviceName != configured {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only probe own biz callback service",
+		}, nil
+	}
+
+	res, err := h.probeSvc.Probe(ctx, serviceName)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: res,
+	}, nil
+}
+
+// canAccessBiz 管理员可以访问所有业务方，操作员只能访问自己的业务方。
+func canAccessBiz(au pkggin.AuthUser, bizId uint64) bool {
+	return au.UserType == domain.UserTypeAdmin || au.Bid == bizId
+}
+
+// invalidBizConfig 配置校验失败，返回所有不合法的字段。
+func invalidBizConfig(err error) pkggin.R {
+	return pkggin.R{
+		Code: http.StatusBadRequest,
+		Msg:  err.Error(),
+	}
+}
+
+// validComment 变更说明超过 biz_config_version.comment 列长度时配置版本无法写入，需要在保存配置之前拒绝
+func validComment(comment string) bool {
+	return utf8.RuneCountInString(comment) <= domain.MaxBizConfigVersionCommentLen
+}
+
+func invalidComment() pkggin.R {
+	return pkggin.R{
+		Code: http.StatusBadRequest,
+		Msg:  fmt.Sprintf("invalid comment, must not exceed %d characters", domain.MaxBizConfigVersionCommentLen),
+	}
+}
+
+func versionNotFound() pkggin.R {
+	return pkggin.R{
+		Code: http.StatusNotFound,
+		Msg:  "[kuryr-admin] biz config version not found",
+	}
+}
+
+func NewBizConfigHandler(
+	svc service.BizConfigService, probeSvc service.CallbackProbeService, auditSvc service.AuditService, logger *zap.Logger,
+) *BizConfigHandler {
 	return &BizConfigHandler{
-		svc: svc,
+		auditor:  newAuditor(auditSvc, logger),
+		svc:      svc,
+		probeSvc: probeSvc,
 	}
 }
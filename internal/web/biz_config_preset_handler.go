@@ -0,0 +1,284 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	pkggorm "github.com/JrMarcco/kuryr-admin/internal/pkg/gorm"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var _ pkggin.RouteRegistry = (*BizConfigPresetHandler)(nil)
+
+// BizConfigPresetHandler 业务方配置预设 web handler，只有管理员可以维护与应用预设。
+type BizConfigPresetHandler struct {
+	auditor
+	svc          service.BizConfigPresetService
+	bizConfigSvc service.BizConfigService
+}
+
+func (h *BizConfigPresetHandler) RegisterRoutes(engine *gin.Engine) {
+	v1 := engine.Group("/api/v1/biz_config_preset")
+
+	v1.Handle(http.MethodPost, "/save", pkggin.BU(h.Save))
+	v1.Handle(http.MethodDelete, "/delete", pkggin.QU(h.Delete))
+	v1.Handle(http.MethodGet, "/list", pkggin.Q(h.List))
+	v1.Handle(http.MethodGet, "/get", pkggin.Q(h.FindById))
+
+	v1.Handle(http.MethodPost, "/apply", pkggin.BU(h.Apply))
+	v1.Handle(http.MethodGet, "/overrides", pkggin.QU(h.Overrides))
+}
+
+type saveBizConfigPresetReq struct {
+	Id            uint64         `json:"id"`
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	ChannelConfig *channelConfig `json:"channel_config,omitempty"`
+	QuotaConfig   *quotaConfig   `json:"quota_config,omitempty"`
+	RateLimit     int32          `json:"rate_limit"`
+	IsDefault     bool           `json:"is_default"`
+}
+
+// Save 保存预设，id 为 0 时新建。
+func (h *BizConfigPresetHandler) Save(ctx *gin.Context, req saveBizConfigPresetReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if au.UserType != domain.UserTypeAdmin {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] only admin can save biz config preset",
+		}, nil
+	}
+	if req.Name == "" {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "invalid name, must not be empty",
+		}, nil
+	}
+
+	preset := domain.BizConfigPreset{
+		Id:          req.Id,
+		Name:        req.Name,
+		Description: req.Description,
+		RateLimit:   req.RateLimit,
+		IsDefault:   req.IsDefault,
+	}
+	if req.ChannelConfig != nil {
+		preset.ChannelConfig = &domain.ChannelConfig{
+			Channels:          toDomainChannels(req.ChannelConfig.Channels),
+			RetryPolicyConfig: req.ChannelConfig.RetryPolicyConfig.toDomain(),
+		}
+	}
+	if req.QuotaConfig != nil {
+		preset.QuotaConfig = &domain.QuotaConfig{
+			Daily:   req.QuotaConfig.Daily.toDomain(),
+			Monthly: req.QuotaConfig.Monthly.toDomain(),
+		}
+	}
+
+	var before any
+	if req.Id != 0 {
+		current, err := h.svc.FindById(ctx, req.Id)
+		if err != nil {
+			if errors.Is(err, errs.ErrRecordNotFound) {
+				return presetNotFound(), nil
+			}
+			return pkggin.R{}, err
+		}
+		before = current
+	}
+
+	saved, err := h.svc.Save(ctx, preset)
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrInvalidBizConfig):
+			return invalidBizConfig(err), nil
+		case errors.Is(err, errs.ErrPresetNameConflict):
+			return pkggin.R{
+				Code: http.StatusConflict,
+				Msg:  err.Error(),
+			}, nil
+		case errors.Is(err, errs.ErrRecordNotFound):
+			return presetNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+
+	action := domain.AuditActionCreate
+	if before != nil {
+		action = domain.AuditActionUpdate
+	}
+	h.record(ctx, au, action, domain.AuditTargetBizConfigPreset, saved.Id, before, saved)
+
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: saved,
+	}, nil
+}
+
+type bizConfigPresetIdReq struct {
+	Id uint64 `json:"id" form:"id"`
+}
+
+// Delete 删除预设，已基于该预设的业务方配置保持不变。
+func (h *BizConfigPresetHandler) Delete(ctx *gin.Context, req bizConfigPresetIdReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if au.UserType != domain.UserTypeAdmin {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] only admin can delete biz config preset",
+		}, nil
+	}
+
+	before, err := h.svc.FindById(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return presetNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+
+	if err = h.svc.Delete(ctx, req.Id); err != nil {
+		return pkggin.R{}, err
+	}
+	h.record(ctx, au, domain.AuditActionDelete, domain.AuditTargetBizConfigPreset, req.Id, before, nil)
+
+	return pkggin.R{Code: http.StatusOK}, nil
+}
+
+type listBizConfigPresetReq struct {
+	*pkggorm.PaginationParam
+}
+
+func (h *BizConfigPresetHandler) List(ctx *gin.Context, req listBizConfigPresetReq) (pkggin.R, error) {
+	res, err := h.svc.List(ctx, req.PaginationParam)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: res,
+	}, nil
+}
+
+func (h *BizConfigPresetHandler) FindById(ctx *gin.Context, req bizConfigPresetIdReq) (pkggin.R, error) {
+	preset, err := h.svc.FindById(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return presetNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: preset,
+	}, nil
+}
+
+type applyBizConfigPresetReq struct {
+	BizId    uint64 `json:"biz_id"`
+	PresetId uint64 `json:"preset_id"`
+}
+
+// Apply 将预设应用到已有业务方，覆盖业务方的渠道、配额与限流配置。
+func (h *BizConfigPresetHandler) Apply(ctx *gin.Context, req applyBizConfigPresetReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if au.UserType != domain.UserTypeAdmin {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] only admin can apply biz config preset",
+		}, nil
+	}
+	if req.BizId == 0 {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "invalid biz_id, must be greater than 0",
+		}, nil
+	}
+
+	preset, err := h.svc.FindById(ctx, req.PresetId)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return presetNotFound(), nil
+		}
+		return pkggin.R{}, err
+	}
+
+	var before any
+	current, err := h.bizConfigSvc.FindByBizId(ctx, req.BizId)
+	switch {
+	case err == nil:
+		before = current
+	case !errors.Is(err, errs.ErrRecordNotFound):
+		return pkggin.R{}, err
+	}
+
+	after, err := h.svc.Apply(ctx, req.BizId, preset, au.Uid)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidBizConfig) {
+			return invalidBizConfig(err), nil
+		}
+		return pkggin.R{}, err
+	}
+
+	action := domain.AuditActionCreate
+	if before != nil {
+		action = domain.AuditActionUpdate
+	}
+	h.record(ctx, au, action, domain.AuditTargetBizConfig, req.BizId, before, after)
+
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: after,
+	}, nil
+}
+
+type bizConfigPresetOverridesReq struct {
+	BizId uint64 `json:"biz_id" form:"biz_id"`
+}
+
+// Overrides 查询业务方当前配置相对于所基于预设的差异。
+func (h *BizConfigPresetHandler) Overrides(ctx *gin.Context, req bizConfigPresetOverridesReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only find own biz config preset overrides",
+		}, nil
+	}
+
+	res, err := h.svc.Overrides(ctx, req.BizId)
+	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return pkggin.R{
+				Code: http.StatusNotFound,
+				Msg:  "[kuryr-admin] biz config is not based on any preset",
+			}, nil
+		}
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: res,
+	}, nil
+}
+
+func presetNotFound() pkggin.R {
+	return pkggin.R{
+		Code: http.StatusNotFound,
+		Msg:  "[kuryr-admin] biz config preset not found",
+	}
+}
+
+func NewBizConfigPresetHandler(
+	svc service.BizConfigPresetService,
+	bizConfigSvc service.BizConfigService,
+	auditSvc service.AuditService,
+	logger *zap.Logger,
+) *BizConfigPresetHandler {
+	return &BizConfigPresetHandler{
+		auditor:      newAuditor(auditSvc, logger),
+		svc:          svc,
+		bizConfigSvc: bizConfigSvc,
+	}
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/JrMarcco/kuryr-admin/internal/domain"
@@ -10,12 +11,14 @@ import (
 	"github.com/JrMarcco/kuryr-admin/internal/search"
 	"github.com/JrMarcco/kuryr-admin/internal/service"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 var _ pkggin.RouteRegistry = (*BizInfoHandler)(nil)
 
 // BizInfoHandler 业务方信息 web handler。
 type BizInfoHandler struct {
+	auditor
 	svc service.BizService
 }
 
@@ -35,6 +38,7 @@ type createBizReq struct {
 	BizName      string `json:"biz_name"`
 	Contact      string `json:"contact"`
 	ContactEmail string `json:"contact_email"`
+	PresetId     uint64 `json:"preset_id"` // 初始化配置使用的预设，为 0 时使用默认预设
 }
 
 // Save 新建业务方信息。
@@ -54,10 +58,18 @@ func (h *BizInfoHandler) Save(ctx *gin.Context, req createBizReq, au pkggin.Auth
 		ContactEmail: req.ContactEmail,
 		CreatorId:    au.Uid,
 	}
-	bi, err := h.svc.Save(ctx, bi)
+	bi, err := h.svc.Save(ctx, bi, req.PresetId)
 	if err != nil {
+		if errors.Is(err, errs.ErrRecordNotFound) {
+			return pkggin.R{
+				Code: http.StatusBadRequest,
+				Msg:  "[kuryr-admin] biz config preset not found",
+			}, nil
+		}
 		return pkggin.R{}, err
 	}
+	h.record(ctx, au, domain.AuditActionCreate, domain.AuditTargetBizInfo, bi.Id, nil, bi)
+
 	return pkggin.R{
 		Code: http.StatusOK,
 		Data: bi,
@@ -79,16 +91,25 @@ func (h *BizInfoHandler) Update(ctx *gin.Context, req updateBizReq, au pkggin.Au
 		}, nil
 	}
 
+	before, err := h.svc.FindById(ctx, req.Id)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	// creator 为关联查询的展示信息，不参与审计对比
+	before.Creator = domain.SysUser{}
+
 	bi := domain.BizInfo{
 		Id:           req.Id,
 		BizName:      req.BizName,
 		Contact:      req.Contact,
 		ContactEmail: req.ContactEmail,
 	}
-	bi, err := h.svc.Update(ctx, bi)
+	bi, err = h.svc.Update(ctx, bi)
 	if err != nil {
 		return pkggin.R{}, err
 	}
+	h.record(ctx, au, domain.AuditActionUpdate, domain.AuditTargetBizInfo, bi.Id, before, bi)
+
 	return pkggin.R{
 		Code: http.StatusOK,
 		Data: bi,
@@ -106,10 +127,18 @@ func (h *BizInfoHandler) Delete(ctx *gin.Context, req deleteBizReq, au pkggin.Au
 			Msg:  "[kuryr-admin] only admin can delete biz",
 		}, nil
 	}
-	err := h.svc.Delete(ctx, req.BizId)
+	before, err := h.svc.FindById(ctx, req.BizId)
 	if err != nil {
 		return pkggin.R{}, err
 	}
+	before.Creator = domain.SysUser{}
+
+	err = h.svc.Delete(ctx, req.BizId)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	h.record(ctx, au, domain.AuditActionDelete, domain.AuditTargetBizInfo, req.BizId, before, nil)
+
 	return pkggin.R{Code: http.StatusOK}, nil
 }
 
@@ -169,6 +198,9 @@ func (h *BizInfoHandler) FindById(ctx *gin.Context, req findBizByIdReq, au pkggi
 	}, nil
 }
 
-func NewBizHandler(svc service.BizService) *BizInfoHandler {
-	return &BizInfoHandler{svc: svc}
+func NewBizHandler(svc service.BizService, auditSvc service.AuditService, logger *zap.Logger) *BizInfoHandler {
+	return &BizInfoHandler{
+		auditor: newAuditor(auditSvc, logger),
+		svc:     svc,
+	}
 }
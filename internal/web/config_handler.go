@@ -0,0 +1,66 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/JrMarcco/kuryr-admin/internal/config"
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var _ pkggin.RouteRegistry = (*ConfigHandler)(nil)
+
+// ConfigHandler 配置管理 web handler。
+type ConfigHandler struct {
+	auditor
+	reloader *config.Reloader
+	logger   *zap.Logger
+}
+
+func (h *ConfigHandler) RegisterRoutes(engine *gin.Engine) {
+	v1 := engine.Group("/api/v1/admin/config")
+
+	v1.Handle(http.MethodPost, "/reload", pkggin.WU(h.Reload))
+}
+
+// Reload 重新加载配置文件，只有支持热加载的配置项会生效。
+func (h *ConfigHandler) Reload(ctx *gin.Context, au pkggin.AuthUser) (pkggin.R, error) {
+	if au.UserType != domain.UserTypeAdmin {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] only admin can reload config",
+		}, nil
+	}
+
+	res, err := h.reloader.Reload()
+	if err != nil {
+		h.logger.Error("[kuryr-admin] failed to reload config", zap.Uint64("uid", au.Uid), zap.Error(err))
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	h.logger.Info("[kuryr-admin] config reloaded",
+		zap.Uint64("uid", au.Uid),
+		zap.Strings("applied", res.Applied),
+		zap.Strings("restart_required", res.RestartRequired),
+	)
+	h.record(ctx, au, domain.AuditActionReload, domain.AuditTargetConfig, 0, nil, res)
+
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: res,
+	}, nil
+}
+
+func NewConfigHandler(reloader *config.Reloader, auditSvc service.AuditService, logger *zap.Logger) *ConfigHandler {
+	return &ConfigHandler{
+		auditor:  newAuditor(auditSvc, logger),
+		reloader: reloader,
+		logger:   logger,
+	}
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/health"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
+	"github.com/gin-gonic/gin"
+)
+
+var _ pkggin.PublicRouteRegistry = (*HealthHandler)(nil)
+
+// HealthHandler 健康检查 web handler。
+//
+//	/healthz 存活检查，进程可以处理请求即返回成功。
+//	/readyz  就绪检查，所有依赖检查通过才返回成功，否则返回 503。
+type HealthHandler struct {
+	checkers []health.Checker
+	timeout  time.Duration
+}
+
+func (h *HealthHandler) RegisterRoutes(engine *gin.Engine) {
+	engine.Handle(http.MethodGet, "/healthz", pkggin.W(h.Liveness))
+	engine.Handle(http.MethodGet, "/readyz", pkggin.W(h.Readiness))
+}
+
+func (h *HealthHandler) PublicRoutes() []route.Route {
+	return []route.Route{
+		{Method: http.MethodGet, Path: "/healthz"},
+		{Method: http.MethodGet, Path: "/readyz"},
+	}
+}
+
+func (h *HealthHandler) Liveness(_ *gin.Context) (pkggin.R, error) {
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: health.Report{Status: health.StatusUp},
+	}, nil
+}
+
+func (h *HealthHandler) Readiness(ctx *gin.Context) (pkggin.R, error) {
+	report := health.Run(ctx, h.timeout, h.checkers)
+	if !report.IsUp() {
+		return pkggin.R{
+			Code: http.StatusServiceUnavailable,
+			Msg:  "not ready",
+			Data: report,
+		}, nil
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: report,
+	}, nil
+}
+
+func NewHealthHandler(checkers []health.Checker, timeout time.Duration) *HealthHandler {
+	return &HealthHandler{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
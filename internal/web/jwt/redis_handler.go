@@ -1,8 +1,10 @@
 package jwt
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +15,8 @@ import (
 
 var _ Handler = (*RedisHandler)(nil)
 
+const scanBatchSize = 100
+
 type RedisHandler struct {
 	rc         redis.Cmdable
 	expiration time.Duration
@@ -52,6 +56,47 @@ func (h *RedisHandler) ClearSession(ctx *gin.Context, sid string) error {
 	return h.rc.Del(ctx, h.redisKey(sid)).Err()
 }
 
+// ClearUserSessions 清理用户的所有 session，返回清理的 session 数量。
+// session 以 sid 作为 key，所以需要遍历所有 session 找出属于该用户的 key。
+func (h *RedisHandler) ClearUserSessions(ctx context.Context, uid uint64) (int, error) {
+	owner := strconv.FormatUint(uid, 10)
+
+	cleared := 0
+	var cursor uint64
+	for {
+		keys, next, err := h.rc.Scan(ctx, cursor, h.redisKey("*"), scanBatchSize).Result()
+		if err != nil {
+			return cleared, err
+		}
+
+		if len(keys) > 0 {
+			vals, err := h.rc.MGet(ctx, keys...).Result()
+			if err != nil {
+				return cleared, err
+			}
+
+			owned := make([]string, 0, len(keys))
+			for i, val := range vals {
+				if s, ok := val.(string); ok && s == owner {
+					owned = append(owned, keys[i])
+				}
+			}
+			if len(owned) > 0 {
+				n, err := h.rc.Del(ctx, owned...).Result()
+				if err != nil {
+					return cleared, err
+				}
+				cleared += int(n)
+			}
+		}
+
+		if next == 0 {
+			return cleared, nil
+		}
+		cursor = next
+	}
+}
+
 func (h *RedisHandler) redisKey(sid string) string {
 	return fmt.Sprintf("user:sid:%s", sid)
 }
@@ -0,0 +1,44 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisHandler_ClearUserSessions(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rc.Close() })
+
+	h := NewRedisHandler(rc, time.Hour)
+
+	ctx := context.Background()
+	// 超过一次 SCAN 的批量，覆盖多轮遍历
+	for i := range scanBatchSize + 10 {
+		require.NoError(t, rc.Set(ctx, h.redisKey(fmt.Sprintf("u1-%d", i)), 1, time.Hour).Err())
+	}
+	require.NoError(t, rc.Set(ctx, h.redisKey("u2"), 2, time.Hour).Err())
+	require.NoError(t, rc.Set(ctx, h.redisKey("u12"), 12, time.Hour).Err())
+	require.NoError(t, rc.Set(ctx, "other:1", 1, time.Hour).Err())
+
+	cleared, err := h.ClearUserSessions(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, scanBatchSize+10, cleared)
+
+	keys, err := rc.Keys(ctx, "*").Result()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{h.redisKey("u2"), h.redisKey("u12"), "other:1"}, keys)
+
+	cleared, err = h.ClearUserSessions(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, cleared)
+}
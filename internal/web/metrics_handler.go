@@ -0,0 +1,34 @@
+package web
+
+import (
+	"net/http"
+
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var _ pkggin.PublicRouteRegistry = (*MetricsHandler)(nil)
+
+// MetricsHandler prometheus 指标采集 web handler。
+type MetricsHandler struct {
+	gatherer prometheus.Gatherer
+}
+
+func (h *MetricsHandler) RegisterRoutes(engine *gin.Engine) {
+	engine.Handle(http.MethodGet, "/metrics", gin.WrapH(promhttp.HandlerFor(h.gatherer, promhttp.HandlerOpts{})))
+}
+
+func (h *MetricsHandler) PublicRoutes() []route.Route {
+	return []route.Route{
+		{Method: http.MethodGet, Path: "/metrics"},
+	}
+}
+
+func NewMetricsHandler(gatherer prometheus.Gatherer) *MetricsHandler {
+	return &MetricsHandler{
+		gatherer: gatherer,
+	}
+}
@@ -0,0 +1,17 @@
+package web
+
+import "encoding/json"
+
+// optional 可选的请求字段，用于区分字段未传与字段传 null。
+//
+//	字段未传：Set = false
+//	字段传 null：Set = true，Val = nil
+type optional[T any] struct {
+	Set bool
+	Val *T
+}
+
+func (o *optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Val)
+}
@@ -7,18 +7,20 @@ import (
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
 	"github.com/JrMarcco/kuryr-admin/internal/service"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 var _ pkggin.RouteRegistry = (*ProviderHandler)(nil)
 
 type ProviderHandler struct {
+	auditor
 	svc service.ProviderService
 }
 
 func (h *ProviderHandler) RegisterRoutes(engine *gin.Engine) {
 	v1 := engine.Group("/api/v1/provider")
 
-	v1.Handle(http.MethodPost, "/save", pkggin.B(h.Save))
+	v1.Handle(http.MethodPost, "/save", pkggin.BU(h.Save))
 	v1.Handle(http.MethodGet, "/list", pkggin.W(h.List))
 	v1.Handle(http.MethodGet, "/find_by_channel", pkggin.Q(h.FindByChannel))
 }
@@ -41,7 +43,7 @@ type saveProviderReq struct {
 	AuditCallbackUrl string `json:"audit_callback_url"`
 }
 
-func (h *ProviderHandler) Save(ctx *gin.Context, req saveProviderReq) (pkggin.R, error) {
+func (h *ProviderHandler) Save(ctx *gin.Context, req saveProviderReq, au pkggin.AuthUser) (pkggin.R, error) {
 	provider := domain.Provider{
 		ProviderName:     req.ProviderName,
 		Channel:          req.Channel,
@@ -56,10 +58,12 @@ func (h *ProviderHandler) Save(ctx *gin.Context, req saveProviderReq) (pkggin.R,
 		AuditCallbackUrl: req.AuditCallbackUrl,
 	}
 
-	_, err := h.svc.Save(ctx, provider)
+	provider, err := h.svc.Save(ctx, provider)
 	if err != nil {
 		return pkggin.R{}, err
 	}
+	h.record(ctx, au, domain.AuditActionCreate, domain.AuditTargetProvider, provider.Id, nil, provider)
+
 	return pkggin.R{Code: http.StatusOK}, nil
 }
 
@@ -97,8 +101,9 @@ func (h *ProviderHandler) FindByChannel(ctx *gin.Context, req findByChannelReq)
 	}, nil
 }
 
-func NewProviderHandler(svc service.ProviderService) *ProviderHandler {
+func NewProviderHandler(svc service.ProviderService, auditSvc service.AuditService, logger *zap.Logger) *ProviderHandler {
 	return &ProviderHandler{
-		svc: svc,
+		auditor: newAuditor(auditSvc, logger),
+		svc:     svc,
 	}
 }
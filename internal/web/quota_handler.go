@@ -0,0 +1,193 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JrMarcco/kuryr-admin/internal/domain"
+	"github.com/JrMarcco/kuryr-admin/internal/errs"
+	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var _ pkggin.RouteRegistry = (*QuotaHandler)(nil)
+
+// QuotaHandler 业务方配额用量与配额告警 web handler。
+type QuotaHandler struct {
+	auditor
+	usageSvc service.QuotaUsageService
+	alertSvc service.QuotaAlertService
+}
+
+func (h *QuotaHandler) RegisterRoutes(engine *gin.Engine) {
+	v1 := engine.Group("/api/v1/quota")
+
+	v1.Handle(http.MethodGet, "/usage", pkggin.QU(h.Usage))
+
+	v1.Handle(http.MethodGet, "/alert_rule/list", pkggin.QU(h.ListAlertRules))
+	v1.Handle(http.MethodPost, "/alert_rule/save", pkggin.BU(h.SaveAlertRule))
+	v1.Handle(http.MethodPost, "/alert_rule/delete", pkggin.BU(h.DeleteAlertRule))
+}
+
+type quotaUsageReq struct {
+	BizId uint64 `json:"biz_id" form:"biz_id"`
+}
+
+// Usage 查询业务方配置的配额、当前周期内的用量、剩余配额与预计耗尽时间。
+func (h *QuotaHandler) Usage(ctx *gin.Context, req quotaUsageReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if req.BizId == 0 {
+		return pkggin.R{
+			Code: http.StatusBadRequest,
+			Msg:  "invalid biz_id, must be greater than 0",
+		}, nil
+	}
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only view own quota usage",
+		}, nil
+	}
+
+	usage, err := h.usageSvc.Usage(ctx, req.BizId)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: usage,
+	}, nil
+}
+
+type listQuotaAlertRulesReq struct {
+	BizId uint64 `json:"biz_id" form:"biz_id"`
+}
+
+// ListAlertRules 查询业务方各渠道的配额告警阈值。
+func (h *QuotaHandler) ListAlertRules(ctx *gin.Context, req listQuotaAlertRulesReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only list own quota alert rules",
+		}, nil
+	}
+
+	rules, err := h.alertSvc.FindRules(ctx, req.BizId)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: rules,
+	}, nil
+}
+
+type saveQuotaAlertRuleReq struct {
+	BizId      uint64  `json:"biz_id"`
+	Channel    string  `json:"channel"`    // sms / email
+	Thresholds []int32 `json:"thresholds"` // 用量占配额的百分比，例如 [80, 100]
+	Enabled    bool    `json:"enabled"`
+}
+
+// SaveAlertRule 保存业务方单个渠道的配额告警阈值，已有阈值时覆盖。
+func (h *QuotaHandler) SaveAlertRule(ctx *gin.Context, req saveQuotaAlertRuleReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only update own quota alert rules",
+		}, nil
+	}
+
+	before, err := h.findAlertRule(ctx, req.BizId, req.Channel)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+
+	saved, err := h.alertSvc.SaveRule(ctx, domain.QuotaAlertRule{
+		BizId:      req.BizId,
+		Channel:    req.Channel,
+		Thresholds: req.Thresholds,
+		Enabled:    req.Enabled,
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidQuotaAlertRule) {
+			return pkggin.R{
+				Code: http.StatusBadRequest,
+				Msg:  err.Error(),
+			}, nil
+		}
+		return pkggin.R{}, err
+	}
+
+	if before == nil {
+		h.record(ctx, au, domain.AuditActionCreate, domain.AuditTargetQuotaAlertRule, saved.BizId, nil, saved)
+	} else {
+		h.record(ctx, au, domain.AuditActionUpdate, domain.AuditTargetQuotaAlertRule, saved.BizId, *before, saved)
+	}
+
+	return pkggin.R{
+		Code: http.StatusOK,
+		Data: saved,
+	}, nil
+}
+
+type deleteQuotaAlertRuleReq struct {
+	BizId   uint64 `json:"biz_id"`
+	Channel string `json:"channel"`
+}
+
+// DeleteAlertRule 删除业务方单个渠道的配额告警阈值。
+func (h *QuotaHandler) DeleteAlertRule(ctx *gin.Context, req deleteQuotaAlertRuleReq, au pkggin.AuthUser) (pkggin.R, error) {
+	if !canAccessBiz(au, req.BizId) {
+		return pkggin.R{
+			Code: http.StatusForbidden,
+			Msg:  "[kuryr-admin] operator can only delete own quota alert rules",
+		}, nil
+	}
+
+	before, err := h.findAlertRule(ctx, req.BizId, req.Channel)
+	if err != nil {
+		return pkggin.R{}, err
+	}
+	if before == nil {
+		return pkggin.R{
+			Code: http.StatusNotFound,
+			Msg:  "[kuryr-admin] quota alert rule not found",
+		}, nil
+	}
+
+	if err = h.alertSvc.DeleteRule(ctx, req.BizId, req.Channel); err != nil {
+		return pkggin.R{}, err
+	}
+	h.record(ctx, au, domain.AuditActionDelete, domain.AuditTargetQuotaAlertRule, req.BizId, *before, nil)
+
+	return pkggin.R{Code: http.StatusOK}, nil
+}
+
+// findAlertRule 查询业务方渠道当前的告警阈值，不存在时返回 nil
+func (h *QuotaHandler) findAlertRule(ctx *gin.Context, bizId uint64, channel string) (*domain.QuotaAlertRule, error) {
+	rules, err := h.alertSvc.FindRules(ctx, bizId)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Channel == channel {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func NewQuotaHandler(
+	usageSvc service.QuotaUsageService,
+	alertSvc service.QuotaAlertService,
+	auditSvc service.AuditService,
+	logger *zap.Logger,
+) *QuotaHandler {
+	return &QuotaHandler{
+		auditor:  newAuditor(auditSvc, logger),
+		usageSvc: usageSvc,
+		alertSvc: alertSvc,
+	}
+}
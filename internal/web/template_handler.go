@@ -7,18 +7,20 @@ import (
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
 	"github.com/JrMarcco/kuryr-admin/internal/service"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 var _ pkggin.RouteRegistry = (*TemplateHandler)(nil)
 
 type TemplateHandler struct {
+	auditor
 	svc service.TemplateService
 }
 
 func (h *TemplateHandler) RegisterRoutes(engine *gin.Engine) {
 	v1 := engine.Group("/api/v1/template")
 
-	v1.Handle(http.MethodPost, "/save", pkggin.B(h.Save))
+	v1.Handle(http.MethodPost, "/save", pkggin.BU(h.Save))
 }
 
 type saveTemplateReq struct {
@@ -30,7 +32,7 @@ type saveTemplateReq struct {
 	NotificationType int32  `json:"notification_type"`
 }
 
-func (h *TemplateHandler) Save(ctx *gin.Context, req saveTemplateReq) (pkggin.R, error) {
+func (h *TemplateHandler) Save(ctx *gin.Context, req saveTemplateReq, au pkggin.AuthUser) (pkggin.R, error) {
 	tpl := domain.ChannelTemplate{
 		BizId:            req.BizId,
 		BizType:          domain.BizType(req.BizType),
@@ -40,10 +42,11 @@ func (h *TemplateHandler) Save(ctx *gin.Context, req saveTemplateReq) (pkggin.R,
 		NotificationType: req.NotificationType,
 	}
 
-	_, err := h.svc.Save(ctx, tpl)
+	tpl, err := h.svc.Save(ctx, tpl)
 	if err != nil {
 		return pkggin.R{}, err
 	}
+	h.record(ctx, au, domain.AuditActionCreate, domain.AuditTargetTemplate, tpl.Id, nil, tpl)
 
 	return pkggin.R{Code: http.StatusOK}, nil
 }
@@ -73,8 +76,9 @@ func (h *TemplateHandler) SaveVersion(ctx *gin.Context, req saveVersionReq) (pkg
 	return pkggin.R{Code: http.StatusOK}, nil
 }
 
-func NewTemplateHandler(svc service.TemplateService) *TemplateHandler {
+func NewTemplateHandler(svc service.TemplateService, auditSvc service.AuditService, logger *zap.Logger) *TemplateHandler {
 	return &TemplateHandler{
-		svc: svc,
+		auditor: newAuditor(auditSvc, logger),
+		svc:     svc,
 	}
 }
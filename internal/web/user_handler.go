@@ -2,23 +2,27 @@ package web
 
 import (
 	"fmt"
-	"log"
 	"net/http"
 
 	"github.com/JrMarcco/kuryr-admin/internal/errs"
 	pkggin "github.com/JrMarcco/kuryr-admin/internal/pkg/gin"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/reqid"
+	"github.com/JrMarcco/kuryr-admin/internal/pkg/route"
 	"github.com/JrMarcco/kuryr-admin/internal/service"
 	"github.com/JrMarcco/kuryr-admin/internal/web/jwt"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
-var _ pkggin.RouteRegistry = (*UserHandler)(nil)
+var _ pkggin.PublicRouteRegistry = (*UserHandler)(nil)
 
 type UserHandler struct {
 	jwt.Handler
 	svc    service.UserService
 	logger *zap.Logger
+
+	loginCounter *prometheus.CounterVec
 }
 
 func (h *UserHandler) RegisterRoutes(engine *gin.Engine) {
@@ -29,6 +33,13 @@ func (h *UserHandler) RegisterRoutes(engine *gin.Engine) {
 	v1.Handle(http.MethodGet, "/logout", pkggin.W(h.Logout))
 }
 
+func (h *UserHandler) PublicRoutes() []route.Route {
+	return []route.Route{
+		{Method: http.MethodPost, Path: "/api/v1/user/login"},
+		{Method: http.MethodPost, Path: "/api/v1/user/refresh_token"},
+	}
+}
+
 type loginReq struct {
 	Account     string `json:"account"`
 	AccountType string `json:"account_type"`
@@ -44,8 +55,10 @@ type tokenResp struct {
 func (h *UserHandler) Login(ctx *gin.Context, req loginReq) (pkggin.R, error) {
 	au, err := h.svc.LoginWithType(ctx, req.Account, req.Credential, req.AccountType, req.VerifyType)
 	if err != nil {
+		h.loginCounter.WithLabelValues("failed").Inc()
 		return pkggin.R{}, err
 	}
+	h.loginCounter.WithLabelValues("succeeded").Inc()
 
 	// 创建 session
 	err = h.CreateSession(ctx, au.Sid, au.Uid)
@@ -87,7 +100,7 @@ func (h *UserHandler) RefreshToken(ctx *gin.Context, req refreshTokenReq) (pkggi
 	err = h.RefreshSession(ctx, au.Sid)
 	if err != nil {
 		// 刷新失败通常不应该中断整个流程，但需要记录日志
-		log.Printf("failed to refresh session: %v", err)
+		h.logger.Warn("failed to refresh session", zap.Error(err), reqid.Field(ctx))
 	}
 
 	// 重新生成 access token 和 refresh token
@@ -121,21 +134,34 @@ func (h *UserHandler) Logout(ctx *gin.Context) (pkggin.R, error) {
 		}, nil
 	}
 
-	go func() {
-		if err := h.ClearSession(ctx, au.Sid); err != nil {
-			h.logger.Error("failed to clear session", zap.Error(err))
-		}
-	}()
+	// 注意：
+	//	gin.Context 在请求结束后会被回收复用，且其 context 会随请求结束而取消，
+	//	所以这里不能在 goroutine 内异步清理 session。
+	if err := h.ClearSession(ctx, au.Sid); err != nil {
+		h.logger.Error("failed to clear session", zap.Error(err), reqid.Field(ctx))
+	}
 	return pkggin.R{
 		Code: http.StatusOK,
 		Msg:  "logged out",
 	}, nil
 }
 
-func NewUserHandler(handler jwt.Handler, svc service.UserService, logger *zap.Logger) *UserHandler {
+func NewUserHandler(
+	handler jwt.Handler, svc service.UserService, logger *zap.Logger, reg prometheus.Registerer,
+) *UserHandler {
+	loginCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kuryr_admin",
+		Subsystem: "user",
+		Name:      "login_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+	reg.MustRegister(loginCounter)
+
 	return &UserHandler{
 		Handler: handler,
 		svc:     svc,
 		logger:  logger,
+
+		loginCounter: loginCounter,
 	}
 }